@@ -0,0 +1,90 @@
+package akp
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/akuity/terraform-provider-akp/akp/kube"
+)
+
+// Paths of the agent customization defaults in the Argo CD and Kargo instance
+// specs sent to and exported from the API.
+var (
+	argoCDCustomizationDefaultsPath = []string{"spec", "instanceSpec", "clusterCustomizationDefaults"}
+	kargoCustomizationDefaultsPath  = []string{"spec", "kargoInstanceSpec", "agentCustomizationDefaults"}
+)
+
+// addImageRewriteDefaults moves the image_registry_mirror and
+// image_pull_secrets of the agent customization defaults at path into their
+// kustomization. The platform has no fields for them, and generates the
+// manifests of new agents with the kustomization of the defaults.
+func addImageRewriteDefaults(spec map[string]any, manifests []byte, path ...string) error {
+	defaults := nestedMap(spec, path...)
+	if defaults == nil {
+		return nil
+	}
+	rewrite := kube.ImageRewrite{}
+	rewrite.RegistryMirror, _ = defaults["imageRegistryMirror"].(string)
+	secrets, _ := defaults["imagePullSecrets"].([]any)
+	for _, s := range secrets {
+		if name, ok := s.(string); ok {
+			rewrite.PullSecrets = append(rewrite.PullSecrets, name)
+		}
+	}
+	delete(defaults, "imageRegistryMirror")
+	delete(defaults, "imagePullSecrets")
+	if rewrite.RegistryMirror == "" && len(rewrite.PullSecrets) == 0 {
+		return nil
+	}
+
+	images, err := kube.AgentImages(manifests)
+	if err != nil {
+		return fmt.Errorf("unable to list the agent images: %w", err)
+	}
+	kustomization, _ := defaults["kustomization"].(map[string]any)
+	defaults["kustomization"] = rewrite.AddToKustomization(kustomization, images)
+	return nil
+}
+
+// takeImageRewriteDefaults is the reverse of addImageRewriteDefaults for an
+// exported spec. It returns spec itself when the kustomization holds no
+// image rewrite, and a modified copy otherwise.
+func takeImageRewriteDefaults(spec *structpb.Struct, manifests []byte, path ...string) (*structpb.Struct, error) {
+	specMap := spec.AsMap()
+	defaults := nestedMap(specMap, path...)
+	kustomization, _ := defaults["kustomization"].(map[string]any)
+	if kustomization == nil {
+		return spec, nil
+	}
+	images, err := kube.AgentImages(manifests)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the agent images: %w", err)
+	}
+	rewrite := kube.TakeImageRewrite(kustomization, images)
+	if rewrite.RegistryMirror == "" && len(rewrite.PullSecrets) == 0 {
+		return spec, nil
+	}
+	if rewrite.RegistryMirror != "" {
+		defaults["imageRegistryMirror"] = rewrite.RegistryMirror
+	}
+	if len(rewrite.PullSecrets) > 0 {
+		secrets := make([]any, 0, len(rewrite.PullSecrets))
+		for _, name := range rewrite.PullSecrets {
+			secrets = append(secrets, name)
+		}
+		defaults["imagePullSecrets"] = secrets
+	}
+	return structpb.NewStruct(specMap)
+}
+
+func nestedMap(m map[string]any, path ...string) map[string]any {
+	for _, key := range path {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			return nil
+		}
+		m = next
+	}
+	return m
+}
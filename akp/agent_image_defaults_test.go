@@ -0,0 +1,51 @@
+//go:build !acc
+
+package akp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/akuity/terraform-provider-akp/akp/kube"
+)
+
+func TestImageRewriteDefaults(t *testing.T) {
+	spec := map[string]any{
+		"spec": map[string]any{"kargoInstanceSpec": map[string]any{"agentCustomizationDefaults": map[string]any{
+			"autoUpgradeDisabled": true,
+			"imageRegistryMirror": "mirror.example.com",
+			"imagePullSecrets":    []any{"creds"},
+		}}},
+	}
+	require.NoError(t, addImageRewriteDefaults(spec, kube.KargoAgentManifests, kargoCustomizationDefaultsPath...))
+
+	defaults := nestedMap(spec, kargoCustomizationDefaultsPath...)
+	assert.NotContains(t, defaults, "imageRegistryMirror")
+	assert.NotContains(t, defaults, "imagePullSecrets")
+	kustomization := defaults["kustomization"].(map[string]any)
+	assert.Len(t, kustomization["images"], 3)
+	// One pull secrets patch for each location of the pod spec.
+	assert.Len(t, kustomization["patches"], 3)
+
+	exported, err := structpb.NewStruct(spec)
+	require.NoError(t, err)
+	taken, err := takeImageRewriteDefaults(exported, kube.KargoAgentManifests, kargoCustomizationDefaultsPath...)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"autoUpgradeDisabled": true,
+		"imageRegistryMirror": "mirror.example.com",
+		"imagePullSecrets":    []any{"creds"},
+		"kustomization":       map[string]any{},
+	}, nestedMap(taken.AsMap(), kargoCustomizationDefaultsPath...))
+	// The exported spec may be cached, so it is left as is.
+	assert.Contains(t, nestedMap(exported.AsMap(), kargoCustomizationDefaultsPath...)["kustomization"], "images")
+
+	unchanged, err := structpb.NewStruct(map[string]any{"spec": map[string]any{}})
+	require.NoError(t, err)
+	taken, err = takeImageRewriteDefaults(unchanged, kube.ArgoCDAgentManifests, argoCDCustomizationDefaultsPath...)
+	require.NoError(t, err)
+	assert.Same(t, unchanged, taken)
+}
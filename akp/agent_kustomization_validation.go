@@ -0,0 +1,78 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/akuity/terraform-provider-akp/akp/kube"
+)
+
+// allowUnmatchedPatchTargetsPath is the attribute that turns unmatched patch
+// targets from errors into warnings.
+var allowUnmatchedPatchTargetsPath = path.Root("allow_unmatched_patch_targets")
+
+// validateAgentKustomization builds kustomization in process on top of a
+// representative set of agent manifests. The platform only builds it when it
+// reconciles the agent, so without this a bad patch surfaces minutes into an
+// apply as a failed reconciliation. Build failures are reported as errors on
+// attrPath, and so are patch targets that match no agent object, which the
+// platform silently skips. As the representative set may not list every object
+// a kustomization legitimately targets, allowUnmatched turns the latter into
+// warnings.
+func validateAgentKustomization(
+	diagnostics *diag.Diagnostics,
+	attrPath path.Path,
+	kustomization tftypes.String,
+	namespace tftypes.String,
+	allowUnmatched tftypes.Bool,
+	manifests []byte,
+) {
+	if kustomization.IsNull() || kustomization.IsUnknown() || kustomization.ValueString() == "" {
+		return
+	}
+	unmatched, err := kube.ValidateKustomization(kustomization.ValueString(), manifests, namespace.ValueString())
+	for _, target := range unmatched {
+		// An unknown opt-out is resolved at apply time; until then the
+		// targets are not held against the plan.
+		if allowUnmatched.ValueBool() || allowUnmatched.IsUnknown() {
+			diagnostics.AddAttributeWarning(attrPath, "Kustomization patch matches no agent object", target)
+			continue
+		}
+		diagnostics.AddAttributeError(attrPath, "Kustomization patch matches no agent object",
+			fmt.Sprintf("%s. Fix the target, or set allow_unmatched_patch_targets to true if it matches an object that the representative agent manifests do not include.", target))
+	}
+	if err != nil {
+		diagnostics.AddAttributeError(attrPath, "Invalid kustomization", err.Error())
+	}
+}
+
+// agentKustomizationValidator validates the instance-level agent
+// customization defaults, which have no other config validator to hook into.
+type agentKustomizationValidator struct {
+	kustomization path.Path
+	manifests     []byte
+}
+
+func (v agentKustomizationValidator) Description(context.Context) string {
+	return "Validates that the agent kustomization builds against the agent manifests"
+}
+
+func (v agentKustomizationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v agentKustomizationValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var kustomization tftypes.String
+	var allowUnmatched tftypes.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.kustomization, &kustomization)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, allowUnmatchedPatchTargetsPath, &allowUnmatched)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateAgentKustomization(&resp.Diagnostics, v.kustomization, kustomization, tftypes.StringNull(), allowUnmatched, v.manifests)
+}
@@ -5,11 +5,60 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
 	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
 )
 
+// authCtx returns ctx with the API credentials and the provider's request
+// policy attached, having resolved the organization the provider is
+// configured for. Failures are reported in diagnostics.
+func (c *AkpCli) authCtx(ctx context.Context, diagnostics *diag.Diagnostics) context.Context {
+	ctx = withRequestPolicy(ctx, c.policy)
+	ctx = httpctx.SetAuthorizationHeader(ctx, c.Cred.Scheme(), c.Cred.Credential())
+	if err := c.resolveOrg(ctx); err != nil {
+		diagnostics.AddError(
+			"Unable to Look Up Akuity Platform Organization",
+			fmt.Sprintf("Unable to get the ID of organization %q. Check the org_name and the API key of the provider.\n\n"+
+				"Akuity Platform Client Error: %s", c.orgName, err),
+		)
+	}
+	return ctx
+}
+
+// resolveOrg looks up OrgId from the organization name the first time the
+// provider calls the API. A failed lookup is retried on the next call.
+func (c *AkpCli) resolveOrg(ctx context.Context) error {
+	c.orgMu.Lock()
+	defer c.orgMu.Unlock()
+	if c.OrgId != "" {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Getting Organization ID by name", map[string]any{"org_name": c.orgName})
+	// The Akuity Platform API sits behind a gateway that intermittently returns
+	// 502/503/504 (surfaced by the gateway client as codes.Unavailable). A single
+	// blip on this lookup fails every resource that needs it. Retry the transient
+	// case with backoff so a momentary hiccup no longer fails a run.
+	res, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.GetOrganizationResponse, error) {
+		return c.OrgCli.GetOrganization(ctx, &orgcv1.GetOrganizationRequest{
+			Id:     c.orgName,
+			IdType: idv1.Type_NAME,
+		})
+	}, "get organization by name")
+	if err != nil {
+		return err
+	}
+	c.OrgId = res.GetOrganization().GetId()
+	tflog.Info(ctx, "Connection successful", map[string]any{"org_id": c.OrgId})
+	return nil
+}
+
 // BaseResource provides shared Configure and auth context injection for all resources.
 // Embed this in resource structs to eliminate Configure boilerplate.
 type BaseResource struct {
@@ -32,8 +81,8 @@ func (b *BaseResource) Configure(_ context.Context, req resource.ConfigureReques
 	b.akpCli = akpCli
 }
 
-func (b *BaseResource) AuthCtx(ctx context.Context) context.Context {
-	return httpctx.SetAuthorizationHeader(ctx, b.akpCli.Cred.Scheme(), b.akpCli.Cred.Credential())
+func (b *BaseResource) AuthCtx(ctx context.Context, diagnostics *diag.Diagnostics) context.Context {
+	return b.akpCli.authCtx(ctx, diagnostics)
 }
 
 type BaseDataSource struct {
@@ -56,6 +105,30 @@ func (b *BaseDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 	b.akpCli = akpCli
 }
 
-func (b *BaseDataSource) AuthCtx(ctx context.Context) context.Context {
-	return httpctx.SetAuthorizationHeader(ctx, b.akpCli.Cred.Scheme(), b.akpCli.Cred.Credential())
+func (b *BaseDataSource) AuthCtx(ctx context.Context, diagnostics *diag.Diagnostics) context.Context {
+	return b.akpCli.authCtx(ctx, diagnostics)
+}
+
+type BaseEphemeralResource struct {
+	akpCli *AkpCli
+}
+
+func (b *BaseEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	akpCli, ok := req.ProviderData.(*AkpCli)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *AkpCli, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	b.akpCli = akpCli
+}
+
+func (b *BaseEphemeralResource) AuthCtx(ctx context.Context, diagnostics *diag.Diagnostics) context.Context {
+	return b.akpCli.authCtx(ctx, diagnostics)
 }
@@ -0,0 +1,85 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/akuity/api-client-go/pkg/api/argocdexport"
+	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+)
+
+// invalidatingArgoCDClient invalidates the cached reads of an instance
+// whenever it is written to.
+type invalidatingArgoCDClient struct {
+	argocdv1.ArgoCDServiceGatewayClient
+	reads *readCache
+}
+
+func (c *invalidatingArgoCDClient) ApplyInstance(ctx context.Context, req *argocdv1.ApplyInstanceRequest) (*argocdv1.ApplyInstanceResponse, error) {
+	defer c.reads.invalidate(req.GetId())
+	return c.ArgoCDServiceGatewayClient.ApplyInstance(ctx, req)
+}
+
+func (c *invalidatingArgoCDClient) PatchInstance(ctx context.Context, req *argocdv1.PatchInstanceRequest) (*argocdv1.PatchInstanceResponse, error) {
+	defer c.reads.invalidate(req.GetId())
+	return c.ArgoCDServiceGatewayClient.PatchInstance(ctx, req)
+}
+
+func (c *invalidatingArgoCDClient) DeleteInstance(ctx context.Context, req *argocdv1.DeleteInstanceRequest) (*argocdv1.DeleteInstanceResponse, error) {
+	defer c.reads.invalidate(req.GetId())
+	return c.ArgoCDServiceGatewayClient.DeleteInstance(ctx, req)
+}
+
+func (c *invalidatingArgoCDClient) DeleteInstanceCluster(ctx context.Context, req *argocdv1.DeleteInstanceClusterRequest) (*argocdv1.DeleteInstanceClusterResponse, error) {
+	defer c.reads.invalidate(req.GetInstanceId())
+	return c.ArgoCDServiceGatewayClient.DeleteInstanceCluster(ctx, req)
+}
+
+// cachingOrgClient serves ListWorkspaces from the read cache, and invalidates
+// it whenever a workspace is written to.
+type cachingOrgClient struct {
+	orgcv1.OrganizationServiceGatewayClient
+	reads *readCache
+}
+
+func (c *cachingOrgClient) ListWorkspaces(ctx context.Context, req *orgcv1.ListWorkspacesRequest) (*orgcv1.ListWorkspacesResponse, error) {
+	return cachedRead(ctx, c.reads, workspacesScope, "ListWorkspaces/"+req.GetOrganizationId(), func(ctx context.Context) (*orgcv1.ListWorkspacesResponse, error) {
+		return c.OrganizationServiceGatewayClient.ListWorkspaces(ctx, req)
+	})
+}
+
+func (c *cachingOrgClient) CreateWorkspace(ctx context.Context, req *orgcv1.CreateWorkspaceRequest) (*orgcv1.CreateWorkspaceResponse, error) {
+	defer c.reads.invalidate(workspacesScope)
+	return c.OrganizationServiceGatewayClient.CreateWorkspace(ctx, req)
+}
+
+func (c *cachingOrgClient) UpdateWorkspace(ctx context.Context, req *orgcv1.UpdateWorkspaceRequest) (*orgcv1.UpdateWorkspaceResponse, error) {
+	defer c.reads.invalidate(workspacesScope)
+	return c.OrganizationServiceGatewayClient.UpdateWorkspace(ctx, req)
+}
+
+func (c *cachingOrgClient) DeleteWorkspace(ctx context.Context, req *orgcv1.DeleteWorkspaceRequest) (*orgcv1.DeleteWorkspaceResponse, error) {
+	defer c.reads.invalidate(workspacesScope)
+	return c.OrganizationServiceGatewayClient.DeleteWorkspace(ctx, req)
+}
+
+// getInstance is GetInstance served from the read cache. It must not be used
+// to poll an instance, as the response only changes when the provider itself
+// writes to the instance.
+func (c *AkpCli) getInstance(ctx context.Context, req *argocdv1.GetInstanceRequest) (*argocdv1.GetInstanceResponse, error) {
+	resp, err := cachedRead(ctx, c.reads, req.GetId(), "GetInstance/"+req.GetIdType().String(), func(ctx context.Context) (*argocdv1.GetInstanceResponse, error) {
+		return c.Cli.GetInstance(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.reads.alias(resp.GetInstance().GetId(), resp.GetInstance().GetName())
+	return resp, nil
+}
+
+// exportInstance is ExportInstance served from the read cache.
+func (c *AkpCli) exportInstance(ctx context.Context, req *argocdv1.ExportInstanceRequest) (*argocdv1.ExportInstanceResponse, error) {
+	return cachedRead(ctx, c.reads, req.GetId(), "ExportInstance/"+req.GetWorkspaceId(), func(ctx context.Context) (*argocdv1.ExportInstanceResponse, error) {
+		return argocdexport.ExportInstance(ctx, c.Cli, req)
+	})
+}
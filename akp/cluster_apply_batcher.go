@@ -0,0 +1,180 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
+)
+
+const (
+	// clusterApplyBatchWindow is how long the first cluster apply of an
+	// instance waits for others to join its ApplyInstance request.
+	clusterApplyBatchWindow = time.Second
+	// clusterApplyBatchMaxSize sends a batch right away once it carries this
+	// many clusters.
+	clusterApplyBatchMaxSize = 50
+)
+
+type applyInstanceFunc func(ctx context.Context, req *argocdv1.ApplyInstanceRequest) (*argocdv1.ApplyInstanceResponse, error)
+
+// clusterApplyBatcher coalesces the ApplyInstance requests of cluster creates
+// and updates that target the same instance within a short window into a
+// single request. Applying many clusters at once otherwise produces as many
+// instance-level writes, which contend with each other and fail with
+// Aborted or Unavailable.
+type clusterApplyBatcher struct {
+	apply   applyInstanceFunc
+	window  time.Duration
+	maxSize int
+
+	mu      sync.Mutex
+	pending map[string]*clusterApplyBatch
+}
+
+type clusterApplyBatch struct {
+	// ctx carries the authorization header of the first caller.
+	ctx     context.Context
+	req     *argocdv1.ApplyInstanceRequest
+	entries []*clusterApplyEntry
+	timer   *time.Timer
+}
+
+type clusterApplyEntry struct {
+	// ctx is the caller's context, which bounds the entry's share of the
+	// batched request and its own request if the batch is split.
+	ctx      context.Context
+	clusters []*structpb.Struct
+	done     chan struct{}
+	resp     *argocdv1.ApplyInstanceResponse
+	err      error
+}
+
+func newClusterApplyBatcher(apply applyInstanceFunc, window time.Duration, maxSize int) *clusterApplyBatcher {
+	return &clusterApplyBatcher{
+		apply:   apply,
+		window:  window,
+		maxSize: maxSize,
+		pending: map[string]*clusterApplyBatch{},
+	}
+}
+
+// applyClusters sends an ApplyInstance request that carries clusters, batched
+// with the concurrent cluster applies on the same instance.
+func (c *AkpCli) applyClusters(ctx context.Context, req *argocdv1.ApplyInstanceRequest) (*argocdv1.ApplyInstanceResponse, error) {
+	if c.clusterApplies == nil {
+		return c.Cli.ApplyInstance(ctx, req)
+	}
+	return c.clusterApplies.Apply(ctx, req)
+}
+
+// Apply sends the clusters of req as part of the next ApplyInstance request
+// for its instance and returns the outcome for those clusters.
+func (b *clusterApplyBatcher) Apply(ctx context.Context, req *argocdv1.ApplyInstanceRequest) (*argocdv1.ApplyInstanceResponse, error) {
+	entry := &clusterApplyEntry{ctx: ctx, clusters: req.GetClusters(), done: make(chan struct{})}
+	key := fmt.Sprintf("%s/%s/%s", req.GetOrganizationId(), req.GetIdType(), req.GetId())
+
+	b.mu.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &clusterApplyBatch{ctx: context.WithoutCancel(ctx), req: req}
+		b.pending[key] = batch
+		batch.timer = time.AfterFunc(b.window, func() { b.flush(key, batch) })
+	}
+	batch.entries = append(batch.entries, entry)
+	if len(batch.entries) >= b.maxSize {
+		batch.timer.Stop()
+		go b.flush(key, batch)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-entry.done:
+		return entry.resp, entry.err
+	case <-ctx.Done():
+		b.remove(key, batch, entry)
+		return nil, ctx.Err()
+	}
+}
+
+// remove drops entry from batch if the batch has not been sent yet.
+func (b *clusterApplyBatcher) remove(key string, batch *clusterApplyBatch, entry *clusterApplyEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pending[key] != batch {
+		return
+	}
+	for i, e := range batch.entries {
+		if e == entry {
+			batch.entries = append(batch.entries[:i], batch.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+func (b *clusterApplyBatcher) flush(key string, batch *clusterApplyBatch) {
+	b.mu.Lock()
+	if b.pending[key] != batch {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.pending, key)
+	entries := batch.entries
+	b.mu.Unlock()
+	if len(entries) == 0 {
+		return
+	}
+
+	ctx, cancel := batchContext(batch.ctx, entries)
+	defer cancel()
+
+	req := proto.Clone(batch.req).(*argocdv1.ApplyInstanceRequest)
+	req.Clusters = nil
+	for _, e := range entries {
+		req.Clusters = append(req.Clusters, e.clusters...)
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Applying %d clusters to instance %s in one request", len(req.Clusters), req.GetId()))
+	resp, err := b.apply(ctx, req)
+
+	// A request the API rejects fails every cluster in it. Apply them one by
+	// one instead, so that only the callers of the offending clusters see the
+	// error. Transient errors are returned as-is, for each caller to retry.
+	if err != nil && len(entries) > 1 && !isRetryableError(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Batched apply to instance %s failed, applying clusters one by one: %s", req.GetId(), err))
+		for _, e := range entries {
+			single := proto.Clone(batch.req).(*argocdv1.ApplyInstanceRequest)
+			single.Clusters = e.clusters
+			e.resp, e.err = b.apply(e.ctx, single)
+			close(e.done)
+		}
+		return
+	}
+
+	for _, e := range entries {
+		e.resp, e.err = resp, err
+		close(e.done)
+	}
+}
+
+// batchContext returns the context of a batched request. The request outlives
+// the caller that started the batch, so it runs until the latest deadline of
+// the callers that joined it, or without a deadline if one of them has none.
+func batchContext(ctx context.Context, entries []*clusterApplyEntry) (context.Context, context.CancelFunc) {
+	var latest time.Time
+	for _, e := range entries {
+		deadline, ok := e.ctx.Deadline()
+		if !ok {
+			return context.WithCancel(ctx)
+		}
+		if deadline.After(latest) {
+			latest = deadline
+		}
+	}
+	return context.WithDeadline(ctx, latest)
+}
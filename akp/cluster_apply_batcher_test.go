@@ -0,0 +1,211 @@
+//go:build !acc
+
+package akp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
+	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
+)
+
+type recordingApplier struct {
+	mu       sync.Mutex
+	requests [][]string
+	// fail rejects any request that carries this cluster.
+	fail string
+}
+
+func (r *recordingApplier) apply(_ context.Context, req *argocdv1.ApplyInstanceRequest) (*argocdv1.ApplyInstanceResponse, error) {
+	var names []string
+	for _, c := range req.GetClusters() {
+		names = append(names, c.GetFields()["name"].GetStringValue())
+	}
+	r.mu.Lock()
+	r.requests = append(r.requests, names)
+	r.mu.Unlock()
+	for _, name := range names {
+		if name == r.fail {
+			return nil, status.Error(codes.InvalidArgument, "invalid cluster "+name)
+		}
+	}
+	return &argocdv1.ApplyInstanceResponse{}, nil
+}
+
+func clusterApplyRequest(t *testing.T, instanceID, name string) *argocdv1.ApplyInstanceRequest {
+	cluster, err := structpb.NewStruct(map[string]any{"name": name})
+	require.NoError(t, err)
+	return &argocdv1.ApplyInstanceRequest{
+		OrganizationId: "org",
+		IdType:         idv1.Type_ID,
+		Id:             instanceID,
+		Clusters:       []*structpb.Struct{cluster},
+	}
+}
+
+func applyConcurrently(t *testing.T, b *clusterApplyBatcher, reqs ...*argocdv1.ApplyInstanceRequest) []error {
+	errs := make([]error, len(reqs))
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = b.Apply(context.Background(), req)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestClusterApplyBatcherCoalescesRequests(t *testing.T) {
+	applier := &recordingApplier{}
+	b := newClusterApplyBatcher(applier.apply, 100*time.Millisecond, clusterApplyBatchMaxSize)
+
+	errs := applyConcurrently(t, b,
+		clusterApplyRequest(t, "inst-1", "a"),
+		clusterApplyRequest(t, "inst-1", "b"),
+		clusterApplyRequest(t, "inst-1", "c"),
+		clusterApplyRequest(t, "inst-2", "d"),
+	)
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	require.Len(t, applier.requests, 2)
+	var sizes []int
+	for _, names := range applier.requests {
+		sizes = append(sizes, len(names))
+	}
+	assert.ElementsMatch(t, []int{3, 1}, sizes)
+}
+
+func TestClusterApplyBatcherIsolatesRejectedCluster(t *testing.T) {
+	applier := &recordingApplier{fail: "b"}
+	b := newClusterApplyBatcher(applier.apply, 100*time.Millisecond, clusterApplyBatchMaxSize)
+
+	errs := applyConcurrently(t, b,
+		clusterApplyRequest(t, "inst-1", "a"),
+		clusterApplyRequest(t, "inst-1", "b"),
+		clusterApplyRequest(t, "inst-1", "c"),
+	)
+
+	assert.NoError(t, errs[0])
+	assert.Equal(t, codes.InvalidArgument, status.Code(errs[1]))
+	assert.NoError(t, errs[2])
+	// The batched request, then one request per cluster.
+	assert.Len(t, applier.requests, 4)
+}
+
+func TestClusterApplyBatcherSharesRetryableError(t *testing.T) {
+	var calls int
+	apply := func(context.Context, *argocdv1.ApplyInstanceRequest) (*argocdv1.ApplyInstanceResponse, error) {
+		calls++
+		return nil, status.Error(codes.Aborted, "conflict")
+	}
+	b := newClusterApplyBatcher(apply, 100*time.Millisecond, clusterApplyBatchMaxSize)
+
+	errs := applyConcurrently(t, b,
+		clusterApplyRequest(t, "inst-1", "a"),
+		clusterApplyRequest(t, "inst-1", "b"),
+	)
+
+	for _, err := range errs {
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestClusterApplyBatcherFlushesFullBatch(t *testing.T) {
+	applier := &recordingApplier{}
+	b := newClusterApplyBatcher(applier.apply, time.Hour, 2)
+
+	done := make(chan []error)
+	go func() {
+		done <- applyConcurrently(t, b,
+			clusterApplyRequest(t, "inst-1", "a"),
+			clusterApplyRequest(t, "inst-1", "b"),
+		)
+	}()
+
+	select {
+	case errs := <-done:
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("full batch was not sent before the window elapsed")
+	}
+	require.Len(t, applier.requests, 1)
+	assert.ElementsMatch(t, []string{"a", "b"}, applier.requests[0])
+}
+
+func TestClusterApplyBatcherDropsCancelledCaller(t *testing.T) {
+	applier := &recordingApplier{}
+	b := newClusterApplyBatcher(applier.apply, 200*time.Millisecond, clusterApplyBatchMaxSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+	go func() {
+		_, err := b.Apply(ctx, clusterApplyRequest(t, "inst-1", "a"))
+		cancelled <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-cancelled, context.Canceled)
+
+	_, err := b.Apply(context.Background(), clusterApplyRequest(t, "inst-1", "b"))
+	require.NoError(t, err)
+	require.Len(t, applier.requests, 1)
+	assert.Equal(t, []string{"b"}, applier.requests[0])
+}
+
+func TestClusterApplyBatcherUsesLatestCallerDeadline(t *testing.T) {
+	var mu sync.Mutex
+	var deadlines []time.Time
+	apply := func(ctx context.Context, req *argocdv1.ApplyInstanceRequest) (*argocdv1.ApplyInstanceResponse, error) {
+		deadline, _ := ctx.Deadline()
+		mu.Lock()
+		deadlines = append(deadlines, deadline)
+		mu.Unlock()
+		if len(req.GetClusters()) > 1 {
+			return nil, status.Error(codes.InvalidArgument, "invalid cluster")
+		}
+		return &argocdv1.ApplyInstanceResponse{}, nil
+	}
+	b := newClusterApplyBatcher(apply, 100*time.Millisecond, clusterApplyBatchMaxSize)
+
+	now := time.Now()
+	short, cancelShort := context.WithDeadline(context.Background(), now.Add(time.Hour))
+	defer cancelShort()
+	long, cancelLong := context.WithDeadline(context.Background(), now.Add(2*time.Hour))
+	defer cancelLong()
+
+	var wg sync.WaitGroup
+	for _, c := range []struct {
+		ctx  context.Context
+		name string
+	}{{short, "a"}, {long, "b"}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := b.Apply(c.ctx, clusterApplyRequest(t, "inst-1", c.name))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// The batched request, then one request per cluster with its caller's
+	// deadline.
+	require.Len(t, deadlines, 3)
+	assert.Equal(t, now.Add(2*time.Hour), deadlines[0])
+	assert.ElementsMatch(t, []time.Time{now.Add(time.Hour), now.Add(2 * time.Hour)}, deadlines[1:])
+}
@@ -0,0 +1,105 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	apikeyv1 "github.com/akuity/api-client-go/pkg/api/gen/apikey/v1"
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AkpApiKeysDataSource{}
+
+func NewAkpApiKeysDataSource() datasource.DataSource {
+	return &AkpApiKeysDataSource{}
+}
+
+// AkpApiKeysDataSource defines the data source implementation.
+type AkpApiKeysDataSource struct {
+	BaseDataSource
+}
+
+func (d *AkpApiKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_keys"
+}
+
+func (d *AkpApiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading an API Keys Datasource")
+	var data types.ApiKeys
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	re := compileFilterRegex(&resp.Diagnostics, data.DescriptionRegex, "description_regex")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scopeID, keys, err := listAPIKeys(ctx, d.akpCli, data.Workspace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API keys, got error: %s", err))
+		return
+	}
+
+	data.ID = tftypes.StringValue(scopeID)
+	data.ApiKeys = make([]types.ApiKey, 0, len(keys))
+	for _, k := range keys {
+		if !matchesFilter(re, k.GetDescription()) {
+			continue
+		}
+		// Workspace drives the role namespace filtering in applyApiKeyResponse.
+		// The expiry is only reported as expire_time.
+		key := types.ApiKey{
+			Workspace:        data.Workspace,
+			ExpireInDuration: tftypes.StringNull(),
+		}
+		applyApiKeyResponse(&key, k)
+		data.ApiKeys = append(data.ApiKeys, key)
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listAPIKeys returns the API keys of the named workspace, or the org-scoped
+// keys when workspace is empty, together with the ID of that workspace or
+// organization.
+func listAPIKeys(ctx context.Context, cli *AkpCli, workspace string) (string, []*apikeyv1.APIKey, error) {
+	if workspace != "" {
+		ws, err := getWorkspace(ctx, cli.OrgCli, cli.OrgId, workspace)
+		if err != nil {
+			return "", nil, err
+		}
+		resp, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.ListWorkspaceAPIKeysResponse, error) {
+			return cli.OrgCli.ListWorkspaceAPIKeys(ctx, &orgcv1.ListWorkspaceAPIKeysRequest{
+				Id:          cli.OrgId,
+				WorkspaceId: ws.GetId(),
+			})
+		}, "ListWorkspaceAPIKeys")
+		if err != nil {
+			return "", nil, err
+		}
+		return ws.GetId(), resp.GetApiKeys(), nil
+	}
+
+	resp, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.ListOrganizationAPIKeysResponse, error) {
+		return cli.OrgCli.ListOrganizationAPIKeys(ctx, &orgcv1.ListOrganizationAPIKeysRequest{
+			Id: cli.OrgId,
+		})
+	}, "ListOrganizationAPIKeys")
+	if err != nil {
+		return "", nil, err
+	}
+	return cli.OrgId, resp.GetApiKeys(), nil
+}
@@ -0,0 +1,108 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (d *AkpApiKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets information about all API keys of the organization or of a single workspace, optionally filtered by description. Key secrets are only returned when a key is created and are never exposed by this data source.",
+		Attributes:          getAKPApiKeysDataSourceAttributes(),
+	}
+}
+
+func getAKPApiKeysDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "ID of the workspace when `workspace` is set, of the organization otherwise",
+			Computed:            true,
+		},
+		"workspace": schema.StringAttribute{
+			MarkdownDescription: "Workspace name. When set, lists the keys scoped to this workspace; when omitted, lists the org-scoped keys.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"description_regex": schema.StringAttribute{
+			MarkdownDescription: "Regular expression (RE2 syntax) the key description must match. Omit to return all keys",
+			Optional:            true,
+		},
+		"api_keys": schema.ListNestedAttribute{
+			MarkdownDescription: "List of API keys",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: getAKPApiKeyDataSourceAttributes(),
+			},
+		},
+	}
+}
+
+func getAKPApiKeyDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "API key ID",
+			Computed:            true,
+		},
+		"workspace": schema.StringAttribute{
+			MarkdownDescription: "Workspace name the key is scoped to. Null for org-scoped keys",
+			Computed:            true,
+		},
+		"description": schema.StringAttribute{
+			MarkdownDescription: "Human-readable description for the key",
+			Computed:            true,
+		},
+		"expire_in_duration": schema.StringAttribute{
+			MarkdownDescription: "Always null; the API only reports the absolute `expire_time`",
+			Computed:            true,
+		},
+		"permissions": schema.SingleNestedAttribute{
+			MarkdownDescription: "Permissions granted to the key",
+			Computed:            true,
+			Attributes:          getAKPApiKeyPermissionsDataSourceAttributes(),
+		},
+		"secret": schema.StringAttribute{
+			MarkdownDescription: "Always empty; the secret is only returned when the key is created",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"organization_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the owning organization",
+			Computed:            true,
+		},
+		"create_time": schema.StringAttribute{
+			MarkdownDescription: "RFC3339 timestamp of key creation",
+			Computed:            true,
+		},
+		"expire_time": schema.StringAttribute{
+			MarkdownDescription: "RFC3339 timestamp at which the key expires. Empty for non-expiring keys",
+			Computed:            true,
+		},
+	}
+}
+
+func getAKPApiKeyPermissionsDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"actions": schema.ListAttribute{
+			MarkdownDescription: "Action grants",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"roles": schema.ListAttribute{
+			MarkdownDescription: "Built-in role names, without their scope prefix",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"custom_roles": schema.ListAttribute{
+			MarkdownDescription: "IDs of custom roles bound to the key",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !acc
+
+package akp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// If this test fails, a field has been added/removed to the ApiKeys related type.
+// Update the schema attribute accordingly.
+func TestNoNewApiKeysDataSourceFields(t *testing.T) {
+	assert.Equal(t, reflect.TypeFor[types.ApiKeys]().NumField(), len(getAKPApiKeysDataSourceAttributes()))
+	assert.Equal(t, reflect.TypeFor[types.ApiKey]().NumField(), len(getAKPApiKeyDataSourceAttributes()))
+	assert.Equal(t, reflect.TypeFor[types.ApiKeyPermissions]().NumField(), len(getAKPApiKeyPermissionsDataSourceAttributes()))
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
 	"github.com/akuity/terraform-provider-akp/akp/types"
 )
 
@@ -28,18 +27,26 @@ func (d *AkpClusterDataSource) Metadata(ctx context.Context, req datasource.Meta
 
 func (d *AkpClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Reading a Cluster Datasource")
-	var data types.Cluster
+	var data types.ClusterDataSource
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	ctx = httpctx.SetAuthorizationHeader(ctx, d.akpCli.Cred.Scheme(), d.akpCli.Cred.Credential())
-	if err := refreshClusterState(ctx, &resp.Diagnostics, d.akpCli.Cli, &data, d.akpCli.OrgId, nil); err != nil {
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cluster := &types.Cluster{
+		InstanceID: data.InstanceID,
+		Name:       data.Name,
+	}
+	if err := refreshClusterState(ctx, &resp.Diagnostics, d.akpCli.Cli, cluster, d.akpCli.OrgId, nil); err != nil {
 		resp.Diagnostics.AddError("Failed to refresh cluster state", err.Error())
 		return
 	}
+	data = types.NewClusterDataSourceModel(cluster)
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
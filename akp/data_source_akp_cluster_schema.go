@@ -43,6 +43,16 @@ func getAKPClusterDataSourceAttributes() map[string]schema.Attribute {
 			MarkdownDescription: "Annotations",
 			Computed:            true,
 		},
+		"labels_all": schema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Labels, including those that come from the provider's `default_labels`",
+			Computed:            true,
+		},
+		"annotations_all": schema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Annotations, including those that come from the provider's `default_annotations`",
+			Computed:            true,
+		},
 		"spec": schema.SingleNestedAttribute{
 			MarkdownDescription: "Cluster spec",
 			Computed:            true,
@@ -61,10 +71,51 @@ func getAKPClusterDataSourceAttributes() map[string]schema.Attribute {
 			MarkdownDescription: "Whether to reapply manifests on update",
 			Computed:            true,
 		},
+		"apply_strategy": schema.StringAttribute{
+			MarkdownDescription: "How agent manifests are applied to the target cluster",
+			Computed:            true,
+		},
+		"force_conflicts": schema.BoolAttribute{
+			MarkdownDescription: "Whether server-side apply takes ownership of conflicting fields",
+			Computed:            true,
+		},
+		"prune_agent_resources": schema.BoolAttribute{
+			MarkdownDescription: "Whether stale agent objects are pruned when manifests are applied",
+			Computed:            true,
+		},
+		"prune_dry_run": schema.BoolAttribute{
+			MarkdownDescription: "Whether stale agent objects are only reported instead of pruned",
+			Computed:            true,
+		},
+		"allow_unmatched_patch_targets": schema.BoolAttribute{
+			MarkdownDescription: "Whether kustomization patches that match no agent object only warn",
+			Computed:            true,
+		},
+		"wait_for_rollout": schema.BoolAttribute{
+			MarkdownDescription: "Whether to wait for the agent rollout after applying manifests",
+			Computed:            true,
+		},
+		"image_registry_mirror": schema.StringAttribute{
+			MarkdownDescription: "Registry that mirrors the agent images",
+			Computed:            true,
+		},
+		"image_pull_secrets": schema.ListAttribute{
+			MarkdownDescription: "Names of secrets added to the `imagePullSecrets` of every agent pod",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"agent_manifest_hash": schema.StringAttribute{
+			MarkdownDescription: "Hash of the agent manifests installed in the target cluster",
+			Computed:            true,
+		},
 		"ensure_healthy": schema.BoolAttribute{
 			MarkdownDescription: "If true, terraform apply will fail if the cluster agent becomes degraded or does not become healthy within the timeout period. When false (default), terraform will not wait for the resource status to be reported.",
 			Computed:            true,
 		},
+		"wait_for": schema.StringAttribute{
+			MarkdownDescription: "Which phases of the wait run after the resource is applied",
+			Computed:            true,
+		},
 	}
 }
 
@@ -242,11 +293,21 @@ func getKubeconfigDataSourceAttributes() map[string]schema.Attribute {
 			Computed:    true,
 			Description: "",
 		},
+		"config_raw": schema.StringAttribute{
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Raw kube config document.",
+		},
 		"token": schema.StringAttribute{
 			Computed:    true,
 			Sensitive:   true,
 			Description: "Token to authenticate an service account",
 		},
+		"token_file": schema.StringAttribute{
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Path to a file containing a token to authenticate an service account.",
+		},
 		"proxy_url": schema.StringAttribute{
 			Computed:    true,
 			Description: "URL to the proxy to be used for all API requests",
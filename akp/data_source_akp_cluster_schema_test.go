@@ -14,7 +14,7 @@ import (
 // If this test fails, a field has been added/removed to the Cluster related type.
 // Update the schema attribute accordingly.
 func TestNoNewClusterDataSourceFields(t *testing.T) {
-	assert.Equal(t, reflect.TypeFor[types.Cluster]().NumField(), len(getAKPClusterDataSourceAttributes()))
+	assert.Equal(t, reflect.TypeFor[types.ClusterDataSource]().NumField(), len(getAKPClusterDataSourceAttributes()))
 	assert.Equal(t, reflect.TypeFor[types.ClusterSpec]().NumField(), len(getClusterSpecDataSourceAttributes()))
 	assert.Equal(t, reflect.TypeFor[types.ClusterData]().NumField(), len(getClusterDataDataSourceAttributes()))
 }
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
 	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
 	"github.com/akuity/terraform-provider-akp/akp/types"
 )
@@ -38,7 +37,10 @@ func (d *AkpClustersDataSource) Read(ctx context.Context, req datasource.ReadReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	ctx = httpctx.SetAuthorizationHeader(ctx, d.akpCli.Cred.Scheme(), d.akpCli.Cred.Credential())
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	apiReq := &argocdv1.ListInstanceClustersRequest{
 		OrganizationId: d.akpCli.OrgId,
@@ -54,13 +56,13 @@ func (d *AkpClustersDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	data.ID = data.InstanceID
 	clusters := apiResp.GetClusters()
-	data.Clusters = make([]types.Cluster, 0, len(clusters))
+	data.Clusters = make([]types.ClusterDataSource, 0, len(clusters))
 	for _, cluster := range clusters {
 		stateCluster := types.Cluster{
 			InstanceID: data.InstanceID,
 		}
 		stateCluster.Update(ctx, &resp.Diagnostics, cluster, nil)
-		data.Clusters = append(data.Clusters, stateCluster)
+		data.Clusters = append(data.Clusters, types.NewClusterDataSourceModel(&stateCluster))
 	}
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
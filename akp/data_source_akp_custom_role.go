@@ -0,0 +1,65 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AkpCustomRoleDataSource{}
+
+func NewAkpCustomRoleDataSource() datasource.DataSource {
+	return &AkpCustomRoleDataSource{}
+}
+
+// AkpCustomRoleDataSource defines the data source implementation.
+type AkpCustomRoleDataSource struct {
+	BaseDataSource
+}
+
+func (d *AkpCustomRoleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_role"
+}
+
+func (d *AkpCustomRoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading a Custom Role Datasource")
+	var data types.CustomRole
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API only gets roles by ID, so look the name up in the scope's list.
+	_, roles, err := listCustomRoles(ctx, d.akpCli, data.Workspace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom roles, got error: %s", err))
+		return
+	}
+	for _, r := range roles {
+		if r.GetName() == data.Name.ValueString() {
+			applyCustomRoleResponse(&data, r)
+			// Save data into Terraform state
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+	resp.Diagnostics.AddError("Custom role not found", fmt.Sprintf("No custom role named %q exists in %s", data.Name.ValueString(), customRoleScope(data.Workspace.ValueString())))
+}
+
+func customRoleScope(workspace string) string {
+	if workspace == "" {
+		return "the organization"
+	}
+	return fmt.Sprintf("workspace %q", workspace)
+}
@@ -0,0 +1,45 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func (d *AkpCustomRoleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets information about a custom role of the organization or of a single workspace by its name",
+		Attributes:          getAKPCustomRoleDataSourceAttributes(),
+	}
+}
+
+func getAKPCustomRoleDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Custom role ID",
+			Computed:            true,
+		},
+		"workspace": schema.StringAttribute{
+			MarkdownDescription: "Workspace name. When set, the role is looked up in this workspace; when omitted, among the org-scoped roles.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Role name",
+			Required:            true,
+		},
+		"description": schema.StringAttribute{
+			MarkdownDescription: "Human-readable description for the role",
+			Computed:            true,
+		},
+		"policy": schema.StringAttribute{
+			MarkdownDescription: "Casbin policy granting the role's permissions",
+			Computed:            true,
+		},
+	}
+}
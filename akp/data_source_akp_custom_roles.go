@@ -0,0 +1,99 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AkpCustomRolesDataSource{}
+
+func NewAkpCustomRolesDataSource() datasource.DataSource {
+	return &AkpCustomRolesDataSource{}
+}
+
+// AkpCustomRolesDataSource defines the data source implementation.
+type AkpCustomRolesDataSource struct {
+	BaseDataSource
+}
+
+func (d *AkpCustomRolesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_roles"
+}
+
+func (d *AkpCustomRolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading a Custom Roles Datasource")
+	var data types.CustomRoles
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	re := compileFilterRegex(&resp.Diagnostics, data.NameRegex, "name_regex")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scopeID, roles, err := listCustomRoles(ctx, d.akpCli, data.Workspace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom roles, got error: %s", err))
+		return
+	}
+
+	data.ID = tftypes.StringValue(scopeID)
+	data.CustomRoles = make([]types.CustomRole, 0, len(roles))
+	for _, r := range roles {
+		if !matchesFilter(re, r.GetName()) {
+			continue
+		}
+		role := types.CustomRole{Workspace: data.Workspace}
+		applyCustomRoleResponse(&role, r)
+		data.CustomRoles = append(data.CustomRoles, role)
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listCustomRoles returns the custom roles of the named workspace, or the
+// org-scoped roles when workspace is empty, together with the ID of that
+// workspace or organization.
+func listCustomRoles(ctx context.Context, cli *AkpCli, workspace string) (string, []*orgcv1.CustomRole, error) {
+	if workspace != "" {
+		ws, err := getWorkspace(ctx, cli.OrgCli, cli.OrgId, workspace)
+		if err != nil {
+			return "", nil, err
+		}
+		resp, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.ListWorkspaceCustomRolesResponse, error) {
+			return cli.OrgCli.ListWorkspaceCustomRoles(ctx, &orgcv1.ListWorkspaceCustomRolesRequest{
+				OrganizationId: cli.OrgId,
+				WorkspaceId:    ws.GetId(),
+			})
+		}, "ListWorkspaceCustomRoles")
+		if err != nil {
+			return "", nil, err
+		}
+		return ws.GetId(), resp.GetCustomRoles(), nil
+	}
+
+	resp, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.ListCustomRolesResponse, error) {
+		return cli.OrgCli.ListCustomRoles(ctx, &orgcv1.ListCustomRolesRequest{
+			OrganizationId: cli.OrgId,
+		})
+	}, "ListCustomRoles")
+	if err != nil {
+		return "", nil, err
+	}
+	return cli.OrgId, resp.GetCustomRoles(), nil
+}
@@ -0,0 +1,44 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func (d *AkpCustomRolesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets information about all custom roles of the organization or of a single workspace, optionally filtered by name",
+		Attributes:          getAKPCustomRolesDataSourceAttributes(),
+	}
+}
+
+func getAKPCustomRolesDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "ID of the workspace when `workspace` is set, of the organization otherwise",
+			Computed:            true,
+		},
+		"workspace": schema.StringAttribute{
+			MarkdownDescription: "Workspace name. When set, lists the roles scoped to this workspace; when omitted, lists the org-scoped roles.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"name_regex": schema.StringAttribute{
+			MarkdownDescription: "Regular expression (RE2 syntax) the role name must match. Omit to return all roles",
+			Optional:            true,
+		},
+		"custom_roles": schema.ListNestedAttribute{
+			MarkdownDescription: "List of custom roles",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: getAKPCustomRoleDataSourceAttributes(),
+			},
+		},
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
 	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
 	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
 	"github.com/akuity/terraform-provider-akp/akp/types"
@@ -40,7 +39,10 @@ func (r *AkpInstanceDataSource) Read(ctx context.Context, req datasource.ReadReq
 	instance := &types.Instance{
 		Name: data.Name,
 	}
-	ctx = httpctx.SetAuthorizationHeader(ctx, r.akpCli.Cred.Scheme(), r.akpCli.Cred.Credential())
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if err := refreshState(ctx, &resp.Diagnostics, r.akpCli, instance, &argocdv1.GetInstanceRequest{
 		OrganizationId: r.akpCli.OrgId,
@@ -0,0 +1,154 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	healthv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/health/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AkpInstancesDataSource{}
+
+func NewAkpInstancesDataSource() datasource.DataSource {
+	return &AkpInstancesDataSource{}
+}
+
+// AkpInstancesDataSource defines the data source implementation.
+type AkpInstancesDataSource struct {
+	BaseDataSource
+}
+
+func (d *AkpInstancesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instances"
+}
+
+func (d *AkpInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading an Instances Datasource")
+	var data types.Instances
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	re := compileFilterRegex(&resp.Diagnostics, data.NameRegex, "name_regex")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope, err := getInstanceListScope(ctx, d.akpCli, data.Workspace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspaces, got error: %s", err))
+		return
+	}
+	apiResp, err := retryWithBackoff(ctx, func(ctx context.Context) (*argocdv1.ListInstancesResponse, error) {
+		return d.akpCli.Cli.ListInstances(ctx, &argocdv1.ListInstancesRequest{
+			OrganizationId: d.akpCli.OrgId,
+			WorkspaceId:    scope.workspaceID,
+		})
+	}, "ListInstances")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Argo CD instances, got error: %s", err))
+		return
+	}
+
+	data.ID = tftypes.StringValue(scope.id)
+	data.Instances = make([]types.InstanceSummary, 0, len(apiResp.GetInstances()))
+	for _, instance := range apiResp.GetInstances() {
+		fqdn := instance.GetSpec().GetFqdn()
+		if fqdn == "" {
+			fqdn = instance.GetHostname()
+		}
+		summary := scope.summary(instance.GetId(), instance.GetName(), instance.GetWorkspaceId(), instance.GetVersion(), fqdn, instance.GetHealthStatus().GetCode())
+		if matchesInstanceFilters(summary, re, data.Health) {
+			data.Instances = append(data.Instances, summary)
+		}
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// instanceHealthValues are the values of the `health` filter and attribute:
+// the health status codes without their STATUS_CODE_ prefix, lowercased.
+var instanceHealthValues = []string{"healthy", "progressing", "degraded", "unknown"}
+
+// instanceHealth returns the instance health value of code. Codes without a
+// value of their own, such as the unspecified status of an instance that has
+// not reported one yet, are "unknown".
+func instanceHealth(code healthv1.StatusCode) string {
+	health := strings.ToLower(strings.TrimPrefix(code.String(), "STATUS_CODE_"))
+	if !slices.Contains(instanceHealthValues, health) {
+		return "unknown"
+	}
+	return health
+}
+
+// instanceListScope is what the instance list data sources are scoped to: a
+// single workspace, or the whole organization when workspaceID is empty.
+type instanceListScope struct {
+	id          string
+	workspaceID string
+	// workspaceNames maps workspace IDs to names, so summaries can report the
+	// workspace the way the rest of the provider does.
+	workspaceNames map[string]string
+}
+
+func getInstanceListScope(ctx context.Context, cli *AkpCli, workspace string) (*instanceListScope, error) {
+	workspaces, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.ListWorkspacesResponse, error) {
+		return cli.OrgCli.ListWorkspaces(ctx, &orgcv1.ListWorkspacesRequest{
+			OrganizationId: cli.OrgId,
+		})
+	}, "ListWorkspaces")
+	if err != nil {
+		return nil, err
+	}
+	scope := &instanceListScope{id: cli.OrgId, workspaceNames: map[string]string{}}
+	for _, w := range workspaces.GetWorkspaces() {
+		scope.workspaceNames[w.GetId()] = w.GetName()
+		if workspace != "" && w.GetName() == workspace {
+			scope.id = w.GetId()
+			scope.workspaceID = w.GetId()
+		}
+	}
+	if workspace != "" && scope.workspaceID == "" {
+		return nil, status.Errorf(codes.NotFound, "workspace %s not found", workspace)
+	}
+	return scope, nil
+}
+
+func (s *instanceListScope) summary(id, name, workspaceID, version, fqdn string, health healthv1.StatusCode) types.InstanceSummary {
+	return types.InstanceSummary{
+		ID:        tftypes.StringValue(id),
+		Name:      tftypes.StringValue(name),
+		Workspace: tftypes.StringValue(s.workspaceNames[workspaceID]),
+		Version:   tftypes.StringValue(version),
+		Fqdn:      tftypes.StringValue(fqdn),
+		Health:    tftypes.StringValue(instanceHealth(health)),
+	}
+}
+
+// matchesInstanceFilters reports whether an instance passes the name and
+// health filters of a list data source.
+func matchesInstanceFilters(instance types.InstanceSummary, nameRegex *regexp.Regexp, health tftypes.String) bool {
+	if !matchesFilter(nameRegex, instance.Name.ValueString()) {
+		return false
+	}
+	return health.IsNull() || health.ValueString() == instance.Health.ValueString()
+}
@@ -0,0 +1,83 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func (d *AkpInstancesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets a summary of all Argo CD instances in the organization, optionally filtered by workspace, name and health. Use `akp_instance` to get the full configuration of a single instance",
+		Attributes:          getAKPInstancesDataSourceAttributes("Argo CD"),
+	}
+}
+
+// getAKPInstancesDataSourceAttributes returns the attributes shared by the
+// Argo CD and Kargo instance list data sources; kind names the instance kind.
+func getAKPInstancesDataSourceAttributes(kind string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "ID of the workspace when `workspace` is set, of the organization otherwise",
+			Computed:            true,
+		},
+		"workspace": schema.StringAttribute{
+			MarkdownDescription: "Workspace name. When set, only instances in this workspace are returned",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"name_regex": schema.StringAttribute{
+			MarkdownDescription: "Regular expression (RE2 syntax) the instance name must match. Omit to return all instances",
+			Optional:            true,
+		},
+		"health": schema.StringAttribute{
+			MarkdownDescription: "When set, only instances with this health status are returned. One of `healthy`, `progressing`, `degraded` or `unknown`",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(instanceHealthValues...),
+			},
+		},
+		"instances": schema.ListNestedAttribute{
+			MarkdownDescription: fmt.Sprintf("List of %s instances", kind),
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: getAKPInstanceSummaryDataSourceAttributes(kind),
+			},
+		},
+	}
+}
+
+func getAKPInstanceSummaryDataSourceAttributes(kind string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("%s instance ID", kind),
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("%s instance name", kind),
+			Computed:            true,
+		},
+		"workspace": schema.StringAttribute{
+			MarkdownDescription: "Name of the workspace the instance belongs to",
+			Computed:            true,
+		},
+		"version": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("%s version", kind),
+			Computed:            true,
+		},
+		"fqdn": schema.StringAttribute{
+			MarkdownDescription: "Fully qualified domain name the instance is served on",
+			Computed:            true,
+		},
+		"health": schema.StringAttribute{
+			MarkdownDescription: "Health status of the instance: `healthy`, `progressing`, `degraded` or `unknown`",
+			Computed:            true,
+		},
+	}
+}
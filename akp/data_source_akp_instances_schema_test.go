@@ -0,0 +1,34 @@
+//go:build !acc
+
+package akp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	healthv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/health/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// If this test fails, a field has been added/removed to the Instances related types.
+// Update the schema attribute accordingly.
+func TestNoNewInstancesDataSourceFields(t *testing.T) {
+	assert.Equal(t, reflect.TypeFor[types.Instances]().NumField(), len(getAKPInstancesDataSourceAttributes("Argo CD")))
+	assert.Equal(t, reflect.TypeFor[types.KargoInstances]().NumField(), len(getAKPInstancesDataSourceAttributes("Kargo")))
+	assert.Equal(t, reflect.TypeFor[types.InstanceSummary]().NumField(), len(getAKPInstanceSummaryDataSourceAttributes("Argo CD")))
+}
+
+func TestInstanceHealth(t *testing.T) {
+	assert.Equal(t, "healthy", instanceHealth(healthv1.StatusCode_STATUS_CODE_HEALTHY))
+	assert.Equal(t, "unknown", instanceHealth(healthv1.StatusCode_STATUS_CODE_UNSPECIFIED))
+	// Every status the API reports must be accepted by the `health` filter.
+	assert.Subset(t, instanceHealthValues, []string{
+		instanceHealth(healthv1.StatusCode_STATUS_CODE_HEALTHY),
+		instanceHealth(healthv1.StatusCode_STATUS_CODE_PROGRESSING),
+		instanceHealth(healthv1.StatusCode_STATUS_CODE_DEGRADED),
+		instanceHealth(healthv1.StatusCode_STATUS_CODE_UNKNOWN),
+		instanceHealth(healthv1.StatusCode_STATUS_CODE_UNSPECIFIED),
+	})
+}
@@ -7,8 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-
-	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
 )
 
 var _ datasource.DataSource = &AkpKargoDefaultShardAgentDataSource{}
@@ -34,7 +32,10 @@ func (a *AkpKargoDefaultShardAgentDataSource) Read(ctx context.Context, req data
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	ctx = httpctx.SetAuthorizationHeader(ctx, a.akpCli.Cred.Scheme(), a.akpCli.Cred.Credential())
+	ctx = a.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	instance, err := getKargoInstanceForDefaultShard(ctx, a.akpCli, data.KargoInstanceID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -0,0 +1,79 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	kargov1 "github.com/akuity/api-client-go/pkg/api/gen/kargo/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AkpKargoInstancesDataSource{}
+
+func NewAkpKargoInstancesDataSource() datasource.DataSource {
+	return &AkpKargoInstancesDataSource{}
+}
+
+// AkpKargoInstancesDataSource defines the data source implementation.
+type AkpKargoInstancesDataSource struct {
+	BaseDataSource
+}
+
+func (d *AkpKargoInstancesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kargo_instances"
+}
+
+func (d *AkpKargoInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading a Kargo Instances Datasource")
+	var data types.KargoInstances
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	re := compileFilterRegex(&resp.Diagnostics, data.NameRegex, "name_regex")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope, err := getInstanceListScope(ctx, d.akpCli, data.Workspace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspaces, got error: %s", err))
+		return
+	}
+	apiResp, err := retryWithBackoff(ctx, func(ctx context.Context) (*kargov1.ListKargoInstancesResponse, error) {
+		return d.akpCli.KargoCli.ListKargoInstances(ctx, &kargov1.ListKargoInstancesRequest{
+			OrganizationId: d.akpCli.OrgId,
+			WorkspaceId:    scope.workspaceID,
+		})
+	}, "ListKargoInstances")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Kargo instances, got error: %s", err))
+		return
+	}
+
+	data.ID = tftypes.StringValue(scope.id)
+	data.Instances = make([]types.InstanceSummary, 0, len(apiResp.GetInstances()))
+	for _, instance := range apiResp.GetInstances() {
+		fqdn := instance.GetFqdn()
+		if fqdn == "" {
+			fqdn = instance.GetHostname()
+		}
+		summary := scope.summary(instance.GetId(), instance.GetName(), instance.GetWorkspaceId(), instance.GetVersion(), fqdn, instance.GetHealthStatus().GetCode())
+		if matchesInstanceFilters(summary, re, data.Health) {
+			data.Instances = append(data.Instances, summary)
+		}
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,15 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func (d *AkpKargoInstancesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets a summary of all Kargo instances in the organization, optionally filtered by workspace, name and health. Use `akp_kargo_instance` to get the full configuration of a single instance",
+		Attributes:          getAKPInstancesDataSourceAttributes("Kargo"),
+	}
+}
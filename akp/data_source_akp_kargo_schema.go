@@ -195,6 +195,15 @@ func getKargoAgentCustomizationDataSourceAttributes() map[string]schema.Attribut
 			MarkdownDescription: "Default PEM bundle of one or more CA certificates applied to new agents that do not specify their own.",
 			Computed:            true,
 		},
+		"image_registry_mirror": schema.StringAttribute{
+			MarkdownDescription: "Default registry that mirrors the agent images",
+			Computed:            true,
+		},
+		"image_pull_secrets": schema.ListAttribute{
+			MarkdownDescription: "Default names of secrets added to the `imagePullSecrets` of every agent pod",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
 	}
 }
 
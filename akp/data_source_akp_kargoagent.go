@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
 	"github.com/akuity/terraform-provider-akp/akp/types"
 )
 
@@ -27,21 +26,29 @@ func (a *AkpKargoAgentDataSource) Metadata(ctx context.Context, req datasource.M
 
 func (a *AkpKargoAgentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Reading a Kargo Agent Datasource")
-	var data types.KargoAgent
+	var data types.KargoAgentDataSource
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	ctx = httpctx.SetAuthorizationHeader(ctx, a.akpCli.Cred.Scheme(), a.akpCli.Cred.Credential())
-	if err := refreshKargoAgentState(ctx, &resp.Diagnostics, a.akpCli, &data, nil); err != nil {
+	ctx = a.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agent := &types.KargoAgent{
+		InstanceID: data.InstanceID,
+		Name:       data.Name,
+	}
+	if err := refreshKargoAgentState(ctx, &resp.Diagnostics, a.akpCli, agent, nil); err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to refresh Kargo Agent state",
 			fmt.Sprintf("Error: %v", err),
 		)
 		return
 	}
+	data = types.NewKargoAgentDataSourceModel(agent)
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -47,6 +47,16 @@ func getAKPKargoAgentDataSourceAttributes() map[string]schema.Attribute {
 			MarkdownDescription: "The annotations of the Kargo agent",
 			Computed:            true,
 		},
+		"labels_all": schema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "The labels of the Kargo agent, including those that come from the provider's `default_labels`",
+			Computed:            true,
+		},
+		"annotations_all": schema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "The annotations of the Kargo agent, including those that come from the provider's `default_annotations`",
+			Computed:            true,
+		},
 		"spec": schema.SingleNestedAttribute{
 			MarkdownDescription: "The spec of the Kargo agent",
 			Computed:            true,
@@ -65,6 +75,47 @@ func getAKPKargoAgentDataSourceAttributes() map[string]schema.Attribute {
 			MarkdownDescription: "Whether to reapply manifests on update",
 			Computed:            true,
 		},
+		"apply_strategy": schema.StringAttribute{
+			MarkdownDescription: "How agent manifests are applied to the target cluster",
+			Computed:            true,
+		},
+		"force_conflicts": schema.BoolAttribute{
+			MarkdownDescription: "Whether server-side apply takes ownership of conflicting fields",
+			Computed:            true,
+		},
+		"prune_agent_resources": schema.BoolAttribute{
+			MarkdownDescription: "Whether stale agent objects are pruned when manifests are applied",
+			Computed:            true,
+		},
+		"prune_dry_run": schema.BoolAttribute{
+			MarkdownDescription: "Whether stale agent objects are only reported instead of pruned",
+			Computed:            true,
+		},
+		"allow_unmatched_patch_targets": schema.BoolAttribute{
+			MarkdownDescription: "Whether kustomization patches that match no agent object only warn",
+			Computed:            true,
+		},
+		"wait_for_rollout": schema.BoolAttribute{
+			MarkdownDescription: "Whether to wait for the agent rollout after applying manifests",
+			Computed:            true,
+		},
+		"image_registry_mirror": schema.StringAttribute{
+			MarkdownDescription: "Registry that mirrors the agent images",
+			Computed:            true,
+		},
+		"image_pull_secrets": schema.ListAttribute{
+			MarkdownDescription: "Names of secrets added to the `imagePullSecrets` of every agent pod",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"agent_manifest_hash": schema.StringAttribute{
+			MarkdownDescription: "Hash of the agent manifests installed in the target cluster",
+			Computed:            true,
+		},
+		"wait_for": schema.StringAttribute{
+			MarkdownDescription: "Which phases of the wait run after the resource is applied",
+			Computed:            true,
+		},
 	}
 }
 
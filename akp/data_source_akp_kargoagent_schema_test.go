@@ -12,7 +12,7 @@ import (
 )
 
 func TestNoNewKargoAgentDataSourceFields(t *testing.T) {
-	assert.Equal(t, reflect.TypeFor[types.KargoAgent]().NumField(), len(getAKPKargoAgentDataSourceAttributes()))
+	assert.Equal(t, reflect.TypeFor[types.KargoAgentDataSource]().NumField(), len(getAKPKargoAgentDataSourceAttributes()))
 	assert.Equal(t, reflect.TypeFor[types.KargoAgentSpec]().NumField(), len(getAKPKargoAgentSpecDataSourceAttributes()))
 	assert.Equal(t, reflect.TypeFor[types.KargoAgentData]().NumField(), len(getAKPKargoAgentDataDataSourceAttributes()))
 	assert.Equal(t, reflect.TypeFor[types.KargoAutoscalerConfig]().NumField(), len(getKargoAutoscalerConfigDataSourceAttributes()))
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
 	kargov1 "github.com/akuity/api-client-go/pkg/api/gen/kargo/v1"
 	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
 	"github.com/akuity/terraform-provider-akp/akp/types"
@@ -39,7 +38,10 @@ func (a *AkpKargoAgentsDataSource) Read(ctx context.Context, req datasource.Read
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	ctx = httpctx.SetAuthorizationHeader(ctx, a.akpCli.Cred.Scheme(), a.akpCli.Cred.Credential())
+	ctx = a.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	workspaces, err := a.akpCli.OrgCli.ListWorkspaces(ctx, &orgcv1.ListWorkspacesRequest{
 		OrganizationId: a.akpCli.OrgId,
@@ -74,13 +76,13 @@ func (a *AkpKargoAgentsDataSource) Read(ctx context.Context, req datasource.Read
 
 	data.ID = data.InstanceID
 	agents := apiResp.GetAgents()
-	data.Agents = make([]types.KargoAgent, 0, len(agents))
+	data.Agents = make([]types.KargoAgentDataSource, 0, len(agents))
 	for _, agent := range agents {
 		stateAgent := types.KargoAgent{
 			InstanceID: data.InstanceID,
 		}
 		stateAgent.Update(ctx, &resp.Diagnostics, agent, nil)
-		data.Agents = append(data.Agents, stateAgent)
+		data.Agents = append(data.Agents, types.NewKargoAgentDataSourceModel(&stateAgent))
 	}
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -0,0 +1,42 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (d *AkpTeamDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets information about an organization team by its name",
+		Attributes:          getAKPTeamDataSourceAttributes(),
+	}
+}
+
+func getAKPTeamDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Team name",
+			Required:            true,
+		},
+		"description": schema.StringAttribute{
+			MarkdownDescription: "Human-readable description for the team",
+			Computed:            true,
+		},
+		"custom_roles": schema.ListAttribute{
+			MarkdownDescription: "Organization-level custom roles granted to the team",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"create_time": schema.StringAttribute{
+			MarkdownDescription: "RFC3339 timestamp of team creation",
+			Computed:            true,
+		},
+		"member_count": schema.Int64Attribute{
+			MarkdownDescription: "Number of members in the team",
+			Computed:            true,
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AkpTeamsDataSource{}
+
+func NewAkpTeamsDataSource() datasource.DataSource {
+	return &AkpTeamsDataSource{}
+}
+
+// AkpTeamsDataSource defines the data source implementation.
+type AkpTeamsDataSource struct {
+	BaseDataSource
+}
+
+func (d *AkpTeamsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_teams"
+}
+
+func (d *AkpTeamsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading a Teams Datasource")
+	var data types.Teams
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	re := compileFilterRegex(&resp.Diagnostics, data.NameRegex, "name_regex")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.ListTeamsResponse, error) {
+		return d.akpCli.OrgCli.ListTeams(ctx, &orgcv1.ListTeamsRequest{
+			OrganizationId: d.akpCli.OrgId,
+		})
+	}, "ListTeams")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read teams, got error: %s", err))
+		return
+	}
+
+	data.ID = tftypes.StringValue(d.akpCli.OrgId)
+	data.Teams = make([]types.Team, 0, len(apiResp.GetUserTeams()))
+	for _, userTeam := range apiResp.GetUserTeams() {
+		if userTeam.GetTeam() == nil || !matchesFilter(re, userTeam.GetTeam().GetName()) {
+			continue
+		}
+		var team types.Team
+		applyTeamResponse(&team, userTeam)
+		data.Teams = append(data.Teams, team)
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,35 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func (d *AkpTeamsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets information about all teams in the organization, optionally filtered by name",
+		Attributes:          getAKPTeamsDataSourceAttributes(),
+	}
+}
+
+func getAKPTeamsDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Organization ID",
+			Computed:            true,
+		},
+		"name_regex": schema.StringAttribute{
+			MarkdownDescription: "Regular expression (RE2 syntax) the team name must match. Omit to return all teams",
+			Optional:            true,
+		},
+		"teams": schema.ListNestedAttribute{
+			MarkdownDescription: "List of teams",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: getAKPTeamDataSourceAttributes(),
+			},
+		},
+	}
+}
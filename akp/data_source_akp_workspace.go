@@ -0,0 +1,52 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AkpWorkspaceDataSource{}
+
+func NewAkpWorkspaceDataSource() datasource.DataSource {
+	return &AkpWorkspaceDataSource{}
+}
+
+// AkpWorkspaceDataSource defines the data source implementation.
+type AkpWorkspaceDataSource struct {
+	BaseDataSource
+}
+
+func (d *AkpWorkspaceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspace"
+}
+
+func (d *AkpWorkspaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading a Workspace Datasource")
+	var data types.Workspace
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An omitted name selects the organization's default workspace.
+	workspace, err := getWorkspace(ctx, d.akpCli.OrgCli, d.akpCli.OrgId, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspace, got error: %s", err))
+		return
+	}
+	applyWorkspaceResponse(&data, workspace)
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
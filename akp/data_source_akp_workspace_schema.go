@@ -0,0 +1,41 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func (d *AkpWorkspaceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets information about a workspace by its name, or about the organization's default workspace when `name` is omitted",
+		Attributes:          getAKPWorkspaceDataSourceAttributes(),
+	}
+}
+
+func getAKPWorkspaceDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Workspace ID",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Workspace name. Defaults to the organization's default workspace",
+			Optional:            true,
+			Computed:            true,
+		},
+		"description": schema.StringAttribute{
+			MarkdownDescription: "Human-readable description for the workspace",
+			Computed:            true,
+		},
+		"create_time": schema.StringAttribute{
+			MarkdownDescription: "RFC3339 timestamp of workspace creation",
+			Computed:            true,
+		},
+		"is_default": schema.BoolAttribute{
+			MarkdownDescription: "Whether this is the organization's default workspace",
+			Computed:            true,
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AkpWorkspacesDataSource{}
+
+func NewAkpWorkspacesDataSource() datasource.DataSource {
+	return &AkpWorkspacesDataSource{}
+}
+
+// AkpWorkspacesDataSource defines the data source implementation.
+type AkpWorkspacesDataSource struct {
+	BaseDataSource
+}
+
+func (d *AkpWorkspacesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workspaces"
+}
+
+func (d *AkpWorkspacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading a Workspaces Datasource")
+	var data types.Workspaces
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	re := compileFilterRegex(&resp.Diagnostics, data.NameRegex, "name_regex")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = d.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.ListWorkspacesResponse, error) {
+		return d.akpCli.OrgCli.ListWorkspaces(ctx, &orgcv1.ListWorkspacesRequest{
+			OrganizationId: d.akpCli.OrgId,
+		})
+	}, "ListWorkspaces")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workspaces, got error: %s", err))
+		return
+	}
+
+	data.ID = tftypes.StringValue(d.akpCli.OrgId)
+	data.Workspaces = make([]types.Workspace, 0, len(apiResp.GetWorkspaces()))
+	for _, ws := range apiResp.GetWorkspaces() {
+		if !matchesFilter(re, ws.GetName()) {
+			continue
+		}
+		var workspace types.Workspace
+		applyWorkspaceResponse(&workspace, ws)
+		data.Workspaces = append(data.Workspaces, workspace)
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,35 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func (d *AkpWorkspacesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gets information about all workspaces in the organization, optionally filtered by name",
+		Attributes:          getAKPWorkspacesDataSourceAttributes(),
+	}
+}
+
+func getAKPWorkspacesDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Organization ID",
+			Computed:            true,
+		},
+		"name_regex": schema.StringAttribute{
+			MarkdownDescription: "Regular expression (RE2 syntax) the workspace name must match. Omit to return all workspaces",
+			Optional:            true,
+		},
+		"workspaces": schema.ListNestedAttribute{
+			MarkdownDescription: "List of workspaces",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: getAKPWorkspaceDataSourceAttributes(),
+			},
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package akp
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// compileFilterRegex compiles the regex filter of a list data source. A null
+// or empty filter matches everything and is returned as a nil regexp; an
+// invalid one is reported against attr.
+func compileFilterRegex(diags *diag.Diagnostics, filter tftypes.String, attr string) *regexp.Regexp {
+	if filter.IsNull() || filter.IsUnknown() || filter.ValueString() == "" {
+		return nil
+	}
+	re, err := regexp.Compile(filter.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root(attr), "Invalid regular expression", err.Error())
+		return nil
+	}
+	return re
+}
+
+func matchesFilter(re *regexp.Regexp, s string) bool {
+	return re == nil || re.MatchString(s)
+}
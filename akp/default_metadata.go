@@ -0,0 +1,120 @@
+package akp
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// metadataDefaults are the provider's default_labels and default_annotations,
+// which akp_cluster and akp_kargo_agent resources add to their own labels and
+// annotations.
+type metadataDefaults struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// withDefaults returns configured on top of defaults. configured is returned
+// as is when there are no defaults.
+func withDefaults(defaults, configured map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return configured
+	}
+	merged := maps.Clone(defaults)
+	maps.Copy(merged, configured)
+	return merged
+}
+
+// withoutDefaults returns the labels or annotations read from the API minus
+// those that only come from defaults, so that the defaults do not show up as
+// a diff against the configuration. Entries the configuration sets itself, and
+// entries whose value no longer matches the default, are kept.
+func withoutDefaults(ctx context.Context, diagnostics *diag.Diagnostics, all, configured types.Map, defaults map[string]string) types.Map {
+	if len(defaults) == 0 || all.IsNull() || all.IsUnknown() {
+		return all
+	}
+	var allElems, configuredElems map[string]string
+	diagnostics.Append(all.ElementsAs(ctx, &allElems, false)...)
+	diagnostics.Append(configured.ElementsAs(ctx, &configuredElems, true)...)
+
+	result := make(map[string]string, len(allElems))
+	for k, v := range allElems {
+		if _, ok := configuredElems[k]; !ok {
+			if d, ok := defaults[k]; ok && d == v {
+				continue
+			}
+		}
+		result[k] = v
+	}
+	m, d := types.MapValueFrom(ctx, types.StringType, result)
+	diagnostics.Append(d...)
+	return m
+}
+
+// stripDefaults replaces the labels and annotations an API read left in
+// labels and annotations with those the configuration owns. configuredLabels
+// and configuredAnnotations are the values before the read.
+func (d metadataDefaults) stripDefaults(ctx context.Context, diagnostics *diag.Diagnostics, labels, annotations *types.Map, configuredLabels, configuredAnnotations types.Map) {
+	*labels = withoutDefaults(ctx, diagnostics, *labels, configuredLabels, d.Labels)
+	*annotations = withoutDefaults(ctx, diagnostics, *annotations, configuredAnnotations, d.Annotations)
+}
+
+// planMetadataDefaults plans labels_all and annotations_all as the configured
+// labels and annotations on top of the provider defaults. It is the
+// ModifyPlanFunc of akp_cluster and akp_kargo_agent.
+func planMetadataDefaults(ctx context.Context, cli *AkpCli, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planAll(ctx, req, resp, "labels", cli.defaultMetadata.Labels)
+	planAll(ctx, req, resp, "annotations", cli.defaultMetadata.Annotations)
+}
+
+func planAll(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attr string, defaults map[string]string) {
+	allPath := path.Root(attr + "_all")
+	var configured types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attr), &configured)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The effective values are only known once the configured ones are.
+	if configured.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, types.MapUnknown(types.StringType))...)
+		return
+	}
+	var configuredElems map[string]types.String
+	resp.Diagnostics.Append(configured.ElementsAs(ctx, &configuredElems, true)...)
+	elems := make(map[string]string, len(configuredElems))
+	for k, v := range configuredElems {
+		if v.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, types.MapUnknown(types.StringType))...)
+			return
+		}
+		elems[k] = v.ValueString()
+	}
+	merged := withDefaults(defaults, elems)
+
+	// Keep the prior value when it already holds the merged values, so that an
+	// empty map and a null one do not make a diff.
+	if !req.State.Raw.IsNull() {
+		var prior types.Map
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, allPath, &prior)...)
+		if !prior.IsUnknown() {
+			var priorElems map[string]string
+			resp.Diagnostics.Append(prior.ElementsAs(ctx, &priorElems, true)...)
+			if maps.Equal(priorElems, merged) {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, prior)...)
+				return
+			}
+		}
+	}
+	// An empty map is left unknown, as the API may read it back as null.
+	if len(merged) == 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, types.MapUnknown(types.StringType))...)
+		return
+	}
+	all, d := types.MapValueFrom(ctx, types.StringType, merged)
+	resp.Diagnostics.Append(d...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, all)...)
+}
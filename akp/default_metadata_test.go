@@ -0,0 +1,194 @@
+//go:build !acc
+
+package akp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringMap(elems map[string]string) types.Map {
+	if elems == nil {
+		return types.MapNull(types.StringType)
+	}
+	values := make(map[string]attr.Value, len(elems))
+	for k, v := range elems {
+		values[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, values)
+}
+
+func TestWithDefaults(t *testing.T) {
+	testCases := map[string]struct {
+		defaults   map[string]string
+		configured map[string]string
+		expected   map[string]string
+	}{
+		"no defaults":         {configured: map[string]string{"env": "prod"}, expected: map[string]string{"env": "prod"}},
+		"nothing configured":  {defaults: map[string]string{"owner": "platform"}, expected: map[string]string{"owner": "platform"}},
+		"configured override": {defaults: map[string]string{"env": "dev", "owner": "platform"}, configured: map[string]string{"env": "prod"}, expected: map[string]string{"env": "prod", "owner": "platform"}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, withDefaults(tc.defaults, tc.configured))
+		})
+	}
+}
+
+func TestWithoutDefaults(t *testing.T) {
+	defaults := map[string]string{"owner": "platform", "env": "dev"}
+
+	testCases := map[string]struct {
+		all        types.Map
+		configured types.Map
+		defaults   map[string]string
+		expected   types.Map
+	}{
+		"defaults only": {
+			all:        stringMap(map[string]string{"owner": "platform", "env": "dev"}),
+			configured: types.MapUnknown(types.StringType),
+			defaults:   defaults,
+			expected:   stringMap(map[string]string{}),
+		},
+		"configured and defaults": {
+			all:        stringMap(map[string]string{"owner": "platform", "env": "prod", "team": "a"}),
+			configured: stringMap(map[string]string{"env": "prod", "team": "a"}),
+			defaults:   defaults,
+			expected:   stringMap(map[string]string{"env": "prod", "team": "a"}),
+		},
+		"configured same as default": {
+			all:        stringMap(map[string]string{"owner": "platform", "env": "dev"}),
+			configured: stringMap(map[string]string{"owner": "platform"}),
+			defaults:   defaults,
+			expected:   stringMap(map[string]string{"owner": "platform"}),
+		},
+		"drift from default": {
+			all:        stringMap(map[string]string{"owner": "someone-else", "env": "dev"}),
+			configured: types.MapNull(types.StringType),
+			defaults:   defaults,
+			expected:   stringMap(map[string]string{"owner": "someone-else"}),
+		},
+		"no defaults": {
+			all:        stringMap(map[string]string{"owner": "platform"}),
+			configured: types.MapNull(types.StringType),
+			expected:   stringMap(map[string]string{"owner": "platform"}),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := withoutDefaults(context.Background(), &diags, tc.all, tc.configured, tc.defaults)
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+var metadataTestSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"labels":          schema.MapAttribute{ElementType: types.StringType, Optional: true, Computed: true},
+		"annotations":     schema.MapAttribute{ElementType: types.StringType, Optional: true, Computed: true},
+		"labels_all":      schema.MapAttribute{ElementType: types.StringType, Computed: true},
+		"annotations_all": schema.MapAttribute{ElementType: types.StringType, Computed: true},
+	},
+}
+
+type metadataTestModel struct {
+	Labels         types.Map `tfsdk:"labels"`
+	Annotations    types.Map `tfsdk:"annotations"`
+	LabelsAll      types.Map `tfsdk:"labels_all"`
+	AnnotationsAll types.Map `tfsdk:"annotations_all"`
+}
+
+func metadataTestPlan(t *testing.T, m *metadataTestModel) tfsdk.Plan {
+	t.Helper()
+	plan := tfsdk.Plan{
+		Schema: metadataTestSchema,
+		Raw:    tftypes.NewValue(metadataTestSchema.Type().TerraformType(context.Background()), nil),
+	}
+	if m != nil {
+		require.False(t, plan.Set(context.Background(), m).HasError())
+	}
+	return plan
+}
+
+func TestPlanMetadataDefaults(t *testing.T) {
+	cli := &AkpCli{defaultMetadata: metadataDefaults{
+		Labels: map[string]string{"owner": "platform"},
+	}}
+	unknown := types.MapUnknown(types.StringType)
+	null := types.MapNull(types.StringType)
+
+	testCases := map[string]struct {
+		plan              metadataTestModel
+		state             *metadataTestModel
+		expectedLabels    types.Map
+		expectAnnotations types.Map
+	}{
+		"create": {
+			plan:              metadataTestModel{Labels: stringMap(map[string]string{"env": "prod"}), Annotations: null, LabelsAll: unknown, AnnotationsAll: unknown},
+			expectedLabels:    stringMap(map[string]string{"env": "prod", "owner": "platform"}),
+			expectAnnotations: unknown,
+		},
+		"unknown labels": {
+			plan:              metadataTestModel{Labels: unknown, Annotations: unknown, LabelsAll: unknown, AnnotationsAll: unknown},
+			expectedLabels:    unknown,
+			expectAnnotations: unknown,
+		},
+		"unchanged": {
+			plan: metadataTestModel{
+				Labels: stringMap(map[string]string{}), Annotations: stringMap(map[string]string{}),
+				LabelsAll: unknown, AnnotationsAll: unknown,
+			},
+			state: &metadataTestModel{
+				Labels: stringMap(map[string]string{}), Annotations: stringMap(map[string]string{}),
+				LabelsAll: stringMap(map[string]string{"owner": "platform"}), AnnotationsAll: null,
+			},
+			expectedLabels:    stringMap(map[string]string{"owner": "platform"}),
+			expectAnnotations: null,
+		},
+		"default added": {
+			plan: metadataTestModel{
+				Labels: stringMap(map[string]string{"env": "prod"}), Annotations: null,
+				LabelsAll: stringMap(map[string]string{"env": "prod"}), AnnotationsAll: null,
+			},
+			state: &metadataTestModel{
+				Labels: stringMap(map[string]string{"env": "prod"}), Annotations: null,
+				LabelsAll: stringMap(map[string]string{"env": "prod"}), AnnotationsAll: null,
+			},
+			expectedLabels:    stringMap(map[string]string{"env": "prod", "owner": "platform"}),
+			expectAnnotations: null,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			plan := metadataTestPlan(t, &tc.plan)
+			req := resource.ModifyPlanRequest{
+				Plan:  plan,
+				State: tfsdk.State(metadataTestPlan(t, tc.state)),
+			}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			planMetadataDefaults(ctx, cli, req, resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var got metadataTestModel
+			require.False(t, resp.Plan.Get(ctx, &got).HasError())
+			assert.Equal(t, tc.expectedLabels, got.LabelsAll)
+			assert.Equal(t, tc.expectAnnotations, got.AnnotationsAll)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/ephemeral/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/akuity/terraform-provider-akp/akp/kube"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// getAgentManifestsEphemeralSchema returns the schema shared by the cluster and
+// Kargo agent manifests ephemeral resources. agent names the kind of agent
+// ("cluster" or "Kargo agent") and instance the kind of instance it belongs to.
+func getAgentManifestsEphemeralSchema(ctx context.Context, agent, instance string) schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Renders the agent install manifests of a %[1]s once the %[1]s has reconciled, "+
+			"so the agent can be installed by another tool (e.g. a GitOps pipeline or the `kubernetes` provider). "+
+			"The manifests contain the agent credentials; as an ephemeral resource they are never written to plan or state. "+
+			"Requires Terraform 1.10 or later.", agent),
+		Attributes: getAgentManifestsEphemeralAttributes(agent, instance),
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func getAgentManifestsEphemeralAttributes(agent, instance string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("The ID of the %s", agent),
+			Computed:            true,
+		},
+		"instance_id": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("The ID of the %s instance", instance),
+			Required:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("The name of the %s", agent),
+			Required:            true,
+		},
+		"manifests": schema.StringAttribute{
+			MarkdownDescription: "The agent install manifests as a multi-document YAML string",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"objects": schema.ListNestedAttribute{
+			MarkdownDescription: "The Kubernetes objects of `manifests`, in install order",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: getAgentManifestObjectEphemeralAttributes(),
+			},
+		},
+	}
+}
+
+func getAgentManifestObjectEphemeralAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"api_version": schema.StringAttribute{
+			MarkdownDescription: "API version of the object",
+			Computed:            true,
+		},
+		"kind": schema.StringAttribute{
+			MarkdownDescription: "Kind of the object",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the object",
+			Computed:            true,
+		},
+		"namespace": schema.StringAttribute{
+			MarkdownDescription: "Namespace of the object. Empty for cluster-scoped objects",
+			Computed:            true,
+		},
+		"manifest": schema.StringAttribute{
+			MarkdownDescription: "The object as a single YAML document, e.g. for `yamldecode()`",
+			Computed:            true,
+			Sensitive:           true,
+		},
+	}
+}
+
+// splitAgentManifests breaks the multi-document agent manifests into one entry
+// per Kubernetes object.
+func splitAgentManifests(manifests string) ([]types.AgentManifestObject, error) {
+	resources, err := kube.SplitYAML([]byte(manifests))
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]types.AgentManifestObject, 0, len(resources))
+	for _, un := range resources {
+		out, err := yaml.Marshal(un.Object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s %s: %w", un.GetKind(), un.GetName(), err)
+		}
+		objects = append(objects, types.AgentManifestObject{
+			APIVersion: tftypes.StringValue(un.GetAPIVersion()),
+			Kind:       tftypes.StringValue(un.GetKind()),
+			Name:       tftypes.StringValue(un.GetName()),
+			Namespace:  tftypes.StringValue(un.GetNamespace()),
+			Manifest:   tftypes.StringValue(string(out)),
+		})
+	}
+	return objects, nil
+}
+
+// setAgentManifests fills the computed attributes of data from the manifests
+// downloaded for the agent with the given ID.
+func setAgentManifests(data *types.AgentManifests, id, manifests string) error {
+	objects, err := splitAgentManifests(manifests)
+	if err != nil {
+		return err
+	}
+	data.ID = tftypes.StringValue(id)
+	data.Manifests = tftypes.StringValue(manifests)
+	data.Objects = objects
+	return nil
+}
@@ -0,0 +1,49 @@
+//go:build !acc
+
+package akp
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// If this test fails, a field has been added/removed to the AgentManifests related type.
+// Update the schema attribute accordingly.
+func TestNoNewAgentManifestsEphemeralFields(t *testing.T) {
+	s := getAgentManifestsEphemeralSchema(context.Background(), "cluster", "Argo CD")
+	assert.Equal(t, reflect.TypeFor[types.AgentManifests]().NumField(), len(s.Attributes)+len(s.Blocks))
+	assert.Equal(t, reflect.TypeFor[types.AgentManifestObject]().NumField(), len(getAgentManifestObjectEphemeralAttributes()))
+}
+
+func TestSplitAgentManifests(t *testing.T) {
+	manifests := `apiVersion: v1
+kind: Namespace
+metadata:
+  name: akuity
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: akuity-agent
+  namespace: akuity
+stringData:
+  token: s3cr3t
+`
+	objects, err := splitAgentManifests(manifests)
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	assert.Equal(t, "Namespace", objects[0].Kind.ValueString())
+	assert.Equal(t, "", objects[0].Namespace.ValueString())
+	assert.Equal(t, "v1", objects[1].APIVersion.ValueString())
+	assert.Equal(t, "akuity-agent", objects[1].Name.ValueString())
+	assert.Equal(t, "akuity", objects[1].Namespace.ValueString())
+	assert.Contains(t, objects[1].Manifest.ValueString(), "token: s3cr3t")
+	assert.NotContains(t, objects[1].Manifest.ValueString(), "---")
+}
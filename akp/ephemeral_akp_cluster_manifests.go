@@ -0,0 +1,63 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ ephemeral.EphemeralResource              = &AkpClusterManifestsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &AkpClusterManifestsEphemeralResource{}
+)
+
+func NewAkpClusterManifestsEphemeralResource() ephemeral.EphemeralResource {
+	return &AkpClusterManifestsEphemeralResource{}
+}
+
+// AkpClusterManifestsEphemeralResource renders the agent install manifests of an Argo CD cluster.
+type AkpClusterManifestsEphemeralResource struct {
+	BaseEphemeralResource
+}
+
+func (r *AkpClusterManifestsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_manifests"
+}
+
+func (r *AkpClusterManifestsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = getAgentManifestsEphemeralSchema(ctx, "cluster", "Argo CD")
+}
+
+func (r *AkpClusterManifestsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	tflog.Debug(ctx, "Opening a Cluster Manifests Ephemeral Resource")
+	var data types.AgentManifests
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := data.Timeouts.Open(ctx, defaultClusterTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cluster := &types.Cluster{InstanceID: data.InstanceID, Name: data.Name}
+	manifests, id, err := getManifests(ctx, r.akpCli.Cli, r.akpCli.OrgId, cluster, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get cluster manifests", err.Error())
+		return
+	}
+	if err := setAgentManifests(&data, id, manifests); err != nil {
+		resp.Diagnostics.AddError("Unable to parse cluster manifests", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
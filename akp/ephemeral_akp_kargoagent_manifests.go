@@ -0,0 +1,63 @@
+package akp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ ephemeral.EphemeralResource              = &AkpKargoAgentManifestsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &AkpKargoAgentManifestsEphemeralResource{}
+)
+
+func NewAkpKargoAgentManifestsEphemeralResource() ephemeral.EphemeralResource {
+	return &AkpKargoAgentManifestsEphemeralResource{}
+}
+
+// AkpKargoAgentManifestsEphemeralResource renders the agent install manifests of a Kargo agent.
+type AkpKargoAgentManifestsEphemeralResource struct {
+	BaseEphemeralResource
+}
+
+func (r *AkpKargoAgentManifestsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kargo_agent_manifests"
+}
+
+func (r *AkpKargoAgentManifestsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = getAgentManifestsEphemeralSchema(ctx, "Kargo agent", "Kargo")
+}
+
+func (r *AkpKargoAgentManifestsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	tflog.Debug(ctx, "Opening a Kargo Agent Manifests Ephemeral Resource")
+	var data types.AgentManifests
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout, diags := data.Timeouts.Open(ctx, defaultKargoAgentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agent := &types.KargoAgent{InstanceID: data.InstanceID, Name: data.Name}
+	manifests, id, err := getKargoManifests(ctx, r.akpCli.KargoCli, r.akpCli.OrgId, agent, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Kargo agent manifests", err.Error())
+		return
+	}
+	if err := setAgentManifests(&data, id, manifests); err != nil {
+		resp.Diagnostics.AddError("Unable to parse Kargo agent manifests", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,120 @@
+package fake
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// agent is an Argo CD cluster or a Kargo agent. Both are applied as a
+// Kubernetes-style manifest and read back with the manifest metadata folded
+// into `data`.
+type agent struct {
+	lifecycle
+	id       string
+	name     string
+	manifest map[string]any
+	// maintenance holds the fields set through the maintenance mode RPCs, which
+	// outlive re-applies of the manifest.
+	maintenance map[string]any
+}
+
+func (a *agent) apply(manifest map[string]any, convergeAfter int) {
+	a.manifest = manifest
+	a.applied(convergeAfter)
+}
+
+// setMaintenance records a maintenance mode change. Turning maintenance off
+// also clears the expiry.
+func (a *agent) setMaintenance(enabled bool, expiry *timestamppb.Timestamp) {
+	a.maintenance = map[string]any{"maintenanceMode": enabled, "maintenanceModeExpiry": nil}
+	if enabled && expiry != nil {
+		a.maintenance["maintenanceModeExpiry"] = expiry.AsTime().UTC().Format(time.RFC3339)
+	}
+}
+
+// render returns the object a read reports, followed by a trimmed variant that
+// only keeps the metadata-derived data fields.
+func (a *agent) render(sizePrefix string) (map[string]any, map[string]any) {
+	spec := deepCopy(childMap(a.manifest, "spec"))
+	meta := childMap(a.manifest, "metadata")
+
+	minimal := map[string]any{"namespace": meta["namespace"]}
+	for _, k := range []string{"labels", "annotations"} {
+		if v, ok := meta[k]; ok {
+			minimal[k] = v
+		}
+	}
+	data := childMap(spec, "data")
+	mergeInto(data, minimal)
+	protoEnum(data, "size", sizePrefix)
+	protoEnum(data, "connectivity", "CONNECTIVITY_")
+	if direct, ok := data["directClusterSpec"].(map[string]any); ok {
+		protoEnum(direct, "clusterType", "DIRECT_CLUSTER_TYPE_")
+	}
+	mergeInto(data, a.maintenance)
+	mergeInto(minimal, a.maintenance)
+
+	obj := a.observe()
+	obj["id"] = a.id
+	obj["name"] = a.name
+	for _, k := range []string{"description", "namespaceScoped"} {
+		if v, ok := spec[k]; ok {
+			obj[k] = v
+		}
+	}
+	trimmed := deepCopy(obj)
+	obj["data"] = data
+	trimmed["data"] = minimal
+	return obj, trimmed
+}
+
+func findAgent(agents []*agent, id string, byName bool) *agent {
+	for _, a := range agents {
+		if (byName && a.name == id) || (!byName && a.id == id) {
+			return a
+		}
+	}
+	return nil
+}
+
+func removeAgent(agents []*agent, a *agent) []*agent {
+	for i := range agents {
+		if agents[i] == a {
+			return append(agents[:i], agents[i+1:]...)
+		}
+	}
+	return agents
+}
+
+// manifestStream returns the agent install manifests the way the streaming
+// gateway call does. The error channel is left open so readers drain the data
+// channel before they stop.
+func manifestStream(a *agent) (<-chan *httpbody.HttpBody, <-chan error, error) {
+	namespace, _ := childMap(a.manifest, "metadata")["namespace"].(string)
+	if namespace == "" {
+		namespace = "akuity"
+	}
+	manifests := fmt.Sprintf(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: %[1]s
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: akuity-agent
+  namespace: %[1]s
+data:
+  agentId: %[2]s
+  agentName: %[3]s
+  generation: "%[4]d"
+`, namespace, a.id, a.name, a.generation)
+
+	res := make(chan *httpbody.HttpBody, 1)
+	res <- &httpbody.HttpBody{ContentType: "application/yaml", Data: []byte(manifests)}
+	close(res)
+	return res, make(chan error), nil
+}
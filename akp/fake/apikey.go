@@ -0,0 +1,119 @@
+package fake
+
+import (
+	"context"
+	"slices"
+
+	apikeyv1 "github.com/akuity/api-client-go/pkg/api/gen/apikey/v1"
+)
+
+type apiKey struct {
+	id          string
+	workspaceID string
+	description string
+	secret      string
+	createTime  string
+	expireTime  string
+	permissions map[string]any
+}
+
+// object renders the key. The secret is only ever returned on create.
+func (k *apiKey) object(withSecret bool) map[string]any {
+	obj := map[string]any{
+		"id":          k.id,
+		"description": k.description,
+		"createTime":  k.createTime,
+		"permissions": deepCopy(k.permissions),
+	}
+	if k.expireTime != "" {
+		obj["expireTime"] = k.expireTime
+	}
+	if withSecret {
+		obj["secret"] = k.secret
+	}
+	return obj
+}
+
+type apiKeyClient struct {
+	apikeyv1.APIKeyServiceGatewayClient
+	s *Server
+}
+
+func (s *Server) findAPIKey(workspaceID, id string) (*apiKey, error) {
+	for _, k := range s.apiKeys {
+		if k.id == id && (workspaceID == "" || k.workspaceID == workspaceID) {
+			return k, nil
+		}
+	}
+	return nil, notFound("API key", id)
+}
+
+// listAPIKeys renders the keys of one scope, without their secrets; an empty
+// workspaceID selects organization-level keys.
+func (s *Server) listAPIKeys(workspaceID string) map[string]any {
+	var keys []any
+	for _, k := range s.apiKeys {
+		if k.workspaceID == workspaceID {
+			obj := k.object(false)
+			obj["organizationId"] = s.orgID
+			keys = append(keys, obj)
+		}
+	}
+	return map[string]any{"apiKeys": keys}
+}
+
+func (c *apiKeyClient) GetAPIKey(_ context.Context, req *apikeyv1.GetAPIKeyRequest) (*apikeyv1.GetAPIKeyResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k, err := s.findAPIKey("", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	obj := k.object(false)
+	obj["organizationId"] = s.orgID
+	return decode(&apikeyv1.GetAPIKeyResponse{}, map[string]any{"apiKey": obj})
+}
+
+func (c *apiKeyClient) GetWorkspaceAPIKey(_ context.Context, req *apikeyv1.GetWorkspaceAPIKeyRequest) (*apikeyv1.GetWorkspaceAPIKeyResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetOrganizationId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	k, err := s.findAPIKey(req.GetWorkspaceId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	obj := k.object(false)
+	obj["organizationId"] = s.orgID
+	return decode(&apikeyv1.GetWorkspaceAPIKeyResponse{}, map[string]any{"apiKey": obj})
+}
+
+func (c *apiKeyClient) DeleteAPIKey(_ context.Context, req *apikeyv1.DeleteAPIKeyRequest) (*apikeyv1.DeleteAPIKeyResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k, err := s.findAPIKey("", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	s.apiKeys = slices.DeleteFunc(s.apiKeys, func(o *apiKey) bool { return o == k })
+	return &apikeyv1.DeleteAPIKeyResponse{}, nil
+}
+
+func (c *apiKeyClient) DeleteWorkspaceAPIKey(_ context.Context, req *apikeyv1.DeleteWorkspaceAPIKeyRequest) (*apikeyv1.DeleteWorkspaceAPIKeyResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetOrganizationId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	k, err := s.findAPIKey(req.GetWorkspaceId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	s.apiKeys = slices.DeleteFunc(s.apiKeys, func(o *apiKey) bool { return o == k })
+	return &apikeyv1.DeleteWorkspaceAPIKeyResponse{}, nil
+}
@@ -0,0 +1,318 @@
+package fake
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
+	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
+)
+
+type argoInstance struct {
+	lifecycle
+	id          string
+	name        string
+	workspaceID string
+	// state is the merged ApplyInstanceRequest in JSON shape, without the
+	// addressing fields and clusters.
+	state    map[string]any
+	clusters []*agent
+}
+
+// render returns the instance a read reports, followed by a variant without the
+// applied spec.
+func (i *argoInstance) render() (map[string]any, map[string]any) {
+	spec := childMap(childMap(i.state, "argocd"), "spec")
+	obj := i.observe()
+	obj["id"] = i.id
+	obj["name"] = i.name
+	obj["workspaceId"] = i.workspaceID
+	for _, k := range []string{"description", "version"} {
+		if v, ok := spec[k]; ok {
+			obj[k] = v
+		}
+	}
+	trimmed := deepCopy(obj)
+	if is, ok := spec["instanceSpec"].(map[string]any); ok {
+		is = deepCopy(is)
+		protoEnum(is, "connectivity", "CONNECTIVITY_")
+		obj["spec"] = is
+	}
+	return obj, trimmed
+}
+
+type argoCDClient struct {
+	argocdv1.ArgoCDServiceGatewayClient
+	s *Server
+}
+
+func (s *Server) findArgoInstance(id string, idType idv1.Type) *argoInstance {
+	for _, i := range s.argoInstances {
+		if (idType == idv1.Type_NAME && i.name == id) || (idType != idv1.Type_NAME && i.id == id) {
+			return i
+		}
+	}
+	return nil
+}
+
+func (c *argoCDClient) ApplyInstance(_ context.Context, req *argocdv1.ApplyInstanceRequest) (*argocdv1.ApplyInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	m, err := toMap(req)
+	if err != nil {
+		return nil, err
+	}
+	inst := s.findArgoInstance(req.GetId(), req.GetIdType())
+	if inst == nil {
+		if req.GetIdType() != idv1.Type_NAME || req.GetArgocd() == nil {
+			return nil, notFound("instance", req.GetId())
+		}
+		workspaceID, err := s.resolveWorkspace(req.GetWorkspaceId())
+		if err != nil {
+			return nil, err
+		}
+		inst = &argoInstance{id: s.newID("inst"), name: req.GetId(), workspaceID: workspaceID, state: map[string]any{}}
+		s.argoInstances = append(s.argoInstances, inst)
+	}
+	for _, k := range []string{"organizationId", "id", "idType", "workspaceId", "pruneResourceTypes", "clusters"} {
+		delete(m, k)
+	}
+	if len(m) > 0 {
+		for k, v := range m {
+			inst.state[k] = v
+		}
+		inst.applied(s.convergeAfter)
+	}
+	for _, cl := range req.GetClusters() {
+		manifest := cl.AsMap()
+		name := metadataName(manifest)
+		a := findAgent(inst.clusters, name, true)
+		if a == nil {
+			a = &agent{id: s.newID("cluster"), name: name}
+			inst.clusters = append(inst.clusters, a)
+		}
+		a.apply(manifest, s.convergeAfter)
+	}
+	return &argocdv1.ApplyInstanceResponse{}, nil
+}
+
+func (c *argoCDClient) GetInstance(_ context.Context, req *argocdv1.GetInstanceRequest) (*argocdv1.GetInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	inst := s.findArgoInstance(req.GetId(), req.GetIdType())
+	if inst == nil {
+		return nil, notFound("instance", req.GetId())
+	}
+	i, err := decodeArgoInstance(inst)
+	if err != nil {
+		return nil, err
+	}
+	return &argocdv1.GetInstanceResponse{Instance: i}, nil
+}
+
+func (c *argoCDClient) ListInstances(_ context.Context, req *argocdv1.ListInstancesRequest) (*argocdv1.ListInstancesResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	resp := &argocdv1.ListInstancesResponse{}
+	for _, inst := range s.argoInstances {
+		if req.GetWorkspaceId() != "" && req.GetWorkspaceId() != inst.workspaceID {
+			continue
+		}
+		i, err := decodeArgoInstance(inst)
+		if err != nil {
+			return nil, err
+		}
+		resp.Instances = append(resp.Instances, i)
+	}
+	return resp, nil
+}
+
+func decodeArgoInstance(inst *argoInstance) (*argocdv1.Instance, error) {
+	obj, trimmed := inst.render()
+	return decodeFirst(func() *argocdv1.Instance { return &argocdv1.Instance{} }, obj, trimmed)
+}
+
+func (c *argoCDClient) ExportInstance(_ context.Context, req *argocdv1.ExportInstanceRequest) (*argocdv1.ExportInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	inst := s.findArgoInstance(req.GetId(), req.GetIdType())
+	if inst == nil {
+		return nil, notFound("instance", req.GetId())
+	}
+	export := map[string]any{}
+	for k, v := range inst.state {
+		// Like the platform, never hand secrets back.
+		if !strings.Contains(strings.ToLower(k), "secret") {
+			export[k] = deepCopy(v)
+		}
+	}
+	return decode(&argocdv1.ExportInstanceResponse{}, export)
+}
+
+func (c *argoCDClient) PatchInstance(_ context.Context, req *argocdv1.PatchInstanceRequest) (*argocdv1.PatchInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	inst := s.findArgoInstance(req.GetId(), idv1.Type_ID)
+	if inst == nil {
+		return nil, notFound("instance", req.GetId())
+	}
+	if patch, ok := req.GetPatch().AsMap()["spec"].(map[string]any); ok {
+		argocd := childMap(inst.state, "argocd")
+		spec := childMap(argocd, "spec")
+		instanceSpec := childMap(spec, "instanceSpec")
+		mergeInto(instanceSpec, patch)
+		spec["instanceSpec"] = instanceSpec
+		argocd["spec"] = spec
+		inst.state["argocd"] = argocd
+		inst.applied(s.convergeAfter)
+	}
+	return &argocdv1.PatchInstanceResponse{}, nil
+}
+
+func (c *argoCDClient) DeleteInstance(_ context.Context, req *argocdv1.DeleteInstanceRequest) (*argocdv1.DeleteInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	for i, inst := range s.argoInstances {
+		if inst.id == req.GetId() {
+			s.argoInstances = append(s.argoInstances[:i], s.argoInstances[i+1:]...)
+			return &argocdv1.DeleteInstanceResponse{}, nil
+		}
+	}
+	return nil, notFound("instance", req.GetId())
+}
+
+// cluster looks up a cluster the way the platform does: a cluster that cannot
+// be found, or whose instance cannot be found, is reported as PermissionDenied.
+func (s *Server) cluster(orgID, instanceID, id string, byName bool) (*argoInstance, *agent, error) {
+	if err := s.checkOrg(orgID); err != nil {
+		return nil, nil, err
+	}
+	inst := s.findArgoInstance(instanceID, idv1.Type_ID)
+	if inst != nil {
+		if a := findAgent(inst.clusters, id, byName); a != nil {
+			return inst, a, nil
+		}
+	}
+	return nil, nil, status.Errorf(codes.PermissionDenied, "cluster %q is not accessible", id)
+}
+
+func (c *argoCDClient) GetInstanceCluster(_ context.Context, req *argocdv1.GetInstanceClusterRequest) (*argocdv1.GetInstanceClusterResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, a, err := s.cluster(req.GetOrganizationId(), req.GetInstanceId(), req.GetId(), req.GetIdType() == idv1.Type_NAME)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := decodeCluster(a)
+	if err != nil {
+		return nil, err
+	}
+	return &argocdv1.GetInstanceClusterResponse{Cluster: cl}, nil
+}
+
+func (c *argoCDClient) ListInstanceClusters(_ context.Context, req *argocdv1.ListInstanceClustersRequest) (*argocdv1.ListInstanceClustersResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	inst := s.findArgoInstance(req.GetInstanceId(), idv1.Type_ID)
+	if inst == nil {
+		return nil, notFound("instance", req.GetInstanceId())
+	}
+	resp := &argocdv1.ListInstanceClustersResponse{}
+	for _, a := range inst.clusters {
+		cl, err := decodeCluster(a)
+		if err != nil {
+			return nil, err
+		}
+		resp.Clusters = append(resp.Clusters, cl)
+	}
+	return resp, nil
+}
+
+func (c *argoCDClient) DeleteInstanceCluster(_ context.Context, req *argocdv1.DeleteInstanceClusterRequest) (*argocdv1.DeleteInstanceClusterResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	inst := s.findArgoInstance(req.GetInstanceId(), idv1.Type_ID)
+	if inst == nil {
+		return nil, notFound("instance", req.GetInstanceId())
+	}
+	a := findAgent(inst.clusters, req.GetId(), false)
+	if a == nil {
+		return nil, notFound("cluster", req.GetId())
+	}
+	inst.clusters = removeAgent(inst.clusters, a)
+	return &argocdv1.DeleteInstanceClusterResponse{}, nil
+}
+
+func (c *argoCDClient) SetClusterMaintenanceMode(_ context.Context, req *argocdv1.SetClusterMaintenanceModeRequest) (*argocdv1.SetClusterMaintenanceModeResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	inst := s.findArgoInstance(req.GetInstanceId(), idv1.Type_ID)
+	if inst == nil {
+		return nil, notFound("instance", req.GetInstanceId())
+	}
+	for _, name := range req.GetClusterNames() {
+		a := findAgent(inst.clusters, name, true)
+		if a == nil {
+			return nil, notFound("cluster", name)
+		}
+		a.setMaintenance(req.GetMaintenanceMode(), req.GetExpiry())
+	}
+	return &argocdv1.SetClusterMaintenanceModeResponse{}, nil
+}
+
+func (c *argoCDClient) GetInstanceClusterManifests(_ context.Context, req *argocdv1.GetInstanceClusterManifestsRequest) (<-chan *httpbody.HttpBody, <-chan error, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, a, err := s.cluster(req.GetOrganizationId(), req.GetInstanceId(), req.GetId(), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifestStream(a)
+}
+
+func decodeCluster(a *agent) (*argocdv1.Cluster, error) {
+	obj, trimmed := a.render("CLUSTER_SIZE_")
+	return decodeFirst(func() *argocdv1.Cluster { return &argocdv1.Cluster{} }, obj, trimmed)
+}
@@ -0,0 +1,328 @@
+package fake
+
+import (
+	"context"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+
+	kargov1 "github.com/akuity/api-client-go/pkg/api/gen/kargo/v1"
+	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
+)
+
+// kargoSecretKeys lists the apply fields that the platform never exports.
+var kargoSecretKeys = []string{"kargoSecret", "repoCredentials"}
+
+type kargoInstance struct {
+	lifecycle
+	id          string
+	name        string
+	workspaceID string
+	// state is the merged ApplyKargoInstanceRequest in JSON shape, without the
+	// addressing fields and agents.
+	state map[string]any
+	// defaultShardAgent is managed through PatchKargoInstance only; applies
+	// leave it untouched.
+	defaultShardAgent string
+	agents            []*agent
+}
+
+// render returns the instance a read reports, followed by a variant without the
+// applied spec.
+func (k *kargoInstance) render() (map[string]any, map[string]any) {
+	spec := childMap(childMap(k.state, "kargo"), "spec")
+	obj := k.observe()
+	obj["id"] = k.id
+	obj["name"] = k.name
+	obj["workspaceId"] = k.workspaceID
+	for _, key := range []string{"description", "version"} {
+		if v, ok := spec[key]; ok {
+			obj[key] = v
+		}
+	}
+	trimmed := deepCopy(obj)
+	trimmed["spec"] = map[string]any{"defaultShardAgent": k.defaultShardAgent}
+	instanceSpec := deepCopy(childMap(spec, "kargoInstanceSpec"))
+	instanceSpec["defaultShardAgent"] = k.defaultShardAgent
+	obj["spec"] = instanceSpec
+	return obj, trimmed
+}
+
+type kargoClient struct {
+	kargov1.KargoServiceGatewayClient
+	s *Server
+}
+
+func (s *Server) findKargoInstance(id string, byName bool) *kargoInstance {
+	for _, k := range s.kargoInstances {
+		if (byName && k.name == id) || (!byName && k.id == id) {
+			return k
+		}
+	}
+	return nil
+}
+
+func decodeKargoInstance(k *kargoInstance) (*kargov1.KargoInstance, error) {
+	obj, trimmed := k.render()
+	return decodeFirst(func() *kargov1.KargoInstance { return &kargov1.KargoInstance{} }, obj, trimmed)
+}
+
+func decodeKargoAgent(a *agent) (*kargov1.KargoAgent, error) {
+	obj, trimmed := a.render("KARGO_AGENT_SIZE_")
+	return decodeFirst(func() *kargov1.KargoAgent { return &kargov1.KargoAgent{} }, obj, trimmed)
+}
+
+func (c *kargoClient) ApplyKargoInstance(_ context.Context, req *kargov1.ApplyKargoInstanceRequest) (*kargov1.ApplyKargoInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	m, err := toMap(req)
+	if err != nil {
+		return nil, err
+	}
+	byName := req.GetIdType() == idv1.Type_NAME
+	k := s.findKargoInstance(req.GetId(), byName)
+	if k == nil {
+		if !byName || req.GetKargo() == nil {
+			return nil, notFound("kargo instance", req.GetId())
+		}
+		workspaceID, err := s.resolveWorkspace(req.GetWorkspaceId())
+		if err != nil {
+			return nil, err
+		}
+		k = &kargoInstance{id: s.newID("kargo"), name: req.GetId(), workspaceID: workspaceID, state: map[string]any{}}
+		s.kargoInstances = append(s.kargoInstances, k)
+	}
+	for _, key := range []string{"organizationId", "id", "idType", "workspaceId", "agents"} {
+		delete(m, key)
+	}
+	if len(m) > 0 {
+		for key, v := range m {
+			k.state[key] = v
+		}
+		k.applied(s.convergeAfter)
+	}
+	for _, ag := range req.GetAgents() {
+		manifest := ag.AsMap()
+		name := metadataName(manifest)
+		a := findAgent(k.agents, name, true)
+		if a == nil {
+			a = &agent{id: s.newID("agent"), name: name}
+			k.agents = append(k.agents, a)
+		}
+		a.apply(manifest, s.convergeAfter)
+	}
+	return &kargov1.ApplyKargoInstanceResponse{}, nil
+}
+
+func (c *kargoClient) GetKargoInstance(_ context.Context, req *kargov1.GetKargoInstanceRequest) (*kargov1.GetKargoInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	k := s.findKargoInstance(req.GetName(), true)
+	if k == nil {
+		return nil, notFound("kargo instance", req.GetName())
+	}
+	inst, err := decodeKargoInstance(k)
+	if err != nil {
+		return nil, err
+	}
+	return &kargov1.GetKargoInstanceResponse{Instance: inst}, nil
+}
+
+func (c *kargoClient) ListKargoInstances(_ context.Context, req *kargov1.ListKargoInstancesRequest) (*kargov1.ListKargoInstancesResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	resp := &kargov1.ListKargoInstancesResponse{}
+	for _, k := range s.kargoInstances {
+		if req.GetWorkspaceId() != "" && req.GetWorkspaceId() != k.workspaceID {
+			continue
+		}
+		inst, err := decodeKargoInstance(k)
+		if err != nil {
+			return nil, err
+		}
+		resp.Instances = append(resp.Instances, inst)
+	}
+	return resp, nil
+}
+
+func (c *kargoClient) PatchKargoInstance(_ context.Context, req *kargov1.PatchKargoInstanceRequest) (*kargov1.PatchKargoInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	k := s.findKargoInstance(req.GetId(), false)
+	if k == nil {
+		return nil, notFound("kargo instance", req.GetId())
+	}
+	spec := childMap(req.GetPatch().AsMap(), "spec")
+	if v, ok := spec["defaultShardAgent"]; ok {
+		k.defaultShardAgent, _ = v.(string)
+		delete(spec, "defaultShardAgent")
+	}
+	if len(spec) > 0 {
+		kargo := childMap(k.state, "kargo")
+		kargoSpec := childMap(kargo, "spec")
+		instanceSpec := childMap(kargoSpec, "kargoInstanceSpec")
+		mergeInto(instanceSpec, spec)
+		kargoSpec["kargoInstanceSpec"] = instanceSpec
+		kargo["spec"] = kargoSpec
+		k.state["kargo"] = kargo
+	}
+	k.applied(s.convergeAfter)
+	inst, err := decodeKargoInstance(k)
+	if err != nil {
+		return nil, err
+	}
+	return &kargov1.PatchKargoInstanceResponse{Instance: inst}, nil
+}
+
+func (c *kargoClient) ExportKargoInstance(_ context.Context, req *kargov1.ExportKargoInstanceRequest) (*kargov1.ExportKargoInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	k := s.findKargoInstance(req.GetId(), false)
+	if k == nil {
+		return nil, notFound("kargo instance", req.GetId())
+	}
+	export := deepCopy(k.state)
+	for _, key := range kargoSecretKeys {
+		delete(export, key)
+	}
+	var agents []any
+	for _, a := range k.agents {
+		agents = append(agents, deepCopy(a.manifest))
+	}
+	if len(agents) > 0 {
+		export["agents"] = agents
+	}
+	return decode(&kargov1.ExportKargoInstanceResponse{}, export)
+}
+
+func (c *kargoClient) DeleteInstance(_ context.Context, req *kargov1.DeleteInstanceRequest) (*kargov1.DeleteInstanceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	for i, k := range s.kargoInstances {
+		if k.id == req.GetId() {
+			s.kargoInstances = append(s.kargoInstances[:i], s.kargoInstances[i+1:]...)
+			return &kargov1.DeleteInstanceResponse{}, nil
+		}
+	}
+	return nil, notFound("kargo instance", req.GetId())
+}
+
+func (c *kargoClient) ListKargoInstanceAgents(_ context.Context, req *kargov1.ListKargoInstanceAgentsRequest) (*kargov1.ListKargoInstanceAgentsResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	k := s.findKargoInstance(req.GetInstanceId(), false)
+	if k == nil {
+		return nil, notFound("kargo instance", req.GetInstanceId())
+	}
+	resp := &kargov1.ListKargoInstanceAgentsResponse{}
+	for _, a := range k.agents {
+		ag, err := decodeKargoAgent(a)
+		if err != nil {
+			return nil, err
+		}
+		resp.Agents = append(resp.Agents, ag)
+	}
+	return resp, nil
+}
+
+func (c *kargoClient) GetKargoInstanceAgent(_ context.Context, req *kargov1.GetKargoInstanceAgentRequest) (*kargov1.GetKargoInstanceAgentResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, err := s.kargoAgent(req.GetOrganizationId(), req.GetInstanceId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	ag, err := decodeKargoAgent(a)
+	if err != nil {
+		return nil, err
+	}
+	return &kargov1.GetKargoInstanceAgentResponse{Agent: ag}, nil
+}
+
+func (c *kargoClient) DeleteInstanceAgent(_ context.Context, req *kargov1.DeleteInstanceAgentRequest) (*kargov1.DeleteInstanceAgentResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, err := s.kargoAgent(req.GetOrganizationId(), req.GetInstanceId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	k := s.findKargoInstance(req.GetInstanceId(), false)
+	k.agents = removeAgent(k.agents, a)
+	return &kargov1.DeleteInstanceAgentResponse{}, nil
+}
+
+func (c *kargoClient) SetAgentMaintenanceMode(_ context.Context, req *kargov1.SetAgentMaintenanceModeRequest) (*kargov1.SetAgentMaintenanceModeResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	k := s.findKargoInstance(req.GetInstanceId(), false)
+	if k == nil {
+		return nil, notFound("kargo instance", req.GetInstanceId())
+	}
+	for _, name := range req.GetAgentNames() {
+		a := findAgent(k.agents, name, true)
+		if a == nil {
+			return nil, notFound("kargo agent", name)
+		}
+		a.setMaintenance(req.GetMaintenanceMode(), req.GetExpiry())
+	}
+	return &kargov1.SetAgentMaintenanceModeResponse{}, nil
+}
+
+func (c *kargoClient) GetKargoInstanceAgentManifests(_ context.Context, req *kargov1.GetKargoInstanceAgentManifestsRequest) (<-chan *httpbody.HttpBody, <-chan error, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, err := s.kargoAgent(req.GetOrganizationId(), req.GetInstanceId(), req.GetId())
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifestStream(a)
+}
+
+func (s *Server) kargoAgent(orgID, instanceID, id string) (*agent, error) {
+	if err := s.checkOrg(orgID); err != nil {
+		return nil, err
+	}
+	k := s.findKargoInstance(instanceID, false)
+	if k == nil {
+		return nil, notFound("kargo instance", instanceID)
+	}
+	a := findAgent(k.agents, id, false)
+	if a == nil {
+		return nil, notFound("kargo agent", id)
+	}
+	return a, nil
+}
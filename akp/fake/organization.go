@@ -0,0 +1,751 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	accesscontrolv1 "github.com/akuity/api-client-go/pkg/api/gen/accesscontrol/v1"
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+)
+
+type workspace struct {
+	id          string
+	name        string
+	description string
+	isDefault   bool
+	createTime  string
+}
+
+func (w *workspace) object() map[string]any {
+	return map[string]any{
+		"id":          w.id,
+		"name":        w.name,
+		"description": w.description,
+		"isDefault":   w.isDefault,
+		"createTime":  w.createTime,
+	}
+}
+
+type team struct {
+	name        string
+	description string
+	customRoles []string
+	createTime  string
+	members     []*teamMember
+}
+
+type teamMember struct {
+	id    string
+	email string
+}
+
+func (m *teamMember) object() map[string]any {
+	return map[string]any{"id": m.id, "email": m.email}
+}
+
+type customRole struct {
+	id          string
+	workspaceID string
+	name        string
+	description string
+	policy      string
+}
+
+func (r *customRole) object() map[string]any {
+	return map[string]any{
+		"id":          r.id,
+		"name":        r.name,
+		"description": r.description,
+		"policy":      r.policy,
+	}
+}
+
+type workspaceMember struct {
+	id          string
+	workspaceID string
+	role        string
+	userEmail   string
+	teamName    string
+}
+
+func (m *workspaceMember) object() map[string]any {
+	obj := map[string]any{"id": m.id, "role": m.role}
+	if m.teamName != "" {
+		obj["team"] = map[string]any{"name": m.teamName}
+	} else {
+		obj["user"] = map[string]any{"email": m.userEmail}
+	}
+	return obj
+}
+
+type organizationClient struct {
+	orgcv1.OrganizationServiceGatewayClient
+	s *Server
+}
+
+func (c *organizationClient) GetOrganization(_ context.Context, req *orgcv1.GetOrganizationRequest) (*orgcv1.GetOrganizationResponse, error) {
+	s := c.s
+	if req.GetId() != s.orgID && req.GetId() != s.orgName {
+		return nil, notFound("organization", req.GetId())
+	}
+	return decode(&orgcv1.GetOrganizationResponse{}, map[string]any{
+		"organization": map[string]any{"id": s.orgID, "name": s.orgName},
+	})
+}
+
+// resolveWorkspace returns the ID of the workspace identified by ID or name,
+// or of the default workspace when ref is empty.
+func (s *Server) resolveWorkspace(ref string) (string, error) {
+	for _, w := range s.workspaces {
+		if (ref == "" && w.isDefault) || w.id == ref || w.name == ref {
+			return w.id, nil
+		}
+	}
+	return "", notFound("workspace", ref)
+}
+
+func (s *Server) findWorkspace(orgID, id string) (*workspace, error) {
+	if err := s.checkOrg(orgID); err != nil {
+		return nil, err
+	}
+	for _, w := range s.workspaces {
+		if w.id == id {
+			return w, nil
+		}
+	}
+	return nil, notFound("workspace", id)
+}
+
+func (c *organizationClient) ListWorkspaces(_ context.Context, req *orgcv1.ListWorkspacesRequest) (*orgcv1.ListWorkspacesResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	var workspaces []any
+	for _, w := range s.workspaces {
+		workspaces = append(workspaces, w.object())
+	}
+	return decode(&orgcv1.ListWorkspacesResponse{}, map[string]any{"workspaces": workspaces})
+}
+
+func (c *organizationClient) GetWorkspace(_ context.Context, req *orgcv1.GetWorkspaceRequest) (*orgcv1.GetWorkspaceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, err := s.findWorkspace(req.GetOrganizationId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.GetWorkspaceResponse{}, map[string]any{"workspace": w.object()})
+}
+
+func (c *organizationClient) CreateWorkspace(_ context.Context, req *orgcv1.CreateWorkspaceRequest) (*orgcv1.CreateWorkspaceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	for _, w := range s.workspaces {
+		if w.name == req.GetName() {
+			return nil, status.Errorf(codes.AlreadyExists, "workspace %q already exists", req.GetName())
+		}
+	}
+	w := &workspace{
+		id:          s.newID("ws"),
+		name:        req.GetName(),
+		description: req.GetDescription(),
+		createTime:  now(),
+	}
+	s.workspaces = append(s.workspaces, w)
+	return decode(&orgcv1.CreateWorkspaceResponse{}, map[string]any{"workspace": w.object()})
+}
+
+func (c *organizationClient) UpdateWorkspace(_ context.Context, req *orgcv1.UpdateWorkspaceRequest) (*orgcv1.UpdateWorkspaceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, err := s.findWorkspace(req.GetOrganizationId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	w.name = req.GetName()
+	w.description = req.GetDescription()
+	return decode(&orgcv1.UpdateWorkspaceResponse{}, map[string]any{"workspace": w.object()})
+}
+
+func (c *organizationClient) DeleteWorkspace(_ context.Context, req *orgcv1.DeleteWorkspaceRequest) (*orgcv1.DeleteWorkspaceResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, err := s.findWorkspace(req.GetOrganizationId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if w.isDefault {
+		return nil, status.Error(codes.FailedPrecondition, "the default workspace cannot be deleted")
+	}
+	for _, i := range s.argoInstances {
+		if i.workspaceID == w.id {
+			return nil, status.Errorf(codes.FailedPrecondition, "workspace %q still contains instance %q", w.name, i.name)
+		}
+	}
+	for _, k := range s.kargoInstances {
+		if k.workspaceID == w.id {
+			return nil, status.Errorf(codes.FailedPrecondition, "workspace %q still contains instance %q", w.name, k.name)
+		}
+	}
+	s.workspaces = slices.DeleteFunc(s.workspaces, func(o *workspace) bool { return o == w })
+	s.members = slices.DeleteFunc(s.members, func(m *workspaceMember) bool { return m.workspaceID == w.id })
+	s.customRoles = slices.DeleteFunc(s.customRoles, func(r *customRole) bool { return r.workspaceID == w.id })
+	s.apiKeys = slices.DeleteFunc(s.apiKeys, func(k *apiKey) bool { return k.workspaceID == w.id })
+	return &orgcv1.DeleteWorkspaceResponse{}, nil
+}
+
+func (s *Server) findTeam(orgID, name string) (*team, error) {
+	if err := s.checkOrg(orgID); err != nil {
+		return nil, err
+	}
+	for _, t := range s.teams {
+		if t.name == name {
+			return t, nil
+		}
+	}
+	return nil, notFound("team", name)
+}
+
+func (s *Server) teamObject(t *team) map[string]any {
+	return map[string]any{
+		"team": map[string]any{
+			"name":        t.name,
+			"description": t.description,
+			"createTime":  t.createTime,
+			"memberCount": int64(len(t.members)),
+		},
+		"customRoles": slices.Clone(t.customRoles),
+	}
+}
+
+func (c *organizationClient) CreateTeam(_ context.Context, req *orgcv1.CreateTeamRequest) (*orgcv1.CreateTeamResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findTeam(req.GetOrganizationId(), req.GetName()); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "team %q already exists", req.GetName())
+	} else if status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+	t := &team{
+		name:        req.GetName(),
+		description: req.GetDescription(),
+		customRoles: slices.Clone(req.GetCustomRoles()),
+		createTime:  now(),
+	}
+	s.teams = append(s.teams, t)
+	return decode(&orgcv1.CreateTeamResponse{}, map[string]any{"userTeam": s.teamObject(t)})
+}
+
+func (c *organizationClient) GetTeam(_ context.Context, req *orgcv1.GetTeamRequest) (*orgcv1.GetTeamResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.findTeam(req.GetOrganizationId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.GetTeamResponse{}, map[string]any{"userTeam": s.teamObject(t)})
+}
+
+func (c *organizationClient) UpdateTeam(_ context.Context, req *orgcv1.UpdateTeamRequest) (*orgcv1.UpdateTeamResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.findTeam(req.GetOrganizationId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	t.description = req.GetDescription()
+	t.customRoles = slices.Clone(req.GetCustomRoles())
+	return decode(&orgcv1.UpdateTeamResponse{}, map[string]any{"userTeam": s.teamObject(t)})
+}
+
+func (c *organizationClient) DeleteTeam(_ context.Context, req *orgcv1.DeleteTeamRequest) (*orgcv1.DeleteTeamResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.findTeam(req.GetOrganizationId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	s.teams = slices.DeleteFunc(s.teams, func(o *team) bool { return o == t })
+	s.members = slices.DeleteFunc(s.members, func(m *workspaceMember) bool { return m.teamName == t.name })
+	return &orgcv1.DeleteTeamResponse{}, nil
+}
+
+func (c *organizationClient) ListTeams(_ context.Context, req *orgcv1.ListTeamsRequest) (*orgcv1.ListTeamsResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	var teams []any
+	for _, t := range s.teams {
+		teams = append(teams, s.teamObject(t))
+	}
+	return decode(&orgcv1.ListTeamsResponse{}, map[string]any{"userTeams": teams})
+}
+
+func (c *organizationClient) ListTeamMembers(_ context.Context, req *orgcv1.ListTeamMembersRequest) (*orgcv1.ListTeamMembersResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.findTeam(req.GetOrganizationId(), req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	members := make([]any, 0, len(t.members))
+	for _, m := range t.members {
+		members = append(members, m.object())
+	}
+	return decode(&orgcv1.ListTeamMembersResponse{}, map[string]any{"teamMembers": members, "count": int64(len(members))})
+}
+
+// AddTeamMember accepts any email; the fake has no notion of organization
+// users. Emails are stored lowercased, like the real backend does.
+func (c *organizationClient) AddTeamMember(_ context.Context, req *orgcv1.AddTeamMemberRequest) (*orgcv1.AddTeamMemberResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.findTeam(req.GetOrganizationId(), req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	email := strings.ToLower(req.GetEmail())
+	if email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	for _, m := range t.members {
+		if m.email == email {
+			return nil, status.Errorf(codes.AlreadyExists, "%q is already a member of team %q", email, t.name)
+		}
+	}
+	m := &teamMember{id: s.newID("tm"), email: email}
+	t.members = append(t.members, m)
+	return decode(&orgcv1.AddTeamMemberResponse{}, map[string]any{"teamMember": m.object()})
+}
+
+func (c *organizationClient) RemoveTeamMember(_ context.Context, req *orgcv1.RemoveTeamMemberRequest) (*orgcv1.RemoveTeamMemberResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.findTeam(req.GetOrganizationId(), req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(t.members, func(m *teamMember) bool { return m.id == req.GetId() })
+	if i < 0 {
+		return nil, notFound("team member", req.GetId())
+	}
+	t.members = slices.Delete(t.members, i, i+1)
+	return &orgcv1.RemoveTeamMemberResponse{}, nil
+}
+
+// findCustomRole looks up a role within a scope; an empty workspaceID selects
+// organization-level roles.
+func (s *Server) findCustomRole(workspaceID, id string) (*customRole, error) {
+	for _, r := range s.customRoles {
+		if r.id == id && r.workspaceID == workspaceID {
+			return r, nil
+		}
+	}
+	return nil, notFound("custom role", id)
+}
+
+func (s *Server) createCustomRole(workspaceID, name, description, policy string) (map[string]any, error) {
+	for _, r := range s.customRoles {
+		if r.name == name && r.workspaceID == workspaceID {
+			return nil, status.Errorf(codes.AlreadyExists, "custom role %q already exists", name)
+		}
+	}
+	r := &customRole{id: s.newID("role"), workspaceID: workspaceID, name: name, description: description, policy: policy}
+	s.customRoles = append(s.customRoles, r)
+	return map[string]any{"customRole": r.object()}, nil
+}
+
+func (s *Server) updateCustomRole(workspaceID, id, name, description, policy string) (map[string]any, error) {
+	r, err := s.findCustomRole(workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+	r.name, r.description, r.policy = name, description, policy
+	return map[string]any{"customRole": r.object()}, nil
+}
+
+func (s *Server) deleteCustomRole(workspaceID, id string) error {
+	r, err := s.findCustomRole(workspaceID, id)
+	if err != nil {
+		return err
+	}
+	s.customRoles = slices.DeleteFunc(s.customRoles, func(o *customRole) bool { return o == r })
+	return nil
+}
+
+// listCustomRoles renders the roles of one scope; an empty workspaceID selects
+// organization-level roles.
+func (s *Server) listCustomRoles(workspaceID string) map[string]any {
+	var roles []any
+	for _, r := range s.customRoles {
+		if r.workspaceID == workspaceID {
+			roles = append(roles, r.object())
+		}
+	}
+	return map[string]any{"customRoles": roles}
+}
+
+func (c *organizationClient) ListCustomRoles(_ context.Context, req *orgcv1.ListCustomRolesRequest) (*orgcv1.ListCustomRolesResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.ListCustomRolesResponse{}, s.listCustomRoles(""))
+}
+
+func (c *organizationClient) CreateCustomRole(_ context.Context, req *orgcv1.CreateCustomRoleRequest) (*orgcv1.CreateCustomRoleResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	obj, err := s.createCustomRole("", req.GetName(), req.GetDescription(), req.GetPolicy())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.CreateCustomRoleResponse{}, obj)
+}
+
+func (c *organizationClient) GetCustomRole(_ context.Context, req *orgcv1.GetCustomRoleRequest) (*orgcv1.GetCustomRoleResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	r, err := s.findCustomRole("", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.GetCustomRoleResponse{}, map[string]any{"customRole": r.object()})
+}
+
+func (c *organizationClient) UpdateCustomRole(_ context.Context, req *orgcv1.UpdateCustomRoleRequest) (*orgcv1.UpdateCustomRoleResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	obj, err := s.updateCustomRole("", req.GetId(), req.GetName(), req.GetDescription(), req.GetPolicy())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.UpdateCustomRoleResponse{}, obj)
+}
+
+func (c *organizationClient) DeleteCustomRole(_ context.Context, req *orgcv1.DeleteCustomRoleRequest) (*orgcv1.DeleteCustomRoleResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetOrganizationId()); err != nil {
+		return nil, err
+	}
+	if err := s.deleteCustomRole("", req.GetId()); err != nil {
+		return nil, err
+	}
+	return &orgcv1.DeleteCustomRoleResponse{}, nil
+}
+
+func (c *organizationClient) ListWorkspaceCustomRoles(_ context.Context, req *orgcv1.ListWorkspaceCustomRolesRequest) (*orgcv1.ListWorkspaceCustomRolesResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetOrganizationId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.ListWorkspaceCustomRolesResponse{}, s.listCustomRoles(req.GetWorkspaceId()))
+}
+
+func (c *organizationClient) CreateWorkspaceCustomRole(_ context.Context, req *orgcv1.CreateWorkspaceCustomRoleRequest) (*orgcv1.CreateWorkspaceCustomRoleResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetOrganizationId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	obj, err := s.createCustomRole(req.GetWorkspaceId(), req.GetName(), req.GetDescription(), req.GetPolicy())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.CreateWorkspaceCustomRoleResponse{}, obj)
+}
+
+func (c *organizationClient) GetWorkspaceCustomRole(_ context.Context, req *orgcv1.GetWorkspaceCustomRoleRequest) (*orgcv1.GetWorkspaceCustomRoleResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetOrganizationId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	r, err := s.findCustomRole(req.GetWorkspaceId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.GetWorkspaceCustomRoleResponse{}, map[string]any{"customRole": r.object()})
+}
+
+func (c *organizationClient) UpdateWorkspaceCustomRole(_ context.Context, req *orgcv1.UpdateWorkspaceCustomRoleRequest) (*orgcv1.UpdateWorkspaceCustomRoleResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetOrganizationId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	obj, err := s.updateCustomRole(req.GetWorkspaceId(), req.GetId(), req.GetName(), req.GetDescription(), req.GetPolicy())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.UpdateWorkspaceCustomRoleResponse{}, obj)
+}
+
+func (c *organizationClient) DeleteWorkspaceCustomRole(_ context.Context, req *orgcv1.DeleteWorkspaceCustomRoleRequest) (*orgcv1.DeleteWorkspaceCustomRoleResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetOrganizationId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	if err := s.deleteCustomRole(req.GetWorkspaceId(), req.GetId()); err != nil {
+		return nil, err
+	}
+	return &orgcv1.DeleteWorkspaceCustomRoleResponse{}, nil
+}
+
+func (s *Server) findMember(orgID, workspaceID, id string) (*workspaceMember, error) {
+	if _, err := s.findWorkspace(orgID, workspaceID); err != nil {
+		return nil, err
+	}
+	for _, m := range s.members {
+		if m.id == id && m.workspaceID == workspaceID {
+			return m, nil
+		}
+	}
+	return nil, notFound("workspace member", id)
+}
+
+func (c *organizationClient) AddWorkspaceMember(_ context.Context, req *orgcv1.AddWorkspaceMemberRequest) (*orgcv1.AddWorkspaceMemberResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetOrganizationId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	ref := req.GetMemberRef()
+	m := &workspaceMember{
+		workspaceID: req.GetWorkspaceId(),
+		role:        ref.GetRole().String(),
+		userEmail:   ref.GetUserEmail(),
+		teamName:    ref.GetTeamName(),
+	}
+	if m.teamName != "" {
+		if _, err := s.findTeam(req.GetOrganizationId(), m.teamName); err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range s.members {
+		if o.workspaceID == m.workspaceID && o.userEmail == m.userEmail && o.teamName == m.teamName {
+			return nil, status.Error(codes.AlreadyExists, "member already belongs to the workspace")
+		}
+	}
+	m.id = s.newID("member")
+	s.members = append(s.members, m)
+	return decode(&orgcv1.AddWorkspaceMemberResponse{}, map[string]any{"workspaceMember": m.object()})
+}
+
+func (c *organizationClient) GetWorkspaceMember(_ context.Context, req *orgcv1.GetWorkspaceMemberRequest) (*orgcv1.GetWorkspaceMemberResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, err := s.findMember(req.GetOrganizationId(), req.GetWorkspaceId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.GetWorkspaceMemberResponse{}, map[string]any{"workspaceMember": m.object()})
+}
+
+func (c *organizationClient) UpdateWorkspaceMember(_ context.Context, req *orgcv1.UpdateWorkspaceMemberRequest) (*orgcv1.UpdateWorkspaceMemberResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, err := s.findMember(req.GetOrganizationId(), req.GetWorkspaceId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	m.role = req.GetRole().String()
+	return decode(&orgcv1.UpdateWorkspaceMemberResponse{}, map[string]any{"workspaceMember": m.object()})
+}
+
+func (c *organizationClient) RemoveWorkspaceMember(_ context.Context, req *orgcv1.RemoveWorkspaceMemberRequest) (*orgcv1.RemoveWorkspaceMemberResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, err := s.findMember(req.GetOrganizationId(), req.GetWorkspaceId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	remaining := 0
+	for _, o := range s.members {
+		if o.workspaceID == m.workspaceID {
+			remaining++
+		}
+	}
+	if remaining == 1 {
+		return nil, status.Error(codes.InvalidArgument, "cannot remove the last member of the workspace")
+	}
+	s.members = slices.DeleteFunc(s.members, func(o *workspaceMember) bool { return o == m })
+	return &orgcv1.RemoveWorkspaceMemberResponse{}, nil
+}
+
+func (c *organizationClient) ListWorkspaceMembers(_ context.Context, req *orgcv1.ListWorkspaceMembersRequest) (*orgcv1.ListWorkspaceMembersResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetOrganizationId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	var members []any
+	for _, m := range s.members {
+		if m.workspaceID == req.GetWorkspaceId() {
+			members = append(members, m.object())
+		}
+	}
+	return decode(&orgcv1.ListWorkspaceMembersResponse{}, map[string]any{"workspaceMembers": members})
+}
+
+func (c *organizationClient) CreateOrganizationAPIKey(_ context.Context, req *orgcv1.CreateOrganizationAPIKeyRequest) (*orgcv1.CreateOrganizationAPIKeyResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetId()); err != nil {
+		return nil, err
+	}
+	k, err := s.createAPIKey("", req.GetDescription(), req.GetPermissions(), req.GetExpireInDuration())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.CreateOrganizationAPIKeyResponse{}, map[string]any{"apiKey": k.object(true)})
+}
+
+func (c *organizationClient) CreateWorkspaceAPIKey(_ context.Context, req *orgcv1.CreateWorkspaceAPIKeyRequest) (*orgcv1.CreateWorkspaceAPIKeyResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	k, err := s.createAPIKey(req.GetWorkspaceId(), req.GetDescription(), req.GetPermissions(), req.GetExpireInDuration())
+	if err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.CreateWorkspaceAPIKeyResponse{}, map[string]any{"apiKey": k.object(true)})
+}
+
+func (c *organizationClient) ListOrganizationAPIKeys(_ context.Context, req *orgcv1.ListOrganizationAPIKeysRequest) (*orgcv1.ListOrganizationAPIKeysResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOrg(req.GetId()); err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.ListOrganizationAPIKeysResponse{}, s.listAPIKeys(""))
+}
+
+func (c *organizationClient) ListWorkspaceAPIKeys(_ context.Context, req *orgcv1.ListWorkspaceAPIKeysRequest) (*orgcv1.ListWorkspaceAPIKeysResponse, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.findWorkspace(req.GetId(), req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+	return decode(&orgcv1.ListWorkspaceAPIKeysResponse{}, s.listAPIKeys(req.GetWorkspaceId()))
+}
+
+func (s *Server) createAPIKey(workspaceID, description string, perms *accesscontrolv1.Permissions, expireIn string) (*apiKey, error) {
+	expireTime, err := expiry(expireIn)
+	if err != nil {
+		return nil, err
+	}
+	// The platform stores roles with their scope prefix and force-adds
+	// organization membership to workspace keys.
+	scope := "organization/"
+	if workspaceID != "" {
+		scope = "workspace/"
+	}
+	var roles []string
+	for _, r := range perms.GetRoles() {
+		if !strings.Contains(r, "/") {
+			r = scope + r
+		}
+		roles = append(roles, r)
+	}
+	if workspaceID != "" && !slices.Contains(roles, "organization/member") {
+		roles = append(roles, "organization/member")
+	}
+	k := &apiKey{
+		id:          s.newID("key"),
+		workspaceID: workspaceID,
+		description: description,
+		secret:      "secret-" + s.newID("key"),
+		createTime:  now(),
+		expireTime:  expireTime,
+		permissions: map[string]any{
+			"actions":     slices.Clone(perms.GetActions()),
+			"roles":       roles,
+			"customRoles": slices.Clone(perms.GetCustomRoles()),
+		},
+	}
+	s.apiKeys = append(s.apiKeys, k)
+	return k, nil
+}
+
+// expiry converts an expire_in_duration into an absolute expiry. "0" means the
+// key never expires; a "d" suffix counts days, as the platform accepts.
+func expiry(d string) (string, error) {
+	if d == "" || d == "0" {
+		return "", nil
+	}
+	var dur time.Duration
+	if days, ok := strings.CutSuffix(d, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "invalid expire_in_duration %q", d)
+		}
+		dur = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if dur, err = time.ParseDuration(d); err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "invalid expire_in_duration %q", d)
+		}
+	}
+	return time.Now().Add(dur).UTC().Format(time.RFC3339), nil
+}
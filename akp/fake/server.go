@@ -0,0 +1,243 @@
+// Package fake provides a stateful, in-memory stand-in for the Akuity Platform
+// gateway. It implements the ArgoCD, Kargo, Organization and APIKey gateway
+// clients closely enough for the provider to run full plan/apply/import cycles
+// under resource.UnitTest without network access or credentials.
+//
+// Objects are kept in the JSON shape the gateway speaks and decoded into the
+// generated response types with protojson, the same way the HTTP gateway client
+// does. Methods the provider does not call are left unimplemented and panic.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	apikeyv1 "github.com/akuity/api-client-go/pkg/api/gen/apikey/v1"
+	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
+	kargov1 "github.com/akuity/api-client-go/pkg/api/gen/kargo/v1"
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	"github.com/akuity/terraform-provider-akp/akp/marshal"
+)
+
+const (
+	healthHealthy     = "STATUS_CODE_HEALTHY"
+	healthProgressing = "STATUS_CODE_PROGRESSING"
+	reconSuccessful   = "STATUS_CODE_SUCCESSFUL"
+	reconProgressing  = "STATUS_CODE_PROGRESSING"
+)
+
+// Server holds the state of one fake organization. It is safe for concurrent use.
+type Server struct {
+	mu sync.Mutex
+
+	orgID         string
+	orgName       string
+	convergeAfter int
+	nextID        int
+
+	workspaces     []*workspace
+	argoInstances  []*argoInstance
+	kargoInstances []*kargoInstance
+	teams          []*team
+	customRoles    []*customRole
+	members        []*workspaceMember
+	apiKeys        []*apiKey
+}
+
+// NewServer returns an empty organization named orgName that only contains its
+// default workspace.
+func NewServer(orgName string) *Server {
+	s := &Server{orgName: orgName}
+	s.orgID = s.newID("org")
+	s.workspaces = append(s.workspaces, &workspace{
+		id:         s.newID("ws"),
+		name:       "default",
+		isDefault:  true,
+		createTime: now(),
+	})
+	return s
+}
+
+// ConvergeAfter sets how many reads a freshly applied instance, cluster or agent
+// reports as progressing before it turns healthy. The default of zero makes
+// every object healthy as soon as it is applied.
+func (s *Server) ConvergeAfter(reads int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.convergeAfter = reads
+}
+
+// OrganizationID returns the ID of the fake organization.
+func (s *Server) OrganizationID() string {
+	return s.orgID
+}
+
+// ArgoCD returns a client backed by this server.
+func (s *Server) ArgoCD() argocdv1.ArgoCDServiceGatewayClient {
+	return &argoCDClient{s: s}
+}
+
+// Kargo returns a client backed by this server.
+func (s *Server) Kargo() kargov1.KargoServiceGatewayClient {
+	return &kargoClient{s: s}
+}
+
+// Organization returns a client backed by this server.
+func (s *Server) Organization() orgcv1.OrganizationServiceGatewayClient {
+	return &organizationClient{s: s}
+}
+
+// APIKey returns a client backed by this server.
+func (s *Server) APIKey() apikeyv1.APIKeyServiceGatewayClient {
+	return &apiKeyClient{s: s}
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s%06d", prefix, s.nextID)
+}
+
+func (s *Server) checkOrg(orgID string) error {
+	if orgID != s.orgID {
+		return status.Errorf(codes.PermissionDenied, "organization %q is not accessible", orgID)
+	}
+	return nil
+}
+
+// lifecycle tracks the generation and the simulated health/reconciliation
+// progress of an object that the platform reconciles asynchronously.
+type lifecycle struct {
+	generation uint32
+	pending    int
+}
+
+func (l *lifecycle) applied(convergeAfter int) {
+	l.generation++
+	l.pending = convergeAfter
+}
+
+// observe returns the status fields for a read and advances the object one
+// step towards being healthy.
+func (l *lifecycle) observe() map[string]any {
+	health, recon := healthHealthy, reconSuccessful
+	if l.pending > 0 {
+		l.pending--
+		health, recon = healthProgressing, reconProgressing
+	}
+	return map[string]any{
+		"generation":           l.generation,
+		"healthStatus":         map[string]any{"code": health},
+		"reconciliationStatus": map[string]any{"code": recon},
+	}
+}
+
+// decodeFirst decodes the first candidate that fits the generated type. Objects
+// carry the applied spec verbatim, so callers pass a trimmed fallback to keep an
+// unexpected spec shape from failing the whole read.
+func decodeFirst[T proto.Message](newMsg func() T, candidates ...map[string]any) (T, error) {
+	var zero T
+	err := status.Error(codes.Internal, "fake: nothing to decode")
+	for _, obj := range candidates {
+		var msg T
+		if msg, err = decode(newMsg(), obj); err == nil {
+			return msg, nil
+		}
+	}
+	return zero, err
+}
+
+// decode converts a JSON-shaped object into msg, ignoring fields the generated
+// type does not know about.
+func decode[T proto.Message](msg T, obj map[string]any) (T, error) {
+	var zero T
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return zero, status.Errorf(codes.Internal, "fake: encode %T: %s", msg, err)
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, msg); err != nil {
+		return zero, status.Errorf(codes.Internal, "fake: decode %T: %s", msg, err)
+	}
+	return msg, nil
+}
+
+// toMap converts a request message into its JSON shape.
+func toMap(msg proto.Message) (map[string]any, error) {
+	m, err := marshal.ProtoToMap(msg)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "fake: %s", err)
+	}
+	return m, nil
+}
+
+// deepCopy returns a copy of a JSON-shaped value that shares no maps or slices
+// with the original.
+func deepCopy[T any](v T) T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// mergeInto applies patch onto dst the way a JSON merge patch does.
+func mergeInto(dst, patch map[string]any) {
+	for k, v := range patch {
+		if v == nil {
+			delete(dst, k)
+			continue
+		}
+		pm, ok := v.(map[string]any)
+		if !ok {
+			dst[k] = v
+			continue
+		}
+		dm, ok := dst[k].(map[string]any)
+		if !ok {
+			dm = map[string]any{}
+			dst[k] = dm
+		}
+		mergeInto(dm, pm)
+	}
+}
+
+func childMap(m map[string]any, key string) map[string]any {
+	if child, ok := m[key].(map[string]any); ok {
+		return child
+	}
+	return map[string]any{}
+}
+
+func metadataName(obj map[string]any) string {
+	name, _ := childMap(obj, "metadata")["name"].(string)
+	return name
+}
+
+// protoEnum rewrites the lowercase CRD spelling of an enum (e.g. "small") into
+// the proto enum name (e.g. "CLUSTER_SIZE_SMALL").
+func protoEnum(m map[string]any, key, prefix string) {
+	v, ok := m[key].(string)
+	if !ok || v == "" || strings.HasPrefix(v, prefix) {
+		return
+	}
+	m[key] = prefix + strings.ToUpper(v)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func notFound(kind, id string) error {
+	return status.Errorf(codes.NotFound, "%s %q not found", kind, id)
+}
@@ -0,0 +1,194 @@
+//go:build !acc
+
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
+	kargov1 "github.com/akuity/api-client-go/pkg/api/gen/kargo/v1"
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
+)
+
+func mustStruct(t *testing.T, m map[string]any) *structpb.Struct {
+	t.Helper()
+	s, err := structpb.NewStruct(m)
+	require.NoError(t, err)
+	return s
+}
+
+func applyTestInstance(t *testing.T, srv *Server) *argocdv1.Instance {
+	t.Helper()
+	ctx := context.Background()
+	cli := srv.ArgoCD()
+	_, err := cli.ApplyInstance(ctx, &argocdv1.ApplyInstanceRequest{
+		OrganizationId: srv.OrganizationID(),
+		IdType:         idv1.Type_NAME,
+		Id:             "test",
+		Argocd: mustStruct(t, map[string]any{
+			"apiVersion": "argocd.akuity.io/v1alpha1",
+			"kind":       "ArgoCD",
+			"metadata":   map[string]any{"name": "test"},
+			"spec":       map[string]any{"version": "v2.13.1", "description": "fake"},
+		}),
+		ArgocdSecret: mustStruct(t, map[string]any{"data": map[string]any{"admin.password": "secret"}}),
+		Clusters: []*structpb.Struct{mustStruct(t, map[string]any{
+			"metadata": map[string]any{"name": "cluster", "namespace": "akuity"},
+			"spec":     map[string]any{"data": map[string]any{"size": "small"}},
+		})},
+	})
+	require.NoError(t, err)
+	resp, err := cli.GetInstance(ctx, &argocdv1.GetInstanceRequest{
+		OrganizationId: srv.OrganizationID(),
+		IdType:         idv1.Type_NAME,
+		Id:             "test",
+	})
+	require.NoError(t, err)
+	return resp.GetInstance()
+}
+
+func TestApplyInstanceConverges(t *testing.T) {
+	srv := NewServer("org")
+	srv.ConvergeAfter(1)
+
+	inst := applyTestInstance(t, srv)
+	assert.Equal(t, "test", inst.GetName())
+	assert.EqualValues(t, 1, inst.GetGeneration())
+	assert.Equal(t, healthProgressing, inst.GetHealthStatus().GetCode().String())
+
+	resp, err := srv.ArgoCD().GetInstance(context.Background(), &argocdv1.GetInstanceRequest{
+		OrganizationId: srv.OrganizationID(),
+		Id:             inst.GetId(),
+		IdType:         idv1.Type_ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, healthHealthy, resp.GetInstance().GetHealthStatus().GetCode().String())
+	assert.Equal(t, reconSuccessful, resp.GetInstance().GetReconciliationStatus().GetCode().String())
+}
+
+func TestExportInstanceOmitsSecrets(t *testing.T) {
+	srv := NewServer("org")
+	inst := applyTestInstance(t, srv)
+
+	resp, err := srv.ArgoCD().ExportInstance(context.Background(), &argocdv1.ExportInstanceRequest{
+		OrganizationId: srv.OrganizationID(),
+		Id:             inst.GetId(),
+		IdType:         idv1.Type_ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v2.13.1", resp.GetArgocd().AsMap()["spec"].(map[string]any)["version"])
+	assert.Nil(t, resp.GetArgocdSecret())
+}
+
+func TestInstanceCluster(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer("org")
+	inst := applyTestInstance(t, srv)
+	cli := srv.ArgoCD()
+
+	resp, err := cli.GetInstanceCluster(ctx, &argocdv1.GetInstanceClusterRequest{
+		OrganizationId: srv.OrganizationID(),
+		InstanceId:     inst.GetId(),
+		Id:             "cluster",
+		IdType:         idv1.Type_NAME,
+	})
+	require.NoError(t, err)
+	cluster := resp.GetCluster()
+	assert.Equal(t, "akuity", cluster.GetData().GetNamespace())
+
+	resChan, _, err := cli.GetInstanceClusterManifests(ctx, &argocdv1.GetInstanceClusterManifestsRequest{
+		OrganizationId: srv.OrganizationID(),
+		InstanceId:     inst.GetId(),
+		Id:             cluster.GetId(),
+	})
+	require.NoError(t, err)
+	body := <-resChan
+	assert.Contains(t, string(body.GetData()), "kind: Namespace")
+
+	_, err = cli.DeleteInstanceCluster(ctx, &argocdv1.DeleteInstanceClusterRequest{
+		OrganizationId: srv.OrganizationID(),
+		InstanceId:     inst.GetId(),
+		Id:             cluster.GetId(),
+	})
+	require.NoError(t, err)
+
+	// Like the platform, a missing cluster reads as PermissionDenied but
+	// deleting it again reports NotFound.
+	_, err = cli.GetInstanceCluster(ctx, &argocdv1.GetInstanceClusterRequest{
+		OrganizationId: srv.OrganizationID(),
+		InstanceId:     inst.GetId(),
+		Id:             cluster.GetId(),
+		IdType:         idv1.Type_ID,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = cli.DeleteInstanceCluster(ctx, &argocdv1.DeleteInstanceClusterRequest{
+		OrganizationId: srv.OrganizationID(),
+		InstanceId:     inst.GetId(),
+		Id:             cluster.GetId(),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDeleteMissingObjects(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer("org")
+
+	_, err := srv.Kargo().DeleteInstance(ctx, &kargov1.DeleteInstanceRequest{
+		OrganizationId: srv.OrganizationID(),
+		Id:             "missing",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = srv.Organization().DeleteTeam(ctx, &orgcv1.DeleteTeamRequest{
+		OrganizationId: srv.OrganizationID(),
+		Name:           "missing",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = srv.Organization().DeleteWorkspace(ctx, &orgcv1.DeleteWorkspaceRequest{
+		OrganizationId: "other-org",
+		Id:             "missing",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestKargoDefaultShardAgentSurvivesApply(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer("org")
+	cli := srv.Kargo()
+	apply := func() {
+		_, err := cli.ApplyKargoInstance(ctx, &kargov1.ApplyKargoInstanceRequest{
+			OrganizationId: srv.OrganizationID(),
+			IdType:         idv1.Type_NAME,
+			Id:             "kargo",
+			Kargo: mustStruct(t, map[string]any{
+				"metadata": map[string]any{"name": "kargo"},
+				"spec":     map[string]any{"version": "v1.7.0"},
+			}),
+		})
+		require.NoError(t, err)
+	}
+	apply()
+
+	inst, err := cli.GetKargoInstance(ctx, &kargov1.GetKargoInstanceRequest{OrganizationId: srv.OrganizationID(), Name: "kargo"})
+	require.NoError(t, err)
+	_, err = cli.PatchKargoInstance(ctx, &kargov1.PatchKargoInstanceRequest{
+		OrganizationId: srv.OrganizationID(),
+		Id:             inst.GetInstance().GetId(),
+		Patch:          mustStruct(t, map[string]any{"spec": map[string]any{"defaultShardAgent": "agent"}}),
+	})
+	require.NoError(t, err)
+	apply()
+
+	inst, err = cli.GetKargoInstance(ctx, &kargov1.GetKargoInstanceRequest{OrganizationId: srv.OrganizationID(), Name: "kargo"})
+	require.NoError(t, err)
+	assert.Equal(t, "agent", inst.GetInstance().GetSpec().GetDefaultShardAgent())
+}
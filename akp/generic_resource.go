@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -14,6 +15,7 @@ var (
 	_ resource.Resource                     = &GenericResource[any]{}
 	_ resource.ResourceWithImportState      = &GenericResource[any]{}
 	_ resource.ResourceWithConfigValidators = &GenericResource[any]{}
+	_ resource.ResourceWithModifyPlan       = &GenericResource[any]{}
 )
 
 type GenericResource[Plan any] struct {
@@ -26,6 +28,13 @@ type GenericResource[Plan any] struct {
 	DeleteFunc           func(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, state *Plan) error
 	ImportStateFunc      func(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse)
 	ConfigValidatorsFunc func() []resource.ConfigValidator
+	// WriteOnlyFunc copies write-only attributes, which are always null in the
+	// plan, from the configuration into the plan before CreateFunc or UpdateFunc
+	// runs. Terraform discards them again when the result is stored in state.
+	WriteOnlyFunc func(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics, plan *Plan)
+	// ModifyPlanFunc adjusts the plan with provider-level settings. It is not
+	// called on destroy, nor before the provider is configured.
+	ModifyPlanFunc func(ctx context.Context, cli *AkpCli, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse)
 }
 
 func (r *GenericResource[Plan]) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,7 +52,16 @@ func (r *GenericResource[Plan]) Create(ctx context.Context, req resource.CreateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	ctx = r.AuthCtx(ctx)
+	if r.WriteOnlyFunc != nil {
+		r.WriteOnlyFunc(ctx, req.Config, &resp.Diagnostics, &plan)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	result, err := r.CreateFunc(ctx, r.akpCli, &resp.Diagnostics, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
@@ -60,7 +78,10 @@ func (r *GenericResource[Plan]) Read(ctx context.Context, req resource.ReadReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	ctx = r.AuthCtx(ctx)
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if err := r.ReadFunc(ctx, r.akpCli, &resp.Diagnostics, &data); err != nil {
 		handleReadResourceError(ctx, resp, err)
 		return
@@ -75,7 +96,16 @@ func (r *GenericResource[Plan]) Update(ctx context.Context, req resource.UpdateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	ctx = r.AuthCtx(ctx)
+	if r.WriteOnlyFunc != nil {
+		r.WriteOnlyFunc(ctx, req.Config, &resp.Diagnostics, &plan)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	result, err := r.UpdateFunc(ctx, r.akpCli, &resp.Diagnostics, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
@@ -92,7 +122,10 @@ func (r *GenericResource[Plan]) Delete(ctx context.Context, req resource.DeleteR
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	ctx = r.AuthCtx(ctx)
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if err := r.DeleteFunc(ctx, r.akpCli, &resp.Diagnostics, &state); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
 	}
@@ -106,6 +139,13 @@ func (r *GenericResource[Plan]) ImportState(ctx context.Context, req resource.Im
 	resp.Diagnostics.AddError("Import Not Supported", fmt.Sprintf("Import is not supported for %s", r.TypeNameSuffix))
 }
 
+func (r *GenericResource[Plan]) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.ModifyPlanFunc == nil || req.Plan.Raw.IsNull() || r.akpCli == nil {
+		return
+	}
+	r.ModifyPlanFunc(ctx, r.akpCli, req, resp)
+}
+
 func (r *GenericResource[Plan]) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	if r.ConfigValidatorsFunc != nil {
 		return r.ConfigValidatorsFunc()
@@ -22,10 +22,19 @@ type ApplyOpts struct {
 	DryRunStrategy cmdutil.DryRunStrategy
 	Force          bool
 	Validate       bool
+	// ServerSide applies through ServerSideApply instead of kubectl's
+	// client-side three-way merge.
+	ServerSide bool
+	// ForceConflicts takes over fields owned by other field managers. Only
+	// used with ServerSide.
+	ForceConflicts bool
 }
 
 // ApplyResource performs an apply of a unstructured resource
 func (k *Kubectl) ApplyResource(ctx context.Context, obj *unstructured.Unstructured, applyOpts ApplyOpts) (string, error) {
+	if applyOpts.ServerSide {
+		return k.ServerSideApply(ctx, obj, applyOpts)
+	}
 	objBytes, err := json.Marshal(obj)
 	if err != nil {
 		return "", err
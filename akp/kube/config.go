@@ -68,7 +68,8 @@ func InitializeConfiguration(ctx context.Context, k *types.Kubeconfig) (*rest.Co
 		} else {
 			loader.Precedence = expandedPaths
 		}
-
+	}
+	if len(configPaths) > 0 || k.ConfigRaw.ValueString() != "" {
 		kubectx := k.ConfigContext.ValueString()
 		authInfo := k.ConfigContextAuthInfo.ValueString()
 		cluster := k.ConfigContextCluster.ValueString()
@@ -155,7 +156,16 @@ func InitializeConfiguration(ctx context.Context, k *types.Kubeconfig) (*rest.Co
 		tflog.Debug(ctx, fmt.Sprintf("Final exec command: %s %s", execConfig.Command, strings.Join(execConfig.Args, " ")))
 	}
 
-	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
+	var cc clientcmd.ClientConfig
+	if v := k.ConfigRaw.ValueString(); v != "" {
+		raw, err := clientcmd.Load([]byte(v))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config_raw: %s", err)
+		}
+		cc = clientcmd.NewNonInteractiveClientConfig(*raw, overrides.CurrentContext, overrides, nil)
+	} else {
+		cc = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
+	}
 	cfg, err := cc.ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("invalid provider configuration: %s", err)
@@ -163,6 +173,19 @@ func InitializeConfiguration(ctx context.Context, k *types.Kubeconfig) (*rest.Co
 	cfg.QPS = 100.0
 	cfg.Burst = 100
 
+	if v := k.TokenFile.ValueString(); v != "" {
+		path, err := homedir.Expand(v)
+		if err != nil {
+			return nil, err
+		}
+		// The token from token_file replaces any other token. client-go would
+		// only re-read BearerTokenFile once a minute, so it is read on every
+		// request instead to pick up rotated tokens immediately.
+		cfg.BearerToken = ""
+		cfg.BearerTokenFile = ""
+		cfg.Wrap(newTokenFileRoundTripper(path))
+	}
+
 	// Overriding with static configuration
 	terraformVersion := "unknown"
 	cfg.UserAgent = fmt.Sprintf("HashiCorp/1.0 Terraform/%s", terraformVersion)
@@ -0,0 +1,97 @@
+package kube
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+const testRawConfig = `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+- name: prod
+  cluster:
+    server: https://prod.example.com
+users:
+- name: admin
+  user:
+    token: admin-token
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+`
+
+func TestInitializeConfigurationConfigRaw(t *testing.T) {
+	t.Run("current context", func(t *testing.T) {
+		cfg, err := InitializeConfiguration(context.Background(), &types.Kubeconfig{
+			ConfigRaw: tftypes.StringValue(testRawConfig),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://dev.example.com", cfg.Host)
+		assert.Equal(t, "admin-token", cfg.BearerToken)
+	})
+
+	t.Run("context override", func(t *testing.T) {
+		cfg, err := InitializeConfiguration(context.Background(), &types.Kubeconfig{
+			ConfigRaw:     tftypes.StringValue(testRawConfig),
+			ConfigContext: tftypes.StringValue("prod"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://prod.example.com", cfg.Host)
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		_, err := InitializeConfiguration(context.Background(), &types.Kubeconfig{
+			ConfigRaw: tftypes.StringValue("clusters: ["),
+		})
+		assert.ErrorContains(t, err, "failed to parse config_raw")
+	})
+}
+
+func TestInitializeConfigurationTokenFile(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("first\n"), 0o600))
+
+	cfg, err := InitializeConfiguration(context.Background(), &types.Kubeconfig{
+		Host:      tftypes.StringValue(server.URL),
+		Token:     tftypes.StringNull(),
+		TokenFile: tftypes.StringValue(tokenFile),
+	})
+	require.NoError(t, err)
+	client, err := rest.HTTPClientFor(cfg)
+	require.NoError(t, err)
+
+	for _, token := range []string{"first", "rotated"} {
+		require.NoError(t, os.WriteFile(tokenFile, []byte(token+"\n"), 0o600))
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, []string{"Bearer first", "Bearer rotated"}, got)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -19,6 +20,8 @@ type DeleteOpts struct {
 	WaitForDeletion bool
 	IgnoreNotFound  bool
 	GracePeriod     int
+	// Timeout bounds how long to wait for the deletion when WaitForDeletion is set.
+	Timeout time.Duration
 }
 
 func (k *Kubectl) DeleteResource(ctx context.Context, obj *unstructured.Unstructured, deleteOpts DeleteOpts) (string, error) {
@@ -60,6 +63,7 @@ func (k *Kubectl) newDeleteOptions(ioStreams genericclioptions.IOStreams, _ *uns
 		IgnoreNotFound:    deleteOpts.IgnoreNotFound,
 		WaitForDeletion:   deleteOpts.WaitForDeletion,
 		GracePeriod:       deleteOpts.GracePeriod,
+		Timeout:           deleteOpts.Timeout,
 		Output:            "name",
 		IOStreams:         ioStreams,
 		CascadingStrategy: metav1.DeletePropagationBackground,
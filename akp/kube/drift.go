@@ -0,0 +1,172 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Drift compares the live objects in the cluster against expected and
+// describes every object that is missing or has been modified, as
+// "Kind namespace/name: reason". An object counts as modified when a field
+// set in its expected manifest holds a different value in the cluster; fields
+// added by the API server or controllers are not considered.
+func (k *Kubectl) Drift(ctx context.Context, expected []unstructured.Unstructured) ([]string, error) {
+	var drifted []string
+	for i := range expected {
+		obj := &expected[i]
+		namespace := obj.GetNamespace()
+		ri, err := k.resourceInterface(obj)
+		if meta.IsNoMatchError(err) {
+			drifted = append(drifted, describeObject(obj.GetKind(), namespace, obj.GetName())+": kind is not served")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		live, err := ri.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			drifted = append(drifted, describeObject(obj.GetKind(), namespace, obj.GetName())+": missing")
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if path := firstDifference("", obj.Object, live.Object, driftIgnored(obj)); path != "" {
+			drifted = append(drifted, describeObject(obj.GetKind(), namespace, obj.GetName())+": modified at "+path)
+		}
+	}
+	return drifted, nil
+}
+
+// driftIgnored returns the top-level paths of obj that are not compared:
+// metadata and status are always owned by the cluster, Secret contents may be
+// rotated by the agent itself, and replica counts may be scaled by autoscalers.
+func driftIgnored(obj *unstructured.Unstructured) []string {
+	ignored := []string{"metadata", "status"}
+	switch obj.GroupVersionKind().GroupKind().String() {
+	case "Secret":
+		ignored = append(ignored, "data", "stringData")
+	case "Deployment.apps", "StatefulSet.apps":
+		ignored = append(ignored, "spec.replicas")
+	}
+	return ignored
+}
+
+// firstDifference returns the path of the first field set in expected whose
+// value differs in live, or "" when live contains everything in expected.
+func firstDifference(path string, expected, live any, ignored []string) string {
+	if slices.Contains(ignored, path) {
+		return ""
+	}
+	switch e := expected.(type) {
+	case map[string]any:
+		if live == nil && len(e) == 0 {
+			return ""
+		}
+		l, ok := live.(map[string]any)
+		if !ok {
+			return path
+		}
+		keys := make([]string, 0, len(e))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if d := firstDifference(joinPath(path, key), e[key], l[key], ignored); d != "" {
+				return d
+			}
+		}
+		return ""
+	case []any:
+		if live == nil && len(e) == 0 {
+			return ""
+		}
+		l, ok := live.([]any)
+		if !ok || len(l) != len(e) {
+			return path
+		}
+		for i := range e {
+			if d := firstDifference(fmt.Sprintf("%s[%d]", path, i), e[i], l[i], ignored); d != "" {
+				return d
+			}
+		}
+		return ""
+	case nil:
+		return ""
+	default:
+		if live == nil && isZero(e) {
+			// The API server drops zero values of omitempty fields.
+			return ""
+		}
+		if !scalarEqual(e, live) {
+			return path
+		}
+		return ""
+	}
+}
+
+// scalarEqual compares two scalar manifest values, treating numbers of any
+// width and equivalent quantities such as "1000m" and "1" as equal.
+func scalarEqual(expected, live any) bool {
+	if ef, ok := toFloat(expected); ok {
+		if lf, ok := toFloat(live); ok {
+			return ef == lf
+		}
+		l, ok := live.(string)
+		return ok && quantityEqual(fmt.Sprint(expected), l)
+	}
+	switch e := expected.(type) {
+	case string:
+		l, ok := live.(string)
+		return ok && (e == l || quantityEqual(e, l))
+	case bool:
+		l, ok := live.(bool)
+		return ok && e == l
+	}
+	return fmt.Sprint(expected) == fmt.Sprint(live)
+}
+
+func quantityEqual(a, b string) bool {
+	aq, err := resource.ParseQuantity(a)
+	if err != nil {
+		return false
+	}
+	bq, err := resource.ParseQuantity(b)
+	return err == nil && aq.Cmp(bq) == 0
+}
+
+func isZero(v any) bool {
+	if f, ok := toFloat(v); ok {
+		return f == 0
+	}
+	return v == "" || v == false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
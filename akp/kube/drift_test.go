@@ -0,0 +1,100 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestFirstDifference(t *testing.T) {
+	expected := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "akuity-agent", "namespace": "akuity"},
+		"spec": map[string]any{
+			"replicas": int64(1),
+			"template": map[string]any{
+				"spec": map[string]any{
+					"hostNetwork": false,
+					"containers": []any{
+						map[string]any{
+							"name":      "agent",
+							"image":     "quay.io/akuity/agent:v0.5.0",
+							"resources": map[string]any{"requests": map[string]any{"cpu": "1000m", "memory": "256Mi"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	live := func(mutate func(containers []any, spec map[string]any)) map[string]any {
+		spec := map[string]any{
+			"replicas":             int64(3),
+			"revisionHistoryLimit": int64(10),
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{
+							"name":                     "agent",
+							"image":                    "quay.io/akuity/agent:v0.5.0",
+							"imagePullPolicy":          "IfNotPresent",
+							"resources":                map[string]any{"requests": map[string]any{"cpu": "1", "memory": "256Mi"}},
+							"terminationMessagePath":   "/dev/termination-log",
+							"terminationMessagePolicy": "File",
+						},
+					},
+				},
+			},
+		}
+		if mutate != nil {
+			mutate(spec["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any), spec)
+		}
+		return map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]any{"name": "akuity-agent", "namespace": "akuity", "generation": int64(4)},
+			"spec":       spec,
+			"status":     map[string]any{"readyReplicas": int64(3)},
+		}
+	}
+	ignored := driftIgnored(&unstructured.Unstructured{Object: expected})
+
+	tests := []struct {
+		name string
+		live map[string]any
+		want string
+	}{
+		{
+			name: "defaults, scaling and normalized quantities are not drift",
+			live: live(nil),
+		},
+		{
+			name: "edited image",
+			live: live(func(containers []any, _ map[string]any) {
+				containers[0].(map[string]any)["image"] = "quay.io/akuity/agent:latest"
+			}),
+			want: "spec.template.spec.containers[0].image",
+		},
+		{
+			name: "added container",
+			live: live(func(_ []any, spec map[string]any) {
+				podSpec := spec["template"].(map[string]any)["spec"].(map[string]any)
+				podSpec["containers"] = append(podSpec["containers"].([]any), map[string]any{"name": "debug"})
+			}),
+			want: "spec.template.spec.containers",
+		},
+		{
+			name: "changed memory request",
+			live: live(func(containers []any, _ map[string]any) {
+				containers[0].(map[string]any)["resources"] = map[string]any{"requests": map[string]any{"cpu": "1", "memory": "128Mi"}}
+			}),
+			want: "spec.template.spec.containers[0].resources.requests.memory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, firstDifference("", expected, tt.live, ignored))
+		})
+	}
+}
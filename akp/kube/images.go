@@ -0,0 +1,381 @@
+package kube
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ImageRewrite points the agent manifests at a private registry mirror, for
+// clusters that cannot pull from the public registries.
+type ImageRewrite struct {
+	// RegistryMirror replaces the registry of every container image, keeping
+	// its repository path, tag and digest. Images without a registry are
+	// treated as Docker Hub images.
+	RegistryMirror string
+	// PullSecrets are added to the imagePullSecrets of every pod template.
+	PullSecrets []string
+}
+
+// podSpecPaths are the locations of the pod spec in the workload kinds that
+// agent manifests contain.
+var podSpecPaths = map[string][]string{
+	"Pod":         {"spec"},
+	"Deployment":  {"spec", "template", "spec"},
+	"StatefulSet": {"spec", "template", "spec"},
+	"DaemonSet":   {"spec", "template", "spec"},
+	"ReplicaSet":  {"spec", "template", "spec"},
+	"Job":         {"spec", "template", "spec"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// Apply rewrites the pod spec of obj in place. Objects without a pod spec are
+// left untouched.
+func (r ImageRewrite) Apply(obj *unstructured.Unstructured) error {
+	if r.RegistryMirror == "" && len(r.PullSecrets) == 0 {
+		return nil
+	}
+	specPath, ok := podSpecPaths[obj.GetKind()]
+	if !ok {
+		return nil
+	}
+	podSpec, found, err := unstructured.NestedMap(obj.Object, specPath...)
+	if err != nil || !found {
+		return err
+	}
+
+	if r.RegistryMirror != "" {
+		for _, field := range []string{"initContainers", "containers", "ephemeralContainers"} {
+			containers, found, err := unstructured.NestedSlice(podSpec, field)
+			if err != nil {
+				return err
+			}
+			if !found {
+				continue
+			}
+			for _, c := range containers {
+				container, ok := c.(map[string]any)
+				if !ok {
+					continue
+				}
+				if image, ok := container["image"].(string); ok && image != "" {
+					container["image"] = mirrorImage(r.RegistryMirror, image)
+				}
+			}
+			podSpec[field] = containers
+		}
+	}
+
+	if len(r.PullSecrets) > 0 {
+		secrets, _, err := unstructured.NestedSlice(podSpec, "imagePullSecrets")
+		if err != nil {
+			return err
+		}
+		existing := map[string]bool{}
+		for _, s := range secrets {
+			if ref, ok := s.(map[string]any); ok {
+				if name, ok := ref["name"].(string); ok {
+					existing[name] = true
+				}
+			}
+		}
+		for _, name := range r.PullSecrets {
+			if !existing[name] {
+				secrets = append(secrets, map[string]any{"name": name})
+				existing[name] = true
+			}
+		}
+		podSpec["imagePullSecrets"] = secrets
+	}
+
+	return unstructured.SetNestedMap(obj.Object, podSpec, specPath...)
+}
+
+// mirrorImage replaces the registry of image with mirror, e.g.
+// quay.io/akuity/agent:v0.5.0 becomes <mirror>/akuity/agent:v0.5.0 and
+// redis:7 becomes <mirror>/library/redis:7. Images already pulled from the
+// mirror are returned unchanged.
+func mirrorImage(mirror, image string) string {
+	mirror = strings.TrimSuffix(mirror, "/")
+	if strings.HasPrefix(image, mirror+"/") {
+		return image
+	}
+	repository := image
+	if registry, rest, ok := strings.Cut(image, "/"); ok && (strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		repository = rest
+	} else if !ok {
+		repository = "library/" + image
+	}
+	return mirror + "/" + repository
+}
+
+// AgentImages returns the distinct container images of the pod specs in
+// manifests, without their tag or digest, in the order they first appear.
+func AgentImages(manifests []byte) ([]string, error) {
+	objs, err := SplitYAML(manifests)
+	if err != nil {
+		return nil, err
+	}
+	var images []string
+	seen := map[string]bool{}
+	for _, obj := range objs {
+		specPath, ok := podSpecPaths[obj.GetKind()]
+		if !ok {
+			continue
+		}
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, _, err := unstructured.NestedSlice(obj.Object, append(append([]string{}, specPath...), field)...)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range containers {
+				container, ok := c.(map[string]any)
+				if !ok {
+					continue
+				}
+				image, _ := container["image"].(string)
+				if name := imageName(image); name != "" && !seen[name] {
+					seen[name] = true
+					images = append(images, name)
+				}
+			}
+		}
+	}
+	return images, nil
+}
+
+// pullSecretsPatchTarget selects the kinds that share a location of the pod
+// spec in podSpecPaths.
+type pullSecretsPatchTarget struct {
+	apiVersion string
+	kinds      []string
+	specPath   []string
+}
+
+// selector is the target of the patch in the kustomization, which matches
+// any of the kinds.
+func (t pullSecretsPatchTarget) selector() map[string]any {
+	return map[string]any{"kind": strings.Join(t.kinds, "|")}
+}
+
+// pullSecretsPatchTargets are the targets of the patches that
+// AddToKustomization generates for pull secrets, one for each location of the
+// pod spec in podSpecPaths. Their apiVersion and kind only make the patch a
+// valid object; kustomize applies it to every object the target selects.
+var pullSecretsPatchTargets = []pullSecretsPatchTarget{
+	{apiVersion: "apps/v1", kinds: []string{"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job"}, specPath: podSpecPaths["Deployment"]},
+	{apiVersion: "batch/v1", kinds: []string{"CronJob"}, specPath: podSpecPaths["CronJob"]},
+	{apiVersion: "v1", kinds: []string{"Pod"}, specPath: podSpecPaths["Pod"]},
+}
+
+// pullSecretsPatchName is the name of the patches that AddToKustomization
+// generates for pull secrets. Kustomize requires one, but does not match it
+// when the patch has a target.
+const pullSecretsPatchName = "image-pull-secrets"
+
+// AddToKustomization adds the rewrite to kustomization, for agent manifests
+// that are generated by the platform rather than applied by the provider:
+// an images entry that moves each of images to the mirror, and strategic
+// merge patches that add the pull secrets to those of every workload. Images
+// the kustomization already lists are left to it. kustomization is modified
+// in place and returned, and may be nil.
+func (r ImageRewrite) AddToKustomization(kustomization map[string]any, images []string) map[string]any {
+	if r.RegistryMirror == "" && len(r.PullSecrets) == 0 {
+		return kustomization
+	}
+	if kustomization == nil {
+		kustomization = map[string]any{}
+	}
+
+	if r.RegistryMirror != "" {
+		entries, _ := kustomization["images"].([]any)
+		listed := map[string]bool{}
+		for _, e := range entries {
+			if entry, ok := e.(map[string]any); ok {
+				if name, ok := entry["name"].(string); ok {
+					listed[name] = true
+				}
+			}
+		}
+		for _, name := range images {
+			if !listed[name] {
+				entries = append(entries, map[string]any{"name": name, "newName": mirrorImage(r.RegistryMirror, name)})
+			}
+		}
+		kustomization["images"] = entries
+	}
+
+	if len(r.PullSecrets) > 0 {
+		patches, _ := kustomization["patches"].([]any)
+		for _, target := range pullSecretsPatchTargets {
+			patches = append(patches, pullSecretsPatch(target, r.PullSecrets))
+		}
+		kustomization["patches"] = patches
+	}
+	return kustomization
+}
+
+// pullSecretsPatch returns the patch that adds secrets to the pull secrets of
+// the objects selected by target. The list of pull secrets is merged by name,
+// so those the objects already have are kept.
+func pullSecretsPatch(target pullSecretsPatchTarget, secrets []string) map[string]any {
+	patch, _ := json.Marshal(pullSecretsPatchObject(target, secrets))
+	return map[string]any{
+		"target": target.selector(),
+		"patch":  string(patch),
+	}
+}
+
+func pullSecretsPatchObject(target pullSecretsPatchTarget, secrets []string) map[string]any {
+	refs := make([]any, 0, len(secrets))
+	for _, name := range secrets {
+		refs = append(refs, map[string]any{"name": name})
+	}
+	obj := map[string]any{"imagePullSecrets": refs}
+	for i := len(target.specPath) - 1; i >= 0; i-- {
+		obj = map[string]any{target.specPath[i]: obj}
+	}
+	obj["apiVersion"] = target.apiVersion
+	obj["kind"] = target.kinds[0]
+	obj["metadata"] = map[string]any{"name": pullSecretsPatchName}
+	return obj
+}
+
+// TakeImageRewrite removes the entries that AddToKustomization added to
+// kustomization and returns the rewrite they were generated from. Fields left
+// empty by the removal are deleted. kustomization is modified in place.
+func TakeImageRewrite(kustomization map[string]any, images []string) ImageRewrite {
+	var r ImageRewrite
+	if kustomization == nil {
+		return r
+	}
+
+	if entries, ok := kustomization["images"].([]any); ok {
+		agentImage := map[string]bool{}
+		for _, name := range images {
+			agentImage[name] = true
+		}
+		// The entries count as generated when every agent image is moved to
+		// the same mirror, except for those the kustomization lists itself.
+		mirrored := map[string]bool{}
+		listed := map[string]bool{}
+		mirror, consistent := "", true
+		for _, e := range entries {
+			entry, _ := e.(map[string]any)
+			name, _ := entry["name"].(string)
+			listed[name] = true
+			if m, ok := generatedImageMirror(entry, agentImage); ok {
+				if mirror != "" && m != mirror {
+					consistent = false
+				}
+				mirror = m
+				mirrored[name] = true
+			}
+		}
+		for _, name := range images {
+			if !listed[name] {
+				consistent = false
+			}
+		}
+		if mirror != "" && consistent {
+			r.RegistryMirror = mirror
+			kept := make([]any, 0, len(entries))
+			for _, e := range entries {
+				entry, _ := e.(map[string]any)
+				if _, ok := generatedImageMirror(entry, agentImage); !ok {
+					kept = append(kept, e)
+				}
+			}
+			setOrDelete(kustomization, "images", kept)
+		}
+	}
+
+	if patches, ok := kustomization["patches"].([]any); ok {
+		kept := make([]any, 0, len(patches))
+		for _, p := range patches {
+			if secrets, ok := generatedPullSecrets(p); ok && (r.PullSecrets == nil || slices.Equal(secrets, r.PullSecrets)) {
+				r.PullSecrets = secrets
+				continue
+			}
+			kept = append(kept, p)
+		}
+		setOrDelete(kustomization, "patches", kept)
+	}
+	return r
+}
+
+// generatedImageMirror returns the mirror of an images entry shaped like
+// those AddToKustomization generates for agent images.
+func generatedImageMirror(entry map[string]any, agentImage map[string]bool) (string, bool) {
+	if len(entry) != 2 {
+		return "", false
+	}
+	name, _ := entry["name"].(string)
+	newName, _ := entry["newName"].(string)
+	if !agentImage[name] || newName == "" {
+		return "", false
+	}
+	repository := strings.TrimPrefix(mirrorImage("", name), "/")
+	mirror, ok := strings.CutSuffix(newName, "/"+repository)
+	if !ok || mirror == "" {
+		return "", false
+	}
+	return mirror, true
+}
+
+// generatedPullSecrets returns the secrets of a patch shaped like those
+// AddToKustomization generates for pull secrets.
+func generatedPullSecrets(p any) ([]string, bool) {
+	patch, ok := p.(map[string]any)
+	if !ok || len(patch) != 2 {
+		return nil, false
+	}
+	selector, _ := patch["target"].(map[string]any)
+	i := slices.IndexFunc(pullSecretsPatchTargets, func(t pullSecretsPatchTarget) bool {
+		return reflect.DeepEqual(selector, t.selector())
+	})
+	if i < 0 {
+		return nil, false
+	}
+	target := pullSecretsPatchTargets[i]
+	body, _ := patch["patch"].(string)
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(body), &obj); err != nil {
+		return nil, false
+	}
+	refs, _, _ := unstructured.NestedSlice(obj, append(slices.Clone(target.specPath), "imagePullSecrets")...)
+	if len(refs) == 0 {
+		return nil, false
+	}
+	secrets := make([]string, 0, len(refs))
+	for _, r := range refs {
+		ref, _ := r.(map[string]any)
+		name, _ := ref["name"].(string)
+		secrets = append(secrets, name)
+	}
+	// Anything else in the patch makes it the kustomization's own.
+	if !reflect.DeepEqual(obj, pullSecretsPatchObject(target, secrets)) {
+		return nil, false
+	}
+	return secrets, true
+}
+
+func setOrDelete(m map[string]any, key string, values []any) {
+	if len(values) == 0 {
+		delete(m, key)
+		return
+	}
+	m[key] = values
+}
+
+// imageName returns image without its tag or digest.
+func imageName(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
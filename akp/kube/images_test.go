@@ -0,0 +1,283 @@
+package kube
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+func TestMirrorImage(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "quay.io/akuity/agent:v0.5.0", want: "mirror.example.com/akp/akuity/agent:v0.5.0"},
+		{image: "us-docker.pkg.dev/akuity/argocd@sha256:abc", want: "mirror.example.com/akp/akuity/argocd@sha256:abc"},
+		{image: "localhost:5000/agent", want: "mirror.example.com/akp/agent"},
+		{image: "redis:7.2", want: "mirror.example.com/akp/library/redis:7.2"},
+		{image: "bitnami/redis:7.2", want: "mirror.example.com/akp/bitnami/redis:7.2"},
+		{image: "mirror.example.com/akp/akuity/agent:v0.5.0", want: "mirror.example.com/akp/akuity/agent:v0.5.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.want, mirrorImage("mirror.example.com/akp/", tt.image))
+		})
+	}
+}
+
+func TestImageRewriteApply(t *testing.T) {
+	rewrite := ImageRewrite{
+		RegistryMirror: "mirror.example.com",
+		PullSecrets:    []string{"mirror-creds", "existing"},
+	}
+
+	t.Run("cron job", func(t *testing.T) {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "batch/v1",
+			"kind":       "CronJob",
+			"spec": map[string]any{
+				"jobTemplate": map[string]any{"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+					"initContainers":   []any{map[string]any{"name": "init", "image": "quay.io/akuity/init:v1"}},
+					"containers":       []any{map[string]any{"name": "main", "image": "quay.io/akuity/agent:v1"}},
+					"imagePullSecrets": []any{map[string]any{"name": "existing"}},
+				}}}},
+			},
+		}}
+		require.NoError(t, rewrite.Apply(obj))
+
+		podSpec, _, err := unstructured.NestedMap(obj.Object, "spec", "jobTemplate", "spec", "template", "spec")
+		require.NoError(t, err)
+		assert.Equal(t, "mirror.example.com/akuity/init:v1", podSpec["initContainers"].([]any)[0].(map[string]any)["image"])
+		assert.Equal(t, "mirror.example.com/akuity/agent:v1", podSpec["containers"].([]any)[0].(map[string]any)["image"])
+		assert.Equal(t, []any{
+			map[string]any{"name": "existing"},
+			map[string]any{"name": "mirror-creds"},
+		}, podSpec["imagePullSecrets"])
+	})
+
+	t.Run("object without pod spec", func(t *testing.T) {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"data":       map[string]any{"image": "quay.io/akuity/agent:v1"},
+		}}
+		require.NoError(t, rewrite.Apply(obj))
+		assert.Equal(t, "quay.io/akuity/agent:v1", obj.Object["data"].(map[string]any)["image"])
+	})
+}
+
+func TestAgentImages(t *testing.T) {
+	images, err := AgentImages(ArgoCDAgentManifests)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"quay.io/akuity/agent", "quay.io/akuity/argocd", "quay.io/akuity/redis"}, images)
+
+	images, err = AgentImages(KargoAgentManifests)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"quay.io/akuity/agent", "ghcr.io/akuity/kargo", "quay.io/akuityio/argo-rollouts"}, images)
+}
+
+func TestImageName(t *testing.T) {
+	tests := map[string]string{
+		"quay.io/akuity/agent:v0.5.0":         "quay.io/akuity/agent",
+		"quay.io/akuity/agent@sha256:abc":     "quay.io/akuity/agent",
+		"quay.io/akuity/agent:v1@sha256:abc":  "quay.io/akuity/agent",
+		"localhost:5000/agent":                "localhost:5000/agent",
+		"localhost:5000/agent:v1":             "localhost:5000/agent",
+		"redis":                               "redis",
+		"registry.example.com:443/akuity/cli": "registry.example.com:443/akuity/cli",
+	}
+	for image, want := range tests {
+		t.Run(image, func(t *testing.T) {
+			assert.Equal(t, want, imageName(image))
+		})
+	}
+}
+
+func TestImageRewriteKustomization(t *testing.T) {
+	images := []string{"quay.io/akuity/agent", "quay.io/akuity/argocd", "quay.io/akuity/redis"}
+	userImage := map[string]any{"name": "quay.io/akuity/redis", "newTag": "7.2"}
+	userPatch := map[string]any{
+		"target": map[string]any{"kind": "Deployment", "name": "argocd-repo-server"},
+		"patch":  `[{"op":"add","path":"/spec/replicas","value":2}]`,
+	}
+
+	tests := map[string]struct {
+		rewrite       ImageRewrite
+		kustomization map[string]any
+		expected      map[string]any
+		unmatched     []string
+	}{
+		"mirror and secrets": {
+			rewrite: ImageRewrite{RegistryMirror: "mirror.example.com/akp", PullSecrets: []string{"creds", "more-creds"}},
+			expected: map[string]any{
+				"images": []any{
+					map[string]any{"name": "quay.io/akuity/agent", "newName": "mirror.example.com/akp/akuity/agent"},
+					map[string]any{"name": "quay.io/akuity/argocd", "newName": "mirror.example.com/akp/akuity/argocd"},
+					map[string]any{"name": "quay.io/akuity/redis", "newName": "mirror.example.com/akp/akuity/redis"},
+				},
+				"patches": []any{
+					map[string]any{
+						"target": map[string]any{"kind": "Deployment|StatefulSet|DaemonSet|ReplicaSet|Job"},
+						"patch":  `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"image-pull-secrets"},"spec":{"template":{"spec":{"imagePullSecrets":[{"name":"creds"},{"name":"more-creds"}]}}}}`,
+					},
+					map[string]any{
+						"target": map[string]any{"kind": "CronJob"},
+						"patch":  `{"apiVersion":"batch/v1","kind":"CronJob","metadata":{"name":"image-pull-secrets"},"spec":{"jobTemplate":{"spec":{"template":{"spec":{"imagePullSecrets":[{"name":"creds"},{"name":"more-creds"}]}}}}}}`,
+					},
+					map[string]any{
+						"target": map[string]any{"kind": "Pod"},
+						"patch":  `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"image-pull-secrets"},"spec":{"imagePullSecrets":[{"name":"creds"},{"name":"more-creds"}]}}`,
+					},
+				},
+			},
+			// The Argo CD agent has no cron job or bare pod.
+			unmatched: []string{
+				"patches[1]: target {kind=CronJob} matches no agent object",
+				"patches[2]: target {kind=Pod} matches no agent object",
+			},
+		},
+		"on top of a kustomization": {
+			rewrite: ImageRewrite{RegistryMirror: "mirror.example.com"},
+			kustomization: map[string]any{
+				"images":  []any{userImage},
+				"patches": []any{userPatch},
+			},
+			expected: map[string]any{
+				"images": []any{
+					userImage,
+					map[string]any{"name": "quay.io/akuity/agent", "newName": "mirror.example.com/akuity/agent"},
+					map[string]any{"name": "quay.io/akuity/argocd", "newName": "mirror.example.com/akuity/argocd"},
+				},
+				"patches": []any{userPatch},
+			},
+		},
+		"no rewrite": {
+			kustomization: map[string]any{"patches": []any{userPatch}},
+			expected:      map[string]any{"patches": []any{userPatch}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			original := maps.Clone(tc.kustomization)
+			kustomization := tc.rewrite.AddToKustomization(tc.kustomization, images)
+			assert.Equal(t, tc.expected, kustomization)
+
+			if kustomization != nil {
+				data, err := yaml.Marshal(kustomization)
+				require.NoError(t, err)
+				unmatched, err := ValidateKustomization(string(data), ArgoCDAgentManifests, "")
+				require.NoError(t, err)
+				assert.Equal(t, tc.unmatched, unmatched)
+			}
+
+			assert.Equal(t, tc.rewrite, TakeImageRewrite(kustomization, images))
+			if original == nil {
+				original = map[string]any{}
+			}
+			assert.Equal(t, original, kustomization)
+		})
+	}
+}
+
+func TestImageRewriteKustomizationPullSecrets(t *testing.T) {
+	manifests := []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: agent
+spec:
+  template:
+    spec:
+      imagePullSecrets:
+      - name: existing
+      containers:
+      - name: agent
+        image: quay.io/akuity/agent:v1
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: redis
+spec:
+  template:
+    spec:
+      containers:
+      - name: redis
+        image: quay.io/akuity/redis:7
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: cleanup
+            image: quay.io/akuity/agent:v1
+`)
+	rewrite := ImageRewrite{PullSecrets: []string{"creds", "existing"}}
+	kustomization := rewrite.AddToKustomization(nil, nil)
+	kustomization["resources"] = []string{"agent.yaml"}
+	data, err := yaml.Marshal(kustomization)
+	require.NoError(t, err)
+
+	fSys := filesys.MakeFsInMemory()
+	require.NoError(t, fSys.WriteFile("/agent/agent.yaml", manifests))
+	require.NoError(t, fSys.WriteFile("/agent/kustomization.yaml", data))
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, "/agent")
+	require.NoError(t, err)
+	built, err := resources.AsYaml()
+	require.NoError(t, err)
+	objs, err := SplitYAML(built)
+	require.NoError(t, err)
+	require.Len(t, objs, 3)
+
+	// The secrets are added to every workload, and those it had are kept.
+	for _, obj := range objs {
+		secrets, _, err := unstructured.NestedSlice(obj.Object, append(append([]string{}, podSpecPaths[obj.GetKind()]...), "imagePullSecrets")...)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []any{
+			map[string]any{"name": "creds"},
+			map[string]any{"name": "existing"},
+		}, secrets, obj.GetKind())
+	}
+}
+
+func TestTakeImageRewriteUserPatches(t *testing.T) {
+	kustomization := ImageRewrite{PullSecrets: []string{"creds"}}.AddToKustomization(nil, nil)
+	patches := kustomization["patches"].([]any)
+	// A patch with the target of a generated one but more to it is the
+	// kustomization's own.
+	patches[0].(map[string]any)["patch"] = `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"image-pull-secrets"},"spec":{"replicas":2,"template":{"spec":{"imagePullSecrets":[{"name":"creds"}]}}}}`
+
+	assert.Equal(t, ImageRewrite{PullSecrets: []string{"creds"}}, TakeImageRewrite(kustomization, nil))
+	assert.Equal(t, []any{patches[0]}, kustomization["patches"])
+}
+
+func TestTakeImageRewriteUserImages(t *testing.T) {
+	images := []string{"quay.io/akuity/agent", "quay.io/akuity/argocd"}
+
+	// Entries that do not move every agent image to the same mirror are the
+	// kustomization's own.
+	kustomization := map[string]any{"images": []any{
+		map[string]any{"name": "quay.io/akuity/agent", "newName": "mirror.example.com/akuity/agent"},
+	}}
+	assert.Equal(t, ImageRewrite{}, TakeImageRewrite(kustomization, images))
+	assert.Len(t, kustomization["images"], 1)
+
+	kustomization = map[string]any{"images": []any{
+		map[string]any{"name": "quay.io/akuity/agent", "newName": "mirror.example.com/akuity/agent"},
+		map[string]any{"name": "quay.io/akuity/argocd", "newName": "other.example.com/akuity/argocd"},
+	}}
+	assert.Equal(t, ImageRewrite{}, TakeImageRewrite(kustomization, images))
+	assert.Len(t, kustomization["images"], 2)
+}
@@ -0,0 +1,136 @@
+package kube
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/provider"
+	"sigs.k8s.io/kustomize/api/resmap"
+	kustomizetypes "sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// ArgoCDAgentManifests stands in for the Argo CD agent manifests when
+	// validating a cluster kustomization.
+	//go:embed manifests/argocd-agent.yaml
+	ArgoCDAgentManifests []byte
+
+	// KargoAgentManifests stands in for the Kargo agent manifests when
+	// validating a Kargo agent kustomization.
+	//go:embed manifests/kargo-agent.yaml
+	KargoAgentManifests []byte
+)
+
+// kustomizationSources are the kustomization fields that pull in objects
+// rather than transform them. They are replaced by the representative
+// manifests, so validation never reaches out to the network.
+var kustomizationSources = []string{"resources", "bases", "components", "helmCharts", "helmGlobals"}
+
+// ValidateKustomization builds kustomization in memory on top of manifests,
+// moved into namespace. It returns the patch targets that match none of the
+// manifests, which kustomize silently skips, and an error if the
+// kustomization does not parse or build.
+func ValidateKustomization(kustomization string, manifests []byte, namespace string) ([]string, error) {
+	var k map[string]any
+	if err := yaml.Unmarshal([]byte(kustomization), &k); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if k == nil {
+		return nil, nil
+	}
+
+	resources, err := resmap.NewFactory(provider.NewDefaultDepProvider().GetResourceFactory()).NewResMapFromBytes(manifests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agent manifests: %w", err)
+	}
+	if namespace != "" {
+		for _, r := range resources.Resources() {
+			if r.GetNamespace() != "" {
+				if err := r.SetNamespace(namespace); err != nil {
+					return nil, fmt.Errorf("failed to load agent manifests: %w", err)
+				}
+			}
+		}
+	}
+
+	var unmatched []string
+	patches, _ := k["patches"].([]any)
+	for i, p := range patches {
+		patch, ok := p.(map[string]any)
+		if !ok || patch["target"] == nil {
+			continue
+		}
+		selector, err := patchTarget(patch["target"])
+		if err != nil {
+			return nil, fmt.Errorf("patches[%d]: invalid target: %w", i, err)
+		}
+		matched, err := resources.Select(*selector)
+		if err != nil {
+			return nil, fmt.Errorf("patches[%d]: invalid target: %w", i, err)
+		}
+		if len(matched) == 0 {
+			unmatched = append(unmatched, fmt.Sprintf("patches[%d]: target %s matches no agent object", i, describeSelector(selector)))
+		}
+	}
+
+	for _, field := range kustomizationSources {
+		delete(k, field)
+	}
+	k["resources"] = []string{"agent.yaml"}
+	return unmatched, buildKustomization(k, resources)
+}
+
+func buildKustomization(k map[string]any, resources resmap.ResMap) error {
+	manifests, err := resources.AsYaml()
+	if err != nil {
+		return err
+	}
+	kustomization, err := yaml.Marshal(k)
+	if err != nil {
+		return err
+	}
+	fSys := filesys.MakeFsInMemory()
+	if err := fSys.WriteFile("/agent/agent.yaml", manifests); err != nil {
+		return err
+	}
+	if err := fSys.WriteFile("/agent/kustomization.yaml", kustomization); err != nil {
+		return err
+	}
+	_, err = krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, "/agent")
+	return err
+}
+
+func patchTarget(target any) (*kustomizetypes.Selector, error) {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+	var selector kustomizetypes.Selector
+	if err := json.Unmarshal(data, &selector); err != nil {
+		return nil, err
+	}
+	return &selector, nil
+}
+
+func describeSelector(s *kustomizetypes.Selector) string {
+	var parts []string
+	for _, f := range []struct{ key, value string }{
+		{"group", s.Group},
+		{"version", s.Version},
+		{"kind", s.Kind},
+		{"name", s.Name},
+		{"namespace", s.Namespace},
+		{"labelSelector", s.LabelSelector},
+		{"annotationSelector", s.AnnotationSelector},
+	} {
+		if f.value != "" {
+			parts = append(parts, fmt.Sprintf("%s=%s", f.key, f.value))
+		}
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
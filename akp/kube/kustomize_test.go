@@ -0,0 +1,104 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKustomization(t *testing.T) {
+	tests := []struct {
+		name          string
+		kustomization string
+		manifests     []byte
+		namespace     string
+		wantUnmatched []string
+		wantErr       string
+	}{
+		{
+			name: "resource patch on the repo server",
+			kustomization: `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+patches:
+  - patch: |-
+      apiVersion: apps/v1
+      kind: Deployment
+      metadata:
+        name: argocd-repo-server
+      spec:
+        template:
+          spec:
+            containers:
+            - name: argocd-repo-server
+              resources:
+                limits:
+                  memory: 2Gi
+    target:
+      kind: Deployment
+      name: argocd-repo-server
+`,
+			manifests: ArgoCDAgentManifests,
+		},
+		{
+			name: "images that are not used",
+			kustomization: `images:
+  - name: ghcr.io/akuity/kargo
+    newName: registry.example.com/akuity/kargo
+  - name: quay.io/akuity/unused
+    newName: registry.example.com/akuity/unused
+`,
+			manifests: KargoAgentManifests,
+		},
+		{
+			name: "target in the configured namespace",
+			kustomization: `patches:
+  - patch: '[{"op": "add", "path": "/spec/replicas", "value": 2}]'
+    target:
+      kind: Deployment
+      name: kargo-controller
+      namespace: kargo-agent
+`,
+			manifests: KargoAgentManifests,
+			namespace: "kargo-agent",
+		},
+		{
+			name: "target that matches nothing",
+			kustomization: `patches:
+  - patch: '[{"op": "add", "path": "/spec/replicas", "value": 2}]'
+    target:
+      kind: Deployment
+      name: argocd-server
+`,
+			manifests:     ArgoCDAgentManifests,
+			wantUnmatched: []string{"patches[0]: target {kind=Deployment, name=argocd-server} matches no agent object"},
+		},
+		{
+			name: "invalid JSON patch",
+			kustomization: `patches:
+  - patch: '[{"op": "replace", "path": "/spec/missing/field", "value": 2}]'
+    target:
+      kind: Deployment
+      name: argocd-redis
+`,
+			manifests: ArgoCDAgentManifests,
+			wantErr:   "doc is missing path",
+		},
+		{
+			name:          "invalid YAML",
+			kustomization: "patches: [",
+			manifests:     ArgoCDAgentManifests,
+			wantErr:       "invalid YAML",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unmatched, err := ValidateKustomization(tt.kustomization, tt.manifests, tt.namespace)
+			assert.Equal(t, tt.wantUnmatched, unmatched)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
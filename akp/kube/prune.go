@@ -0,0 +1,229 @@
+package kube
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// AppliedByLabel is set on every object applied for an ApplySet, with the
+	// set's ID as value. Prune only ever considers objects carrying it.
+	AppliedByLabel = "akuity.io/applied-by"
+	// appliedKindsAnnotation lists, on the ApplySet's record ConfigMap, the
+	// group kinds applied last time, so kinds dropped from the manifests
+	// altogether are still searched for stale objects.
+	appliedKindsAnnotation = "akuity.io/applied-kinds"
+)
+
+// neverPrune lists kinds that are not pruned even when they disappear from
+// the manifests: deleting them would take down everything inside them.
+var neverPrune = map[schema.GroupKind]bool{
+	{Kind: "Namespace"}: true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: true,
+}
+
+// ApplySet identifies the objects applied for one owner, so that objects
+// dropped from later manifests can be found and pruned.
+type ApplySet struct {
+	// ID is the AppliedByLabel value of every member.
+	ID string
+	// Namespace holds the ConfigMap recording the applied kinds. When empty,
+	// the first Namespace object of the applied manifests is used.
+	Namespace string
+}
+
+// NewApplySet returns the ApplySet identified by parts, e.g. an instance ID and
+// an agent name. The ID is hashed so it always fits in a label value.
+func NewApplySet(namespace string, parts ...string) ApplySet {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "/")))
+	return ApplySet{
+		ID:        "akp-" + hex.EncodeToString(sum[:])[:16],
+		Namespace: namespace,
+	}
+}
+
+// Label marks obj as a member of the set.
+func (s ApplySet) Label(obj *unstructured.Unstructured) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[AppliedByLabel] = s.ID
+	obj.SetLabels(labels)
+}
+
+func (s ApplySet) recordName() string {
+	return s.ID + "-applyset"
+}
+
+// Prune deletes the members of set that are not in applied. Every kind in
+// applied or recorded by the previous Prune is searched, in all namespaces.
+// It returns the objects deleted, or with dryRun the objects that would be
+// deleted, as "Kind namespace/name". The kinds of applied are recorded for the
+// next Prune unless dryRun is set.
+func (k *Kubectl) Prune(ctx context.Context, set ApplySet, applied []unstructured.Unstructured, dryRun bool) ([]string, error) {
+	client, err := dynamic.NewForConfig(k.config)
+	if err != nil {
+		return nil, err
+	}
+	if set.Namespace == "" {
+		set.Namespace = firstNamespace(applied)
+	}
+
+	keep := map[string]bool{}
+	kinds := map[schema.GroupKind]bool{}
+	for _, obj := range applied {
+		gk := obj.GroupVersionKind().GroupKind()
+		kinds[gk] = true
+		keep[objectKey(gk, obj.GetNamespace(), obj.GetName())] = true
+		if obj.GetNamespace() == "" {
+			// Namespaced objects applied without a namespace land in the
+			// default namespace.
+			keep[objectKey(gk, metav1.NamespaceDefault, obj.GetName())] = true
+		}
+	}
+	recorded, err := recordedKinds(ctx, client, set)
+	if err != nil {
+		return nil, err
+	}
+	for _, gk := range recorded {
+		kinds[gk] = true
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	deleteOpts := metav1.DeleteOptions{PropagationPolicy: &propagation}
+	if dryRun {
+		deleteOpts.DryRun = []string{metav1.DryRunAll}
+	}
+	var pruned []string
+	for _, gk := range sortedKinds(kinds) {
+		if neverPrune[gk] {
+			continue
+		}
+		mapping, err := k.restMapping(gk)
+		if meta.IsNoMatchError(err) {
+			// The kind is no longer served, so there is nothing left to prune.
+			continue
+		}
+		if err != nil {
+			return pruned, err
+		}
+		namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+		list, err := client.Resource(mapping.Resource).List(ctx, metav1.ListOptions{
+			LabelSelector: AppliedByLabel + "=" + set.ID,
+		})
+		if err != nil {
+			return pruned, fmt.Errorf("failed to list %s: %w", gk, err)
+		}
+		for _, item := range list.Items {
+			namespace := item.GetNamespace()
+			if keep[objectKey(gk, namespace, item.GetName())] || item.GetDeletionTimestamp() != nil {
+				continue
+			}
+			var ri dynamic.ResourceInterface = client.Resource(mapping.Resource)
+			if namespaced {
+				ri = client.Resource(mapping.Resource).Namespace(namespace)
+			}
+			err := ri.Delete(ctx, item.GetName(), deleteOpts)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return pruned, fmt.Errorf("failed to prune %s %s: %w", gk.Kind, item.GetName(), err)
+			}
+			pruned = append(pruned, describeObject(gk.Kind, namespace, item.GetName()))
+		}
+	}
+
+	if dryRun {
+		return pruned, nil
+	}
+	return pruned, recordKinds(ctx, client, set, applied)
+}
+
+// recordedKinds returns the kinds recorded by the previous Prune of set.
+func recordedKinds(ctx context.Context, client dynamic.Interface, set ApplySet) ([]schema.GroupKind, error) {
+	record, err := client.Resource(configMapResource).Namespace(set.Namespace).Get(ctx, set.recordName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied kinds: %w", err)
+	}
+	var kinds []schema.GroupKind
+	for _, s := range strings.Split(record.GetAnnotations()[appliedKindsAnnotation], ",") {
+		if s != "" {
+			kinds = append(kinds, schema.ParseGroupKind(s))
+		}
+	}
+	return kinds, nil
+}
+
+// recordKinds stores the kinds of applied on set's record ConfigMap. The
+// record carries no AppliedByLabel, so it is never pruned itself.
+func recordKinds(ctx context.Context, client dynamic.Interface, set ApplySet, applied []unstructured.Unstructured) error {
+	kinds := map[schema.GroupKind]bool{}
+	for _, obj := range applied {
+		kinds[obj.GroupVersionKind().GroupKind()] = true
+	}
+	names := make([]string, 0, len(kinds))
+	for _, gk := range sortedKinds(kinds) {
+		names = append(names, gk.String())
+	}
+	record := &unstructured.Unstructured{}
+	record.SetAPIVersion("v1")
+	record.SetKind("ConfigMap")
+	record.SetName(set.recordName())
+	record.SetNamespace(set.Namespace)
+	record.SetAnnotations(map[string]string{appliedKindsAnnotation: strings.Join(names, ",")})
+	_, err := client.Resource(configMapResource).Namespace(set.Namespace).Apply(ctx, record.GetName(), record, metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record applied kinds: %w", err)
+	}
+	return nil
+}
+
+var configMapResource = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+func firstNamespace(objs []unstructured.Unstructured) string {
+	for _, obj := range objs {
+		if obj.GroupVersionKind().GroupKind() == (schema.GroupKind{Kind: "Namespace"}) {
+			return obj.GetName()
+		}
+	}
+	return metav1.NamespaceDefault
+}
+
+func sortedKinds(kinds map[schema.GroupKind]bool) []schema.GroupKind {
+	sorted := make([]schema.GroupKind, 0, len(kinds))
+	for gk := range kinds {
+		sorted = append(sorted, gk)
+	}
+	slices.SortFunc(sorted, func(a, b schema.GroupKind) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return sorted
+}
+
+// objectKey identifies an object within an ApplySet.
+func objectKey(gk schema.GroupKind, namespace, name string) string {
+	return gk.String() + "/" + namespace + "/" + name
+}
+
+func describeObject(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + " " + name
+	}
+	return kind + " " + namespace + "/" + name
+}
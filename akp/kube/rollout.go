@@ -0,0 +1,264 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	rolloutPollInterval = 5 * time.Second
+	// diagnoseTimeout bounds collecting pod failures once the rollout wait
+	// itself has run out of time.
+	diagnoseTimeout = 15 * time.Second
+)
+
+// podFailureReasons are container waiting and termination reasons that
+// explain a stuck rollout and are worth surfacing to the user.
+var podFailureReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"ErrImagePull":               true,
+	"Error":                      true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"OOMKilled":                  true,
+	"RunContainerError":          true,
+}
+
+// workload is a Deployment or StatefulSet whose rollout is awaited.
+type workload struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (w workload) String() string {
+	return describeObject(w.kind, w.namespace, w.name)
+}
+
+// WaitForRollout waits until every Deployment and StatefulSet in objs has
+// finished rolling out, like `kubectl rollout status`. If ctx ends first, the
+// error lists the unfinished workloads together with the failures reported by
+// their pods, such as ImagePullBackOff or OOMKilled.
+func (k *Kubectl) WaitForRollout(ctx context.Context, objs []unstructured.Unstructured) error {
+	var pending []workload
+	for _, obj := range objs {
+		gk := obj.GroupVersionKind().GroupKind()
+		if gk.Group != appsv1.GroupName || (gk.Kind != "Deployment" && gk.Kind != "StatefulSet") {
+			continue
+		}
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		pending = append(pending, workload{kind: gk.Kind, namespace: namespace, name: obj.GetName()})
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	client, err := kubernetes.NewForConfig(k.config)
+	if err != nil {
+		return err
+	}
+
+	status := map[workload]string{}
+	err = wait.PollUntilContextCancel(ctx, rolloutPollInterval, true, func(ctx context.Context) (bool, error) {
+		var remaining []workload
+		for _, w := range pending {
+			done, msg, err := rolloutStatus(ctx, client, w)
+			if err != nil {
+				return false, err
+			}
+			if !done {
+				status[w] = msg
+				remaining = append(remaining, w)
+			}
+		}
+		pending = remaining
+		return len(pending) == 0, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if !wait.Interrupted(err) && ctx.Err() == nil {
+		return err
+	}
+
+	diagCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), diagnoseTimeout)
+	defer cancel()
+	var msg strings.Builder
+	msg.WriteString("timed out waiting for rollout:")
+	for _, w := range pending {
+		progress := status[w]
+		if progress == "" {
+			progress = "rollout not complete"
+		}
+		fmt.Fprintf(&msg, "\n  %s: %s", w, progress)
+		failures, err := podFailures(diagCtx, client, w)
+		if err != nil {
+			fmt.Fprintf(&msg, "\n    (unable to inspect pods: %s)", err)
+		}
+		for _, f := range failures {
+			fmt.Fprintf(&msg, "\n    %s", f)
+		}
+	}
+	return errors.New(msg.String())
+}
+
+// rolloutStatus reports whether w has finished rolling out, and if not, a short
+// description of the progress so far.
+func rolloutStatus(ctx context.Context, client kubernetes.Interface, w workload) (bool, string, error) {
+	switch w.kind {
+	case "Deployment":
+		d, err := client.AppsV1().Deployments(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		done, msg := deploymentRolloutStatus(d)
+		return done, msg, nil
+	default:
+		sts, err := client.AppsV1().StatefulSets(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		done, msg := statefulSetRolloutStatus(sts)
+		return done, msg, nil
+	}
+}
+
+func deploymentRolloutStatus(d *appsv1.Deployment) (bool, string) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return false, "waiting for the deployment spec update to be observed"
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return false, fmt.Sprintf("exceeded its progress deadline: %s", c.Message)
+		}
+	}
+	replicas := ptrValue(d.Spec.Replicas, 1)
+	switch {
+	case d.Status.UpdatedReplicas < replicas:
+		return false, fmt.Sprintf("%d of %d updated replicas", d.Status.UpdatedReplicas, replicas)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d old replicas pending termination", d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d of %d updated replicas available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	}
+	return true, ""
+}
+
+func statefulSetRolloutStatus(sts *appsv1.StatefulSet) (bool, string) {
+	if sts.Status.ObservedGeneration == 0 || sts.Generation > sts.Status.ObservedGeneration {
+		return false, "waiting for the statefulset spec update to be observed"
+	}
+	replicas := ptrValue(sts.Spec.Replicas, 1)
+	if sts.Status.ReadyReplicas < replicas {
+		return false, fmt.Sprintf("%d of %d replicas ready", sts.Status.ReadyReplicas, replicas)
+	}
+	if sts.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return true, ""
+	}
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil && *ru.Partition > 0 {
+		updated := replicas - *ru.Partition
+		if sts.Status.UpdatedReplicas < updated {
+			return false, fmt.Sprintf("%d of %d updated replicas", sts.Status.UpdatedReplicas, updated)
+		}
+		return true, ""
+	}
+	if sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+		return false, fmt.Sprintf("%d of %d updated replicas", sts.Status.UpdatedReplicas, replicas)
+	}
+	return true, ""
+}
+
+// podFailures describes why the pods of w are not becoming ready.
+func podFailures(ctx context.Context, client kubernetes.Interface, w workload) ([]string, error) {
+	var selector *metav1.LabelSelector
+	switch w.kind {
+	case "Deployment":
+		d, err := client.AppsV1().Deployments(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = d.Spec.Selector
+	default:
+		sts, err := client.AppsV1().StatefulSets(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = sts.Spec.Selector
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := client.CoreV1().Pods(w.namespace).List(ctx, metav1.ListOptions{LabelSelector: s.String()})
+	if err != nil {
+		return nil, err
+	}
+	var failures []string
+	for i := range pods.Items {
+		failures = append(failures, podFailure(&pods.Items[i])...)
+	}
+	return failures, nil
+}
+
+// podFailure returns the scheduling and container failures of pod.
+func podFailure(pod *corev1.Pod) []string {
+	var failures []string
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			failures = append(failures, fmt.Sprintf("pod %s: %s: %s", pod.Name, c.Reason, c.Message))
+		}
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		reason, message := containerFailure(cs)
+		if reason == "" {
+			continue
+		}
+		failure := fmt.Sprintf("pod %s container %s: %s", pod.Name, cs.Name, reason)
+		if message != "" {
+			failure += ": " + message
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+// containerFailure returns the reason a container is failing, preferring its
+// current state over the last termination, e.g. CrashLoopBackOff caused by an
+// earlier OOMKilled.
+func containerFailure(cs corev1.ContainerStatus) (string, string) {
+	if w := cs.State.Waiting; w != nil && podFailureReasons[w.Reason] {
+		if t := cs.LastTerminationState.Terminated; t != nil && podFailureReasons[t.Reason] {
+			return w.Reason, fmt.Sprintf("last terminated with %s (exit code %d)", t.Reason, t.ExitCode)
+		}
+		return w.Reason, w.Message
+	}
+	if t := cs.State.Terminated; t != nil && podFailureReasons[t.Reason] {
+		return t.Reason, t.Message
+	}
+	if t := cs.LastTerminationState.Terminated; t != nil && !cs.Ready && podFailureReasons[t.Reason] {
+		return t.Reason, t.Message
+	}
+	return "", ""
+}
+
+func ptrValue(p *int32, def int32) int32 {
+	if p == nil {
+		return def
+	}
+	return *p
+}
@@ -0,0 +1,101 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	replicas := int32(2)
+	tests := []struct {
+		name   string
+		status appsv1.DeploymentStatus
+		done   bool
+		msg    string
+	}{
+		{
+			name:   "not observed",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 1},
+			msg:    "waiting for the deployment spec update to be observed",
+		},
+		{
+			name:   "updating",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 1},
+			msg:    "1 of 2 updated replicas",
+		},
+		{
+			name:   "old replicas terminating",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2},
+			msg:    "1 old replicas pending termination",
+		},
+		{
+			name:   "not available",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+			msg:    "1 of 2 updated replicas available",
+		},
+		{
+			name: "progress deadline exceeded",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "agent-123" has timed out progressing.`,
+			}}},
+			msg: `exceeded its progress deadline: ReplicaSet "agent-123" has timed out progressing.`,
+		},
+		{
+			name:   "complete",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			done:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     tt.status,
+			}
+			done, msg := deploymentRolloutStatus(d)
+			assert.Equal(t, tt.done, done)
+			assert.Equal(t, tt.msg, msg)
+		})
+	}
+}
+
+func TestPodFailure(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent-123"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "agent",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: `Back-off pulling image "quay.io/akuity/agent:bad"`,
+					}},
+				},
+				{
+					Name:  "redis",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						Reason:   "OOMKilled",
+						ExitCode: 137,
+					}},
+				},
+				{
+					Name:  "healthy",
+					Ready: true,
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []string{
+		`pod agent-123 container agent: ImagePullBackOff: Back-off pulling image "quay.io/akuity/agent:bad"`,
+		"pod agent-123 container redis: CrashLoopBackOff: last terminated with OOMKilled (exit code 137)",
+	}, podFailure(pod))
+}
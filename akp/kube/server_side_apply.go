@@ -0,0 +1,84 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// FieldManager is the field manager recorded on every field the provider
+// owns through server-side apply. It must stay stable across releases, or
+// re-applies would conflict with the provider's own earlier applies.
+const FieldManager = "akp-terraform"
+
+// ServerSideApply applies obj with a server-side apply patch owned by
+// FieldManager. Unlike ApplyResource it needs no temp file, OpenAPI download
+// or last-applied annotation, so it is unaffected by the annotation size limit
+// large CRDs run into. With ForceConflicts, fields owned by other managers are
+// taken over instead of failing the apply.
+func (k *Kubectl) ServerSideApply(ctx context.Context, obj *unstructured.Unstructured, applyOpts ApplyOpts) (string, error) {
+	ri, err := k.resourceInterface(obj)
+	if err != nil {
+		return "", err
+	}
+	opts := metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        applyOpts.ForceConflicts,
+	}
+	if applyOpts.DryRunStrategy != cmdutil.DryRunNone {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	if _, err := ri.Apply(ctx, obj.GetName(), obj, opts); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s serverside-applied", obj.GetKind(), obj.GetName()), nil
+}
+
+// resourceInterface returns the dynamic client for obj's resource, scoped to
+// its namespace when the resource is namespaced.
+func (k *Kubectl) resourceInterface(obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := k.restMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(k.config)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		return client.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+	return client.Resource(mapping.Resource), nil
+}
+
+// restMapping maps gk to its resource, using the preferred version when no
+// versions are given. The discovery cache is reset once on a miss, since the
+// kind may come from a CRD applied moments earlier.
+func (k *Kubectl) restMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	mapper, err := k.fact.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := mapper.RESTMapping(gk, versions...)
+	if meta.IsNoMatchError(err) {
+		if r, ok := mapper.(meta.ResettableRESTMapper); ok {
+			r.Reset()
+			mapping, err = mapper.RESTMapping(gk, versions...)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", gk, err)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,37 @@
+package kube
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/transport"
+)
+
+// tokenFileRoundTripper authenticates every request with the bearer token
+// currently stored in path.
+type tokenFileRoundTripper struct {
+	path string
+	rt   http.RoundTripper
+}
+
+func newTokenFileRoundTripper(path string) transport.WrapperFunc {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &tokenFileRoundTripper{path: path, rt: rt}
+	}
+}
+
+func (t *tokenFileRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := os.ReadFile(t.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token_file: %w", err)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	return t.rt.RoundTrip(req)
+}
+
+func (t *tokenFileRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return t.rt
+}
@@ -0,0 +1,50 @@
+package string
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RecomputeWhenChanged returns a plan modifier for computed attributes that
+// are derived from other attributes at apply time, such as
+// agent_manifest_hash from image_registry_mirror. The value is planned as
+// unknown when any of the attributes at paths changes, which also plans an
+// update. It is meant to follow a modifier that carries over the state value.
+func RecomputeWhenChanged(paths ...path.Path) planmodifier.String {
+	return recomputeWhenChangedModifier{paths: paths}
+}
+
+type recomputeWhenChangedModifier struct {
+	paths []path.Path
+}
+
+func (m recomputeWhenChangedModifier) Description(_ context.Context) string {
+	return "Plans an unknown value when any of the attributes it is derived from changes."
+}
+
+func (m recomputeWhenChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recomputeWhenChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to compare against on create, nothing to plan on destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	for _, p := range m.paths {
+		var planned, prior attr.Value
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if planned.IsUnknown() || !planned.Equal(prior) {
+			resp.PlanValue = types.StringUnknown()
+			return
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package string
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecomputeWhenChanged(t *testing.T) {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"mirror": schema.StringAttribute{Optional: true},
+			"hash":   schema.StringAttribute{Computed: true},
+		},
+	}
+	objectType := s.Type().TerraformType(context.Background())
+	value := func(mirror any) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"mirror": tftypes.NewValue(tftypes.String, mirror),
+			"hash":   tftypes.NewValue(tftypes.String, "abc123"),
+		})
+	}
+	null := tftypes.NewValue(objectType, nil)
+
+	tests := map[string]struct {
+		plan     tftypes.Value
+		state    tftypes.Value
+		expected types.String
+	}{
+		"create - no modification": {
+			plan:     value("mirror.example.com"),
+			state:    null,
+			expected: types.StringValue("abc123"),
+		},
+		"unchanged - keep": {
+			plan:     value("mirror.example.com"),
+			state:    value("mirror.example.com"),
+			expected: types.StringValue("abc123"),
+		},
+		"changed - recompute": {
+			plan:     value("other.example.com"),
+			state:    value("mirror.example.com"),
+			expected: types.StringUnknown(),
+		},
+		"set - recompute": {
+			plan:     value("mirror.example.com"),
+			state:    value(nil),
+			expected: types.StringUnknown(),
+		},
+		"unknown - recompute": {
+			plan:     value(tftypes.UnknownValue),
+			state:    value("mirror.example.com"),
+			expected: types.StringUnknown(),
+		},
+		"destroy - no modification": {
+			plan:     null,
+			state:    value("mirror.example.com"),
+			expected: types.StringValue("abc123"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				Path:       path.Root("hash"),
+				Plan:       tfsdk.Plan{Schema: s, Raw: tc.plan},
+				State:      tfsdk.State{Schema: s, Raw: tc.state},
+				StateValue: types.StringValue("abc123"),
+				PlanValue:  types.StringValue("abc123"),
+			}
+			resp := &planmodifier.StringResponse{
+				PlanValue: types.StringValue("abc123"),
+			}
+
+			RecomputeWhenChanged(path.Root("mirror")).PlanModifyString(context.Background(), req, resp)
+
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, tc.expected, resp.PlanValue)
+		})
+	}
+}
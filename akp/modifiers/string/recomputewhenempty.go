@@ -0,0 +1,39 @@
+package string
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RecomputeWhenEmpty returns a plan modifier for computed attributes that Read
+// empties to request an update, such as agent_manifest_hash after drift was
+// detected. An empty state value is planned as unknown, which plans an update
+// even when nothing else changed. Any other state value is carried over like
+// UseStateForUnknown does.
+func RecomputeWhenEmpty() planmodifier.String {
+	return recomputeWhenEmptyModifier{}
+}
+
+type recomputeWhenEmptyModifier struct{}
+
+func (m recomputeWhenEmptyModifier) Description(_ context.Context) string {
+	return "Plans an unknown value when the state holds an empty string, and keeps the state value otherwise."
+}
+
+func (m recomputeWhenEmptyModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recomputeWhenEmptyModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to carry over on create or destroy.
+	if req.StateValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	if req.StateValue.ValueString() == "" {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
@@ -0,0 +1,64 @@
+package string
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecomputeWhenEmpty(t *testing.T) {
+	plan := tfsdk.Plan{Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})}
+	destroy := tfsdk.Plan{Raw: tftypes.NewValue(tftypes.Object{}, nil)}
+
+	tests := map[string]struct {
+		plan     tfsdk.Plan
+		state    types.String
+		expected types.String
+	}{
+		"create - leave unknown": {
+			plan:     plan,
+			state:    types.StringNull(),
+			expected: types.StringUnknown(),
+		},
+		"state empty - recompute": {
+			plan:     plan,
+			state:    types.StringValue(""),
+			expected: types.StringUnknown(),
+		},
+		"state set - keep state": {
+			plan:     plan,
+			state:    types.StringValue("abc123"),
+			expected: types.StringValue("abc123"),
+		},
+		"destroy - no modification": {
+			plan:     destroy,
+			state:    types.StringValue(""),
+			expected: types.StringUnknown(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				Plan:        tc.plan,
+				ConfigValue: types.StringNull(),
+				StateValue:  tc.state,
+				PlanValue:   types.StringUnknown(),
+			}
+			resp := &planmodifier.StringResponse{
+				PlanValue: types.StringUnknown(),
+			}
+
+			RecomputeWhenEmpty().PlanModifyString(context.Background(), req, resp)
+
+			require.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.expected, resp.PlanValue)
+		})
+	}
+}
@@ -2,39 +2,103 @@ package akp
 
 import (
 	"context"
-	"fmt"
+	"net/http"
 	"os"
+	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
 	"github.com/akuity/api-client-go/pkg/api/gateway/accesscontrol"
-	gwoption "github.com/akuity/api-client-go/pkg/api/gateway/option"
 	apikeyv1 "github.com/akuity/api-client-go/pkg/api/gen/apikey/v1"
 	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
 	kargov1 "github.com/akuity/api-client-go/pkg/api/gen/kargo/v1"
 	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
-	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+	tfakptypes "github.com/akuity/terraform-provider-akp/akp/types"
 )
 
-var _ provider.Provider = &AkpProvider{}
+var (
+	_ provider.Provider                       = &AkpProvider{}
+	_ provider.ProviderWithEphemeralResources = &AkpProvider{}
+)
 
 type AkpProvider struct {
 	version string
+	// clients overrides the gateway clients built from the provider
+	// configuration. It is only set by NewWithGatewayClients.
+	clients GatewayClients
+}
+
+// GatewayClients supplies the Akuity Platform API clients used by the provider.
+// The default implementation talks to the configured server URL; tests can
+// substitute an in-memory implementation such as fake.Server.
+type GatewayClients interface {
+	ArgoCD() argocdv1.ArgoCDServiceGatewayClient
+	Kargo() kargov1.KargoServiceGatewayClient
+	Organization() orgcv1.OrganizationServiceGatewayClient
+	APIKey() apikeyv1.APIKeyServiceGatewayClient
+}
+
+type gatewayClients struct {
+	argocd argocdv1.ArgoCDServiceGatewayClient
+	kargo  kargov1.KargoServiceGatewayClient
+	org    orgcv1.OrganizationServiceGatewayClient
+	apiKey apikeyv1.APIKeyServiceGatewayClient
+}
+
+// newGatewayClients returns the clients of the API at serverURL.
+func newGatewayClients(serverURL string, httpClient *http.Client) *gatewayClients {
+	gwc := gateway.NewClient(serverURL, gateway.WithHTTPClient(httpClient))
+	return &gatewayClients{
+		argocd: argocdv1.NewArgoCDServiceGatewayClient(gwc),
+		kargo:  kargov1.NewKargoServiceGatewayClient(gwc),
+		org:    orgcv1.NewOrganizationServiceGatewayClient(gwc),
+		apiKey: apikeyv1.NewAPIKeyServiceGatewayClient(gwc),
+	}
 }
 
+func (c *gatewayClients) ArgoCD() argocdv1.ArgoCDServiceGatewayClient           { return c.argocd }
+func (c *gatewayClients) Kargo() kargov1.KargoServiceGatewayClient              { return c.kargo }
+func (c *gatewayClients) Organization() orgcv1.OrganizationServiceGatewayClient { return c.org }
+func (c *gatewayClients) APIKey() apikeyv1.APIKeyServiceGatewayClient           { return c.apiKey }
+
 type AkpProviderModel struct {
-	ServerUrl        types.String `tfsdk:"server_url"`
-	ApiKeyId         types.String `tfsdk:"api_key_id"`
-	ApiKeySecret     types.String `tfsdk:"api_key_secret"`
-	OrganizationName types.String `tfsdk:"org_name"`
-	SkipTLSVerify    types.Bool   `tfsdk:"skip_tls_verify"`
+	ServerUrl        types.String           `tfsdk:"server_url"`
+	ApiKeyId         types.String           `tfsdk:"api_key_id"`
+	ApiKeySecret     types.String           `tfsdk:"api_key_secret"`
+	OrganizationName types.String           `tfsdk:"org_name"`
+	OrganizationId   types.String           `tfsdk:"org_id"`
+	SkipTLSVerify    types.Bool             `tfsdk:"skip_tls_verify"`
+	Kubeconfig       *tfakptypes.Kubeconfig `tfsdk:"kube_config"`
+
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	RetryMaxDelay         types.String  `tfsdk:"retry_max_delay"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+
+	CACertPEM  types.String `tfsdk:"ca_cert_pem"`
+	CACertFile types.String `tfsdk:"ca_cert_file"`
+	ClientCert types.String `tfsdk:"client_cert"`
+	ClientKey  types.String `tfsdk:"client_key"`
+	HTTPSProxy types.String `tfsdk:"https_proxy"`
+
+	Profile           types.String `tfsdk:"profile"`
+	ConfigFile        types.String `tfsdk:"config_file"`
+	CredentialProcess types.String `tfsdk:"credential_process"`
+
+	DefaultLabels      types.Map `tfsdk:"default_labels"`
+	DefaultAnnotations types.Map `tfsdk:"default_annotations"`
 }
 
 type AkpCli struct {
@@ -43,7 +107,27 @@ type AkpCli struct {
 	Cred      accesscontrol.ClientCredential
 	OrgCli    orgcv1.OrganizationServiceGatewayClient
 	ApiKeyCli apikeyv1.APIKeyServiceGatewayClient
-	OrgId     string
+	// OrgId is the ID of the organization, which is looked up from orgName
+	// on first use when the provider is configured with org_name. Call
+	// resolveOrg before reading it.
+	OrgId   string
+	orgName string
+	orgMu   sync.Mutex
+	// Kubeconfig is the provider-level kube_config, used by akp_cluster and
+	// akp_kargo_agent resources for any attribute their own block leaves unset.
+	Kubeconfig *tfakptypes.Kubeconfig
+	// clusterApplies batches the ApplyInstance requests of akp_cluster
+	// resources. Requests are sent one by one when it is nil.
+	clusterApplies *clusterApplyBatcher
+	// reads caches the instance and workspace reads that resources repeat.
+	// Reads always reach the API when it is nil.
+	reads *readCache
+	// defaultMetadata holds the provider-level default_labels and
+	// default_annotations.
+	defaultMetadata metadataDefaults
+	// policy holds the retry settings of the provider configuration. Its
+	// request limits are enforced by the transport of the API clients.
+	policy *requestPolicy
 }
 
 func (p *AkpProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -63,8 +147,15 @@ func (p *AkpProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Optional:            true,
 			},
 			"org_name": schema.StringAttribute{
-				MarkdownDescription: "Organization Name",
-				Required:            true,
+				MarkdownDescription: "Organization Name. At most one of `org_name` or `org_id` can be set, and the profile's organization is used when neither is. The organization ID is looked up from the name when the provider first calls the API.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("org_id")),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "Organization ID. At most one of `org_name` or `org_id` can be set, and the profile's organization is used when neither is. Unlike the name, the ID does not change when the organization is renamed.",
+				Optional:            true,
 			},
 			"api_key_id": schema.StringAttribute{
 				MarkdownDescription: "API Key Id. Use environment variable `AKUITY_API_KEY_ID`",
@@ -76,6 +167,91 @@ func (p *AkpProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"kube_config": schema.SingleNestedAttribute{
+				MarkdownDescription: "Default Kubernetes client configuration for installing agents. Used by `akp_cluster` and `akp_kargo_agent` resources without a `kube_config` block; attributes set in a resource's own `kube_config` override these one by one.",
+				Optional:            true,
+				Attributes:          getProviderKubeconfigAttributes(),
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times an API request that fails with a transient error, such as `Unavailable` or `ResourceExhausted`, is retried. Defaults to `5`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_delay": schema.StringAttribute{
+				MarkdownDescription: "Longest delay between two attempts of an API request, as a duration such as `30s` or `2m`. Retries back off exponentially up to this delay, and a longer delay requested by the API is cut down to it. Defaults to `30s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					positiveDurationValidator{},
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests the provider has in flight at once, across all resources. Unlimited by default.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum rate of API requests the provider sends, across all resources. Short bursts of up to one second's worth of requests are allowed. Must be at least `0.01`. Unlimited by default.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(minRequestsPerSecond),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA bundle trusted in addition to the system roots when connecting to the API, for self-hosted Akuity Platform behind an internal CA. You can also set this with the `AKUITY_CA_CERT_PEM` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM-encoded CA bundle trusted in addition to the system roots when connecting to the API. Conflicts with `ca_cert_pem`. You can also set this with the `AKUITY_CA_CERT_FILE` environment variable.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client certificate presented to the API, for ingresses that require mutual TLS. Requires `client_key`. You can also set this with the `AKUITY_CLIENT_CERT` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded private key of `client_cert`. You can also set this with the `AKUITY_CLIENT_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"https_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy used to reach the API, such as `http://proxy.example.com:3128`. Defaults to the proxy of the `HTTPS_PROXY` and `NO_PROXY` environment variables. You can also set this with the `AKUITY_HTTPS_PROXY` environment variable.",
+				Optional:            true,
+			},
+			"default_labels": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels added to every `akp_cluster` and `akp_kargo_agent` resource. A resource's own `labels` take precedence, and its `labels_all` attribute holds the result.",
+				Optional:            true,
+			},
+			"default_annotations": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Annotations added to every `akp_cluster` and `akp_kargo_agent` resource. A resource's own `annotations` take precedence, and its `annotations_all` attribute holds the result.",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile of `config_file` to use. A profile can set `server_url`, `org_name` or `org_id`, `api_key_id` and `api_key_secret`, and `credential_process`, each of which applies when neither the provider configuration nor the environment sets it. Defaults to `default`. You can also set this with the `AKUITY_PROFILE` environment variable.",
+				Optional:            true,
+			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the YAML file of named profiles, under a top-level `profiles` key. Defaults to `~/.akuity/config`, which is ignored if it does not exist. You can also set this with the `AKUITY_CONFIG_FILE` environment variable.",
+				Optional:            true,
+			},
+			"credential_process": schema.StringAttribute{
+				MarkdownDescription: "Command run with the system shell to get the API key when no `api_key_id` and `api_key_secret` are set. It must print a JSON object with `api_key_id` and `api_key_secret` fields to stdout. You can also set this with the `AKUITY_CREDENTIAL_PROCESS` environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -93,6 +269,9 @@ func (p *AkpProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	ServerUrl := os.Getenv("AKUITY_SERVER_URL")
 	apiKeyID := os.Getenv("AKUITY_API_KEY_ID")
 	apiKeySecret := os.Getenv("AKUITY_API_KEY_SECRET")
+	configFile := os.Getenv("AKUITY_CONFIG_FILE")
+	profileName := os.Getenv("AKUITY_PROFILE")
+	credentialProcess := os.Getenv("AKUITY_CREDENTIAL_PROCESS")
 
 	skipTLSVerify := config.SkipTLSVerify.ValueBool()
 	if ServerUrl == "" {
@@ -104,16 +283,73 @@ func (p *AkpProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	if apiKeySecret == "" {
 		apiKeySecret = config.ApiKeySecret.ValueString()
 	}
+	if configFile == "" {
+		configFile = config.ConfigFile.ValueString()
+	}
+	if profileName == "" {
+		profileName = config.Profile.ValueString()
+	}
+	if credentialProcess == "" {
+		credentialProcess = config.CredentialProcess.ValueString()
+	}
+	orgID := config.OrganizationId.ValueString()
+	orgName := config.OrganizationName.ValueString()
+
+	// The profile fills in what the configuration and environment leave
+	// unset.
+	profile, err := loadCredentialsProfile(configFile, profileName)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unable to Load Akuity Platform Profile",
+			"The provider cannot load the profile from the profiles file: "+err.Error(),
+		)
+		return
+	}
+	if ServerUrl == "" {
+		ServerUrl = profile.ServerURL
+	}
+	if apiKeyID == "" && apiKeySecret == "" {
+		apiKeyID, apiKeySecret = profile.APIKeyID, profile.APIKeySecret
+	}
+	if credentialProcess == "" {
+		credentialProcess = profile.CredentialProcess
+	}
+	if orgID == "" && orgName == "" {
+		orgID, orgName = profile.OrgID, profile.OrgName
+	}
 	if ServerUrl == "" {
 		ServerUrl = "https://akuity.cloud"
 	}
 
+	if apiKeyID == "" && apiKeySecret == "" && credentialProcess != "" {
+		creds, err := runCredentialProcess(ctx, credentialProcess)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_process"),
+				"Unable to Run Akuity Platform Credential Process",
+				"The provider cannot get the API key from credential_process: "+err.Error(),
+			)
+			return
+		}
+		apiKeyID, apiKeySecret = creds.APIKeyID, creds.APIKeySecret
+	}
+
+	if orgID == "" && orgName == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("org_name"),
+			"Missing Akuity Platform Organization",
+			"The provider cannot create the Akuity Platform API client as there is no organization. "+
+				"Set org_name or org_id, or select a profile that sets one of them.",
+		)
+	}
+
 	if apiKeyID == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key_id"),
 			"Missing Akuity Platform API Key Id",
 			"The provider cannot create the Akuity Platform API client as there is an missing API key. "+
-				"Use the AKUITY_API_KEY_ID environment variable to configure it.",
+				"Use the AKUITY_API_KEY_ID environment variable, a profile or credential_process to configure it.",
 		)
 	}
 	if apiKeySecret == "" {
@@ -121,67 +357,64 @@ func (p *AkpProvider) Configure(ctx context.Context, req provider.ConfigureReque
 			path.Root("api_key_secret"),
 			"Missing Akuity Platform API Key Secret",
 			"The provider cannot create the Akuity Platform API client as there is an missing API key. "+
-				"Use the AKUITY_API_KEY_SECRET environment variable to configure it.",
+				"Use the AKUITY_API_KEY_SECRET environment variable, a profile or credential_process to configure it.",
+		)
+	}
+
+	policy := buildRequestPolicy(config)
+
+	var defaults metadataDefaults
+	resp.Diagnostics.Append(config.DefaultLabels.ElementsAs(ctx, &defaults.Labels, true)...)
+	resp.Diagnostics.Append(config.DefaultAnnotations.ElementsAs(ctx, &defaults.Annotations, true)...)
+
+	// Requests always go through pacedTransport, which also reads the
+	// Retry-After header of throttled responses.
+	httpClient, err := newGatewayTransportConfig(config).httpClient()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Akuity Platform API TLS Configuration",
+			"The provider cannot create the Akuity Platform API client: "+err.Error(),
 		)
+	} else {
+		httpClient.Transport = &pacedTransport{base: httpClient.Transport, policy: policy}
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	orgName := config.OrganizationName.ValueString()
 	ctx = tflog.SetField(ctx, "server_url", ServerUrl)
 	ctx = tflog.SetField(ctx, "skip_tls_verify", skipTLSVerify)
 	ctx = tflog.SetField(ctx, "api_key_id", apiKeyID)
-	ctx = tflog.SetField(ctx, "org_name", orgName)
-
-	tflog.Debug(ctx, "Getting Organization ID by name")
 
 	cred := accesscontrol.NewAPIKeyCredential(apiKeyID, apiKeySecret)
-	// Get Organizaton ID by name
-	ctx = httpctx.SetAuthorizationHeader(ctx, cred.Scheme(), cred.Credential())
-	gwc := gwoption.NewClient(ServerUrl, skipTLSVerify)
-	orgc := orgcv1.NewOrganizationServiceGatewayClient(gwc)
-
-	// The Akuity Platform API sits behind a gateway that intermittently returns
-	// 502/503/504 (surfaced by the gateway client as codes.Unavailable). A single
-	// blip on this lookup fails provider configuration, and because every akp
-	// resource depends on the provider, that aborts the entire plan/apply. Retry
-	// the transient case with backoff so a momentary hiccup no longer fails a run.
-	res, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.GetOrganizationResponse, error) {
-		return orgc.GetOrganization(ctx, &orgcv1.GetOrganizationRequest{
-			Id:     orgName,
-			IdType: idv1.Type_NAME,
-		})
-	}, "get organization by name")
-	tflog.Debug(ctx, fmt.Sprintf("Res: %s", res))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Akuity Platform API Client",
-			"An unexpected error occurred when creating the Akuity Platform API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"Akuity Platform Client Error: "+err.Error(),
-		)
-		return
+	clients := p.clients
+	if clients == nil {
+		clients = newGatewayClients(ServerUrl, httpClient)
 	}
 
-	orgID := res.Organization.Id
-	tflog.Info(ctx, "Connection successful", map[string]any{"org_id": orgID})
-
-	argoc := argocdv1.NewArgoCDServiceGatewayClient(gwc)
-	kargoc := kargov1.NewKargoServiceGatewayClient(gwc)
-	orgc = orgcv1.NewOrganizationServiceGatewayClient(gwc)
-	apikeyc := apikeyv1.NewAPIKeyServiceGatewayClient(gwc)
+	// The organization is not looked up here, so that configuring the
+	// provider needs no network access. Plans without akp resources never
+	// call the API at all.
+	tflog.Debug(ctx, "Configured Akuity Platform API client")
 
+	reads := newReadCache()
 	akpCli := &AkpCli{
-		Cli:       argoc,
-		KargoCli:  kargoc,
-		Cred:      cred,
-		OrgId:     orgID,
-		OrgCli:    orgc,
-		ApiKeyCli: apikeyc,
+		Cli:             &invalidatingArgoCDClient{ArgoCDServiceGatewayClient: clients.ArgoCD(), reads: reads},
+		KargoCli:        clients.Kargo(),
+		Cred:            cred,
+		OrgId:           orgID,
+		orgName:         orgName,
+		OrgCli:          &cachingOrgClient{OrganizationServiceGatewayClient: clients.Organization(), reads: reads},
+		ApiKeyCli:       clients.APIKey(),
+		Kubeconfig:      config.Kubeconfig,
+		reads:           reads,
+		defaultMetadata: defaults,
+		policy:          policy,
 	}
+	akpCli.clusterApplies = newClusterApplyBatcher(akpCli.Cli.ApplyInstance, clusterApplyBatchWindow, clusterApplyBatchMaxSize)
 	resp.DataSourceData = akpCli
 	resp.ResourceData = akpCli
+	resp.EphemeralResourceData = akpCli
 }
 
 func (p *AkpProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -196,19 +429,38 @@ func (p *AkpProvider) Resources(ctx context.Context) []func() resource.Resource
 		NewAkpCustomRoleResource,
 		NewAkpWorkspaceResource,
 		NewAkpWorkspaceMemberResource,
+		NewAkpWorkspaceMembersResource,
 		NewAkpTeamResource,
+		NewAkpTeamMemberResource,
+		NewAkpTeamMembersResource,
 	}
 }
 
 func (p *AkpProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAkpInstanceDataSource,
+		NewAkpInstancesDataSource,
 		NewAkpClusterDataSource,
 		NewAkpClustersDataSource,
 		NewAkpKargoDataSource,
+		NewAkpKargoInstancesDataSource,
 		NewAkpKargoAgentDataSource,
 		NewAkpKargoAgentsDataSource,
 		NewAkpKargoDefaultShardAgentDataSource,
+		NewAkpWorkspaceDataSource,
+		NewAkpWorkspacesDataSource,
+		NewAkpTeamDataSource,
+		NewAkpTeamsDataSource,
+		NewAkpCustomRoleDataSource,
+		NewAkpCustomRolesDataSource,
+		NewAkpApiKeysDataSource,
+	}
+}
+
+func (p *AkpProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAkpClusterManifestsEphemeralResource,
+		NewAkpKargoAgentManifestsEphemeralResource,
 	}
 }
 
@@ -219,3 +471,15 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// NewWithGatewayClients returns a provider that uses clients instead of
+// connecting to the configured server URL. API key credentials are still
+// required by the provider configuration but are never sent anywhere.
+func NewWithGatewayClients(version string, clients GatewayClients) func() provider.Provider {
+	return func() provider.Provider {
+		return &AkpProvider{
+			version: version,
+			clients: clients,
+		}
+	}
+}
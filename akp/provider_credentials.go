@@ -0,0 +1,133 @@
+package akp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultConfigFile = "~/.akuity/config"
+	defaultProfile    = "default"
+	// credentialProcessTimeout bounds how long the provider waits for the
+	// credential_process command.
+	credentialProcessTimeout = time.Minute
+)
+
+// akuityConfigFile is the profiles file of the provider, e.g.
+//
+//	profiles:
+//	  default:
+//	    org_name: my-org
+//	    credential_process: vault-akuity-credentials
+//	  self-hosted:
+//	    server_url: https://akuity.example.com
+//	    org_id: 0123456789abcdef
+//	    api_key_id: ...
+//	    api_key_secret: ...
+type akuityConfigFile struct {
+	Profiles map[string]credentialsProfile `json:"profiles"`
+}
+
+// credentialsProfile is a named profile of the profiles file. Its settings
+// apply when the provider configuration and environment leave them unset.
+type credentialsProfile struct {
+	ServerURL         string `json:"server_url,omitempty"`
+	OrgName           string `json:"org_name,omitempty"`
+	OrgID             string `json:"org_id,omitempty"`
+	APIKeyID          string `json:"api_key_id,omitempty"`
+	APIKeySecret      string `json:"api_key_secret,omitempty"`
+	CredentialProcess string `json:"credential_process,omitempty"`
+}
+
+// processCredentials is the JSON document a credential_process command
+// prints to stdout.
+type processCredentials struct {
+	APIKeyID     string `json:"api_key_id"`
+	APIKeySecret string `json:"api_key_secret"`
+}
+
+// loadCredentialsProfile returns the named profile of the profiles file. When
+// neither the file nor the profile is set, the default profile of
+// ~/.akuity/config is used if it exists, and an empty profile otherwise.
+func loadCredentialsProfile(file, profile string) (credentialsProfile, error) {
+	explicit := file != "" || profile != ""
+	if file == "" {
+		file = defaultConfigFile
+	}
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	path, err := homedir.Expand(file)
+	if err != nil {
+		return credentialsProfile{}, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return credentialsProfile{}, nil
+	}
+	if err != nil {
+		return credentialsProfile{}, fmt.Errorf("unable to read %s: %w", file, err)
+	}
+
+	var config akuityConfigFile
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return credentialsProfile{}, fmt.Errorf("unable to parse %s: %w", file, err)
+	}
+	p, ok := config.Profiles[profile]
+	if !ok {
+		if !explicit {
+			return credentialsProfile{}, nil
+		}
+		return credentialsProfile{}, fmt.Errorf("profile %q not found in %s", profile, file)
+	}
+	if p.OrgName != "" && p.OrgID != "" {
+		return credentialsProfile{}, fmt.Errorf("profile %q sets both org_name and org_id", profile)
+	}
+	return p, nil
+}
+
+// runCredentialProcess runs command with the system shell and returns the
+// API key it prints.
+func runCredentialProcess(ctx context.Context, command string) (processCredentials, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return processCredentials{}, fmt.Errorf("%w: %s", err, msg)
+		}
+		return processCredentials{}, err
+	}
+
+	var creds processCredentials
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		// The output holds the secret, so it is left out of the error.
+		return processCredentials{}, fmt.Errorf("the output is not a JSON object with api_key_id and api_key_secret: %w", err)
+	}
+	if creds.APIKeyID == "" || creds.APIKeySecret == "" {
+		return processCredentials{}, fmt.Errorf("the output must set both api_key_id and api_key_secret")
+	}
+	return creds, nil
+}
@@ -0,0 +1,128 @@
+//go:build !acc
+
+package akp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigFile = `
+profiles:
+  default:
+    org_name: default-org
+    api_key_id: default-id
+    api_key_secret: default-secret
+  ci:
+    server_url: https://akuity.example.com
+    org_id: ci-org-id
+    credential_process: vault-akuity-credentials
+  both-orgs:
+    org_name: an-org
+    org_id: an-org-id
+`
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadCredentialsProfile(t *testing.T) {
+	configFile := writeTestFile(t, "config", testConfigFile)
+	invalidFile := writeTestFile(t, "invalid", "profiles:\n  default:\n    api_key: typo\n")
+	missingFile := filepath.Join(t.TempDir(), "missing")
+	// The default file must not leak into the test from the home directory.
+	t.Setenv("HOME", t.TempDir())
+	homedir.Reset()
+	t.Cleanup(homedir.Reset)
+
+	testCases := map[string]struct {
+		file        string
+		profile     string
+		expected    credentialsProfile
+		expectError bool
+	}{
+		"default profile": {
+			file:     configFile,
+			expected: credentialsProfile{OrgName: "default-org", APIKeyID: "default-id", APIKeySecret: "default-secret"},
+		},
+		"named profile": {
+			file:    configFile,
+			profile: "ci",
+			expected: credentialsProfile{
+				ServerURL:         "https://akuity.example.com",
+				OrgID:             "ci-org-id",
+				CredentialProcess: "vault-akuity-credentials",
+			},
+		},
+		"no default file":         {},
+		"missing profile":         {file: configFile, profile: "prod", expectError: true},
+		"missing file":            {file: missingFile, expectError: true},
+		"profile of default file": {profile: "ci", expectError: true},
+		"unknown field":           {file: invalidFile, expectError: true},
+		"both organizations":      {file: configFile, profile: "both-orgs", expectError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p, err := loadCredentialsProfile(tc.file, tc.profile)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, p)
+		})
+	}
+}
+
+func TestRunCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are POSIX shell")
+	}
+
+	testCases := map[string]struct {
+		command     string
+		expected    processCredentials
+		expectError string
+	}{
+		"credentials": {
+			command:  `echo '{"api_key_id": "id", "api_key_secret": "secret"}'`,
+			expected: processCredentials{APIKeyID: "id", APIKeySecret: "secret"},
+		},
+		"failure": {
+			command:     "echo 'vault is sealed' >&2; exit 2",
+			expectError: "vault is sealed",
+		},
+		"not json": {
+			command:     "echo secret",
+			expectError: "not a JSON object",
+		},
+		"missing secret": {
+			command:     `echo '{"api_key_id": "id"}'`,
+			expectError: "must set both",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			creds, err := runCredentialProcess(context.Background(), tc.command)
+			if tc.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, creds)
+		})
+	}
+}
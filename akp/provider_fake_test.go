@@ -0,0 +1,908 @@
+//go:build !acc
+
+package akp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mitchellh/go-homedir"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
+	kargov1 "github.com/akuity/api-client-go/pkg/api/gen/kargo/v1"
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
+	"github.com/akuity/terraform-provider-akp/akp/fake"
+)
+
+// fakeProviderConfig configures the provider for a fake.Server. The
+// credentials are required by the schema but never leave the process.
+const fakeProviderConfig = `
+provider "akp" {
+  org_name       = "fake-org"
+  api_key_id     = "fake-id"
+  api_key_secret = "fake-secret"
+}
+`
+
+func fakeProviderFactories(srv *fake.Server) map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"akp": providerserver.NewProtocol6WithError(NewWithGatewayClients("test", srv)()),
+	}
+}
+
+func TestFakeWorkspaceTeamLifecycle(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy:             checkFakeOrgEmpty(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fakeWorkspaceTeamConfig("initial", "member"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akp_workspace.test", "id"),
+					resource.TestCheckResourceAttr("akp_workspace.test", "description", "initial"),
+					resource.TestCheckResourceAttr("akp_workspace.test", "is_default", "false"),
+					resource.TestCheckResourceAttrPair("akp_workspace_member.test", "workspace_id", "akp_workspace.test", "id"),
+					resource.TestCheckResourceAttr("akp_workspace_member.test", "role", "member"),
+				),
+			},
+			{
+				ResourceName:      "akp_workspace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:                         "akp_team.test",
+				ImportState:                          true,
+				ImportStateId:                        "fake-team",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				// The team's member is added after the team itself, so the
+				// count in state depends on when it was last refreshed.
+				ImportStateVerifyIgnore: []string{"member_count"},
+			},
+			{
+				ResourceName:      "akp_workspace_member.test",
+				ImportState:       true,
+				ImportStateIdFunc: fakeWorkspaceMemberImportID,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fakeProviderConfig + fakeWorkspaceTeamConfig("updated", "admin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akp_workspace.test", "description", "updated"),
+					resource.TestCheckResourceAttr("akp_team.test", "description", "updated"),
+					resource.TestCheckResourceAttr("akp_workspace_member.test", "role", "admin"),
+				),
+			},
+		},
+	})
+}
+
+func TestFakeProviderOrgID(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy:             checkFakeOrgEmpty(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "akp" {
+  org_id         = %q
+  api_key_id     = "fake-id"
+  api_key_secret = "fake-secret"
+}
+
+resource "akp_workspace" "test" {
+  name = "fake-workspace"
+}
+`, srv.OrganizationID()),
+				Check: resource.TestCheckResourceAttrSet("akp_workspace.test", "id"),
+			},
+		},
+	})
+}
+
+func TestFakeProviderOrgLookup(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+	const workspace = `
+resource "akp_workspace" "test" {
+  name = "fake-workspace"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		Steps: []resource.TestStep{
+			{
+				// The organization is only looked up once a resource calls the API.
+				Config: `
+provider "akp" {
+  org_name       = "missing-org"
+  api_key_id     = "fake-id"
+  api_key_secret = "fake-secret"
+}
+` + workspace,
+				ExpectError: regexp.MustCompile(`Unable to Look Up Akuity Platform Organization`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "akp" {
+  org_name       = "fake-org"
+  org_id         = %q
+  api_key_id     = "fake-id"
+  api_key_secret = "fake-secret"
+}
+`, srv.OrganizationID()) + workspace,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestFakeProviderProfile(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+	configFile := writeTestFile(t, "config", `
+profiles:
+  ci:
+    org_name: fake-org
+    credential_process: echo '{"api_key_id": "fake-id", "api_key_secret": "fake-secret"}'
+`)
+	t.Setenv("HOME", t.TempDir())
+	homedir.Reset()
+	t.Cleanup(homedir.Reset)
+	const workspace = `
+resource "akp_workspace" "test" {
+  name = "fake-workspace"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy:             checkFakeOrgEmpty(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "akp" {
+  api_key_id     = "fake-id"
+  api_key_secret = "fake-secret"
+}
+` + workspace,
+				ExpectError: regexp.MustCompile(`Missing Akuity Platform Organization`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "akp" {
+  config_file = %q
+  profile     = "ci"
+}
+`, configFile) + workspace,
+				Check: resource.TestCheckResourceAttrSet("akp_workspace.test", "id"),
+			},
+		},
+	})
+}
+
+func TestFakeProviderInvalidTLS(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "akp" {
+  org_name       = "fake-org"
+  api_key_id     = "fake-id"
+  api_key_secret = "fake-secret"
+  ca_cert_pem    = "not a certificate"
+}
+
+resource "akp_workspace" "test" {
+  name = "fake-workspace"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Akuity Platform API TLS Configuration`),
+			},
+		},
+	})
+}
+
+func TestFakeTeamMembers(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy:             checkFakeOrgEmpty(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fakeTeamMembersConfig(`"alice@example.com", "bob@example.com"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akp_team_member.test", "id"),
+					resource.TestCheckResourceAttr("akp_team_member.test", "email", "Carol@example.com"),
+					resource.TestCheckResourceAttr("akp_team_members.test", "id", "fake-team-all"),
+					resource.TestCheckResourceAttr("akp_team_members.test", "emails.#", "2"),
+				),
+			},
+			{
+				ResourceName:                         "akp_team_member.test",
+				ImportState:                          true,
+				ImportStateId:                        "fake-team/Carol@example.com",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "email",
+			},
+			{
+				ResourceName:      "akp_team_members.test",
+				ImportState:       true,
+				ImportStateId:     "fake-team-all",
+				ImportStateVerify: true,
+			},
+			{
+				// Members removed in the UI show up as drift for both resources.
+				PreConfig: func() {
+					removeFakeTeamMember(t, srv, "fake-team", "carol@example.com")
+					removeFakeTeamMember(t, srv, "fake-team-all", "bob@example.com")
+				},
+				Config:             fakeProviderConfig + fakeTeamMembersConfig(`"alice@example.com", "bob@example.com"`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: fakeProviderConfig + fakeTeamMembersConfig(`"alice@example.com", "dave@example.com"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akp_team_member.test", "email", "Carol@example.com"),
+					resource.TestCheckTypeSetElemAttr("akp_team_members.test", "emails.*", "alice@example.com"),
+					resource.TestCheckTypeSetElemAttr("akp_team_members.test", "emails.*", "dave@example.com"),
+					resource.TestCheckResourceAttr("akp_team_members.test", "emails.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func fakeTeamMembersConfig(emails string) string {
+	return fmt.Sprintf(`
+resource "akp_team" "test" {
+  name = "fake-team"
+}
+
+resource "akp_team_member" "test" {
+  team_name = akp_team.test.name
+  email     = "Carol@example.com"
+}
+
+resource "akp_team" "all" {
+  name = "fake-team-all"
+}
+
+resource "akp_team_members" "test" {
+  team_name = akp_team.all.name
+  emails    = [%s]
+}
+`, emails)
+}
+
+func removeFakeTeamMember(t *testing.T, srv *fake.Server, teamName, email string) {
+	t.Helper()
+	ctx := context.Background()
+	resp, err := srv.Organization().ListTeamMembers(ctx, &orgcv1.ListTeamMembersRequest{OrganizationId: srv.OrganizationID(), TeamName: teamName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range resp.GetTeamMembers() {
+		if m.GetEmail() == email {
+			if _, err := srv.Organization().RemoveTeamMember(ctx, &orgcv1.RemoveTeamMemberRequest{OrganizationId: srv.OrganizationID(), TeamName: teamName, Id: m.GetId()}); err != nil {
+				t.Fatal(err)
+			}
+			return
+		}
+	}
+	t.Fatalf("%s is not a member of team %s", email, teamName)
+}
+
+func TestFakeWorkspaceMembers(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy:             checkFakeOrgEmpty(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fakeWorkspaceMembersConfig(`
+    { user_email = "Alice@example.com", role = "admin" },
+    { team_name = akp_team.test.name, role = "member" },`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("akp_workspace_members.test", "id", "akp_workspace.test", "id"),
+					resource.TestCheckResourceAttr("akp_workspace_members.test", "members.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("akp_workspace_members.test", "members.*", map[string]string{
+						"user_email": "Alice@example.com",
+						"role":       "admin",
+					}),
+				),
+			},
+			{
+				ResourceName:                         "akp_workspace_members.test",
+				ImportState:                          true,
+				ImportStateId:                        "fake-workspace",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "workspace",
+				// The backend lowercases emails and import has no config to
+				// preserve the original casing from.
+				ImportStateVerifyIgnore: []string{"members"},
+			},
+			{
+				// A member added in the UI shows up as drift.
+				PreConfig: func() {
+					addFakeWorkspaceMember(t, srv, "fake-workspace", "bob@example.com")
+				},
+				Config: fakeProviderConfig + fakeWorkspaceMembersConfig(`
+    { user_email = "Alice@example.com", role = "admin" },
+    { team_name = akp_team.test.name, role = "member" },`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: fakeProviderConfig + fakeWorkspaceMembersConfig(`
+    { user_email = "Alice@example.com", role = "member" },`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("akp_workspace_members.test", "members.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("akp_workspace_members.test", "members.*", map[string]string{
+						"user_email": "Alice@example.com",
+						"role":       "member",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func fakeWorkspaceMembersConfig(members string) string {
+	return fmt.Sprintf(`
+resource "akp_workspace" "test" {
+  name = "fake-workspace"
+}
+
+resource "akp_team" "test" {
+  name = "fake-team"
+}
+
+resource "akp_workspace_members" "test" {
+  workspace = akp_workspace.test.name
+  members = [%s
+  ]
+}
+`, members)
+}
+
+func addFakeWorkspaceMember(t *testing.T, srv *fake.Server, workspaceName, email string) {
+	t.Helper()
+	ctx := context.Background()
+	resp, err := srv.Organization().ListWorkspaces(ctx, &orgcv1.ListWorkspacesRequest{OrganizationId: srv.OrganizationID()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range resp.GetWorkspaces() {
+		if w.GetName() != workspaceName {
+			continue
+		}
+		_, err := srv.Organization().AddWorkspaceMember(ctx, &orgcv1.AddWorkspaceMemberRequest{
+			OrganizationId: srv.OrganizationID(),
+			WorkspaceId:    w.GetId(),
+			MemberRef: &orgcv1.WorkspaceMemberRef{
+				Role:   orgcv1.WorkspaceMemberRole_WORKSPACE_MEMBER_ROLE_MEMBER,
+				Member: &orgcv1.WorkspaceMemberRef_UserEmail{UserEmail: email},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	t.Fatalf("workspace %s not found", workspaceName)
+}
+
+func TestFakeAccessDataSources(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy:             checkFakeOrgEmpty(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fakeAccessDataSourcesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akp_workspace.default", "is_default", "true"),
+					resource.TestCheckResourceAttrPair("data.akp_workspace.test", "id", "akp_workspace.test", "id"),
+					resource.TestCheckResourceAttr("data.akp_workspaces.test", "workspaces.#", "1"),
+					resource.TestCheckResourceAttr("data.akp_workspaces.test", "workspaces.0.name", "fake-workspace"),
+					resource.TestCheckResourceAttr("data.akp_team.test", "description", "platform team"),
+					resource.TestCheckResourceAttr("data.akp_teams.test", "teams.#", "1"),
+					resource.TestCheckResourceAttrPair("data.akp_custom_role.test", "id", "akp_custom_role.test", "id"),
+					resource.TestCheckResourceAttrPair("data.akp_custom_role.test", "policy", "akp_custom_role.test", "policy"),
+					resource.TestCheckResourceAttr("data.akp_custom_roles.test", "custom_roles.#", "1"),
+					resource.TestCheckResourceAttrPair("data.akp_custom_roles.test", "id", "akp_workspace.test", "id"),
+					resource.TestCheckResourceAttr("data.akp_api_keys.test", "api_keys.#", "1"),
+					resource.TestCheckResourceAttrPair("data.akp_api_keys.test", "api_keys.0.id", "akp_api_key.test", "id"),
+					resource.TestCheckResourceAttrPair("data.akp_api_keys.test", "api_keys.0.expire_time", "akp_api_key.test", "expire_time"),
+					resource.TestCheckResourceAttr("data.akp_api_keys.test", "api_keys.0.permissions.roles.0", "admin"),
+					resource.TestCheckResourceAttr("data.akp_api_keys.test", "api_keys.0.secret", ""),
+				),
+			},
+		},
+	})
+}
+
+const fakeAccessDataSourcesConfig = `
+resource "akp_workspace" "test" {
+  name = "fake-workspace"
+}
+
+resource "akp_team" "test" {
+  name        = "fake-team"
+  description = "platform team"
+}
+
+resource "akp_custom_role" "test" {
+  workspace = akp_workspace.test.name
+  name      = "instance-viewer"
+  policy    = "p, role:instance-viewer, workspace/instances, get, *"
+}
+
+resource "akp_api_key" "test" {
+  workspace          = akp_workspace.test.name
+  description        = "ci key"
+  expire_in_duration = "30d"
+  permissions = {
+    roles = ["admin"]
+  }
+}
+
+data "akp_workspace" "default" {}
+
+data "akp_workspace" "test" {
+  name       = "fake-workspace"
+  depends_on = [akp_workspace.test]
+}
+
+data "akp_workspaces" "test" {
+  name_regex = "^fake-"
+  depends_on = [akp_workspace.test]
+}
+
+data "akp_team" "test" {
+  name       = "fake-team"
+  depends_on = [akp_team.test]
+}
+
+data "akp_teams" "test" {
+  name_regex = "team$"
+  depends_on = [akp_team.test]
+}
+
+data "akp_custom_role" "test" {
+  workspace  = akp_workspace.test.name
+  name       = "instance-viewer"
+  depends_on = [akp_custom_role.test]
+}
+
+data "akp_custom_roles" "test" {
+  workspace  = akp_workspace.test.name
+  name_regex = "viewer"
+  depends_on = [akp_custom_role.test]
+}
+
+data "akp_api_keys" "test" {
+  workspace         = akp_workspace.test.name
+  description_regex = "^ci"
+  depends_on        = [akp_api_key.test]
+}
+`
+
+func TestFakeInstancesDataSources(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+	for _, name := range []string{"team-a", "team-b", "shared"} {
+		applyFakeInstance(t, srv, name, "v3.0.0")
+	}
+	applyFakeKargoInstance(t, srv, "team-kargo", "v1.5.0")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + `
+data "akp_instances" "all" {}
+
+data "akp_instances" "teams" {
+  workspace  = "default"
+  name_regex = "^team-"
+  health     = "healthy"
+}
+
+data "akp_kargo_instances" "all" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.akp_instances.all", "instances.#", "3"),
+					resource.TestCheckResourceAttr("data.akp_instances.teams", "instances.#", "2"),
+					resource.TestCheckResourceAttr("data.akp_instances.teams", "instances.0.name", "team-a"),
+					resource.TestCheckResourceAttr("data.akp_instances.teams", "instances.0.workspace", "default"),
+					resource.TestCheckResourceAttr("data.akp_instances.teams", "instances.0.version", "v3.0.0"),
+					resource.TestCheckResourceAttr("data.akp_instances.teams", "instances.0.health", "healthy"),
+					resource.TestCheckResourceAttr("data.akp_kargo_instances.all", "instances.#", "1"),
+					resource.TestCheckResourceAttr("data.akp_kargo_instances.all", "instances.0.version", "v1.5.0"),
+				),
+			},
+		},
+	})
+}
+
+func fakeWorkspaceTeamConfig(description, role string) string {
+	return fmt.Sprintf(`
+resource "akp_workspace" "test" {
+  name        = "fake-workspace"
+  description = %[1]q
+}
+
+resource "akp_team" "test" {
+  name        = "fake-team"
+  description = %[1]q
+}
+
+resource "akp_workspace_member" "test" {
+  workspace = akp_workspace.test.name
+  team_name = akp_team.test.name
+  role      = %[2]q
+}
+`, description, role)
+}
+
+func fakeWorkspaceMemberImportID(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["akp_workspace_member.test"]
+	if !ok {
+		return "", fmt.Errorf("not found: akp_workspace_member.test")
+	}
+	return rs.Primary.Attributes["workspace"] + "/" + rs.Primary.ID, nil
+}
+
+func checkFakeOrgEmpty(srv *fake.Server) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		ctx := context.Background()
+		resp, err := srv.Organization().ListWorkspaces(ctx, &orgcv1.ListWorkspacesRequest{OrganizationId: srv.OrganizationID()})
+		if err != nil {
+			return err
+		}
+		if n := len(resp.GetWorkspaces()); n != 1 {
+			return fmt.Errorf("expected only the default workspace to remain, got %d workspaces", n)
+		}
+		if _, err := srv.Organization().GetTeam(ctx, &orgcv1.GetTeamRequest{OrganizationId: srv.OrganizationID(), Name: "fake-team"}); err == nil {
+			return fmt.Errorf("team fake-team still exists")
+		}
+		return nil
+	}
+}
+
+func TestFakeInstanceLifecycle(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+	srv.ConvergeAfter(1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy: checkFakeDestroyed("instance fake-instance", func(ctx context.Context) bool {
+			_, err := srv.ArgoCD().GetInstance(ctx, &argocdv1.GetInstanceRequest{OrganizationId: srv.OrganizationID(), IdType: idv1.Type_NAME, Id: "fake-instance"})
+			return err == nil
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fakeInstanceConfig("initial"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akp_instance.test", "id"),
+					resource.TestCheckResourceAttr("akp_instance.test", "argocd.spec.version", "v3.0.0"),
+					resource.TestCheckResourceAttr("akp_instance.test", "argocd.spec.description", "initial"),
+					resource.TestCheckResourceAttr("akp_instance.test", "argocd.spec.instance_spec.declarative_management_enabled", "true"),
+				),
+			},
+			{
+				ResourceName:  "akp_instance.test",
+				ImportState:   true,
+				ImportStateId: "fake-instance",
+				ImportStateCheck: checkFakeImportedAttrs(map[string]string{
+					"name":                    "fake-instance",
+					"argocd.spec.version":     "v3.0.0",
+					"argocd.spec.description": "initial",
+					"argocd.spec.instance_spec.declarative_management_enabled": "true",
+				}),
+			},
+			{
+				Config: fakeProviderConfig + fakeInstanceConfig("updated"),
+				Check:  resource.TestCheckResourceAttr("akp_instance.test", "argocd.spec.description", "updated"),
+			},
+		},
+	})
+}
+
+func fakeInstanceConfig(description string) string {
+	return fmt.Sprintf(`
+resource "akp_instance" "test" {
+  name = "fake-instance"
+  argocd = {
+    spec = {
+      version     = "v3.0.0"
+      description = %q
+      instance_spec = {
+        declarative_management_enabled = true
+      }
+    }
+  }
+}
+`, description)
+}
+
+func TestFakeClusterLifecycle(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+	instanceID := applyFakeInstance(t, srv, "fake-instance", "v3.0.0")
+	srv.ConvergeAfter(1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy: checkFakeDestroyed("cluster fake-cluster", func(ctx context.Context) bool {
+			_, err := srv.ArgoCD().GetInstanceCluster(ctx, &argocdv1.GetInstanceClusterRequest{OrganizationId: srv.OrganizationID(), InstanceId: instanceID, IdType: idv1.Type_NAME, Id: "fake-cluster"})
+			return err == nil
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fakeClusterConfig(instanceID, "initial"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akp_cluster.test", "id"),
+					resource.TestCheckResourceAttr("akp_cluster.test", "namespace", "akuity"),
+					resource.TestCheckResourceAttr("akp_cluster.test", "spec.description", "initial"),
+					resource.TestCheckResourceAttr("akp_cluster.test", "spec.data.size", "small"),
+				),
+			},
+			{
+				ResourceName:  "akp_cluster.test",
+				ImportState:   true,
+				ImportStateId: instanceID + "/fake-cluster",
+				ImportStateCheck: checkFakeImportedAttrs(map[string]string{
+					"instance_id":      instanceID,
+					"name":             "fake-cluster",
+					"namespace":        "akuity",
+					"spec.description": "initial",
+					"spec.data.size":   "small",
+				}),
+			},
+			{
+				Config: fakeProviderConfig + fakeClusterConfig(instanceID, "updated"),
+				Check:  resource.TestCheckResourceAttr("akp_cluster.test", "spec.description", "updated"),
+			},
+		},
+	})
+}
+
+func fakeClusterConfig(instanceID, description string) string {
+	return fmt.Sprintf(`
+resource "akp_cluster" "test" {
+  instance_id = %q
+  name        = "fake-cluster"
+  namespace   = "akuity"
+  spec = {
+    description = %q
+    data = {
+      size                  = "small"
+      auto_upgrade_disabled = true
+    }
+  }
+}
+`, instanceID, description)
+}
+
+func TestFakeKargoInstanceLifecycle(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+	srv.ConvergeAfter(1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy: checkFakeDestroyed("Kargo instance fake-kargo", func(ctx context.Context) bool {
+			_, err := srv.Kargo().GetKargoInstance(ctx, &kargov1.GetKargoInstanceRequest{OrganizationId: srv.OrganizationID(), Name: "fake-kargo"})
+			return err == nil
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fakeKargoInstanceConfig("initial"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akp_kargo_instance.test", "id"),
+					resource.TestCheckResourceAttr("akp_kargo_instance.test", "kargo.spec.version", "v1.5.0"),
+					resource.TestCheckResourceAttr("akp_kargo_instance.test", "kargo.spec.description", "initial"),
+					resource.TestCheckResourceAttr("akp_kargo_instance.test", "kargo.spec.kargo_instance_spec.backend_ip_allow_list_enabled", "true"),
+				),
+			},
+			{
+				ResourceName:  "akp_kargo_instance.test",
+				ImportState:   true,
+				ImportStateId: "fake-kargo",
+				ImportStateCheck: checkFakeImportedAttrs(map[string]string{
+					"name":                   "fake-kargo",
+					"kargo.spec.version":     "v1.5.0",
+					"kargo.spec.description": "initial",
+					"kargo.spec.kargo_instance_spec.backend_ip_allow_list_enabled": "true",
+				}),
+			},
+			{
+				Config: fakeProviderConfig + fakeKargoInstanceConfig("updated"),
+				Check:  resource.TestCheckResourceAttr("akp_kargo_instance.test", "kargo.spec.description", "updated"),
+			},
+		},
+	})
+}
+
+func fakeKargoInstanceConfig(description string) string {
+	return fmt.Sprintf(`
+resource "akp_kargo_instance" "test" {
+  name = "fake-kargo"
+  kargo = {
+    spec = {
+      version     = "v1.5.0"
+      description = %q
+      kargo_instance_spec = {
+        backend_ip_allow_list_enabled = true
+      }
+    }
+  }
+}
+`, description)
+}
+
+func TestFakeKargoAgentLifecycle(t *testing.T) {
+	srv := fake.NewServer("fake-org")
+	instanceID := applyFakeKargoInstance(t, srv, "fake-kargo", "v1.5.0")
+	// The default shard agent cannot be destroyed, so keep the agent under
+	// test from becoming it.
+	if _, err := srv.Kargo().PatchKargoInstance(context.Background(), &kargov1.PatchKargoInstanceRequest{
+		OrganizationId: srv.OrganizationID(),
+		Id:             instanceID,
+		Patch:          mustFakeStruct(t, map[string]any{"spec": map[string]any{"defaultShardAgent": "shard-agent"}}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	srv.ConvergeAfter(1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: fakeProviderFactories(srv),
+		CheckDestroy: checkFakeDestroyed("Kargo agent fake-agent", func(ctx context.Context) bool {
+			resp, err := srv.Kargo().ListKargoInstanceAgents(ctx, &kargov1.ListKargoInstanceAgentsRequest{OrganizationId: srv.OrganizationID(), InstanceId: instanceID})
+			return err == nil && len(resp.GetAgents()) > 0
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fakeKargoAgentConfig(instanceID, "initial"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akp_kargo_agent.test", "id"),
+					resource.TestCheckResourceAttr("akp_kargo_agent.test", "workspace", "default"),
+					resource.TestCheckResourceAttr("akp_kargo_agent.test", "spec.description", "initial"),
+					resource.TestCheckResourceAttr("akp_kargo_agent.test", "spec.data.size", "small"),
+				),
+			},
+			{
+				ResourceName:  "akp_kargo_agent.test",
+				ImportState:   true,
+				ImportStateId: instanceID + "/fake-agent",
+				ImportStateCheck: checkFakeImportedAttrs(map[string]string{
+					"instance_id":      instanceID,
+					"name":             "fake-agent",
+					"namespace":        "akuity",
+					"workspace":        "default",
+					"spec.description": "initial",
+					"spec.data.size":   "small",
+				}),
+			},
+			{
+				Config: fakeProviderConfig + fakeKargoAgentConfig(instanceID, "updated"),
+				Check:  resource.TestCheckResourceAttr("akp_kargo_agent.test", "spec.description", "updated"),
+			},
+		},
+	})
+}
+
+func fakeKargoAgentConfig(instanceID, description string) string {
+	return fmt.Sprintf(`
+resource "akp_kargo_agent" "test" {
+  instance_id = %q
+  name        = "fake-agent"
+  namespace   = "akuity"
+  spec = {
+    description = %q
+    data = {
+      size                  = "small"
+      auto_upgrade_disabled = true
+    }
+  }
+}
+`, instanceID, description)
+}
+
+func mustFakeStruct(t *testing.T, m map[string]any) *structpb.Struct {
+	t.Helper()
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// applyFakeInstance creates an Argo CD instance directly on the server and
+// returns its ID.
+func applyFakeInstance(t *testing.T, srv *fake.Server, name, version string) string {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := srv.ArgoCD().ApplyInstance(ctx, &argocdv1.ApplyInstanceRequest{
+		OrganizationId: srv.OrganizationID(),
+		IdType:         idv1.Type_NAME,
+		Id:             name,
+		Argocd:         mustFakeStruct(t, map[string]any{"spec": map[string]any{"version": version}}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.ArgoCD().GetInstance(ctx, &argocdv1.GetInstanceRequest{OrganizationId: srv.OrganizationID(), IdType: idv1.Type_NAME, Id: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.GetInstance().GetId()
+}
+
+// applyFakeKargoInstance creates a Kargo instance directly on the server and
+// returns its ID.
+func applyFakeKargoInstance(t *testing.T, srv *fake.Server, name, version string) string {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := srv.Kargo().ApplyKargoInstance(ctx, &kargov1.ApplyKargoInstanceRequest{
+		OrganizationId: srv.OrganizationID(),
+		IdType:         idv1.Type_NAME,
+		Id:             name,
+		Kargo:          mustFakeStruct(t, map[string]any{"spec": map[string]any{"version": version}}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Kargo().GetKargoInstance(ctx, &kargov1.GetKargoInstanceRequest{OrganizationId: srv.OrganizationID(), Name: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.GetInstance().GetId()
+}
+
+// checkFakeImportedAttrs checks the attributes of the single imported resource.
+// The import has no configuration to fill in the attributes the API does not
+// return, so only those it reads back are compared.
+func checkFakeImportedAttrs(expected map[string]string) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+		}
+		if states[0].ID == "" {
+			return fmt.Errorf("imported resource has no ID")
+		}
+		for k, v := range expected {
+			if got := states[0].Attributes[k]; got != v {
+				return fmt.Errorf("imported %s: expected %q, got %q", k, v, got)
+			}
+		}
+		return nil
+	}
+}
+
+// checkFakeDestroyed fails if exists still finds the object on the server.
+func checkFakeDestroyed(object string, exists func(context.Context) bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if exists(context.Background()) {
+			return fmt.Errorf("%s still exists", object)
+		}
+		return nil
+	}
+}
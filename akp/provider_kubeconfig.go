@@ -0,0 +1,83 @@
+package akp
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+
+	tfakptypes "github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// kubeconfigFor returns the kube_config used to install the agent of a
+// resource: its own kube_config block with unset attributes taken from the
+// provider's kube_config. It returns nil when neither is set.
+func (c *AkpCli) kubeconfigFor(kubeConfig *tfakptypes.Kubeconfig) *tfakptypes.Kubeconfig {
+	if c == nil {
+		return kubeConfig
+	}
+	return kubeConfig.WithDefaults(c.Kubeconfig)
+}
+
+// getProviderKubeconfigAttributes returns the attributes of the kube_config
+// block of resources for the provider schema, which has no plan modifiers.
+func getProviderKubeconfigAttributes() map[string]schema.Attribute {
+	return providerAttributes(getKubeconfigAttributes())
+}
+
+// providerAttributes converts resource schema attributes to provider schema
+// ones, dropping their plan modifiers. It only supports the attribute types
+// that kube_config uses.
+func providerAttributes(attrs map[string]resourceschema.Attribute) map[string]schema.Attribute {
+	result := make(map[string]schema.Attribute, len(attrs))
+	for name, attr := range attrs {
+		switch a := attr.(type) {
+		case resourceschema.StringAttribute:
+			result[name] = schema.StringAttribute{
+				Required:    a.Required,
+				Optional:    a.Optional,
+				Sensitive:   a.Sensitive,
+				Description: a.Description,
+				Validators:  a.Validators,
+			}
+		case resourceschema.BoolAttribute:
+			result[name] = schema.BoolAttribute{
+				Required:    a.Required,
+				Optional:    a.Optional,
+				Sensitive:   a.Sensitive,
+				Description: a.Description,
+				Validators:  a.Validators,
+			}
+		case resourceschema.ListAttribute:
+			result[name] = schema.ListAttribute{
+				ElementType: a.ElementType,
+				Required:    a.Required,
+				Optional:    a.Optional,
+				Sensitive:   a.Sensitive,
+				Description: a.Description,
+				Validators:  a.Validators,
+			}
+		case resourceschema.MapAttribute:
+			result[name] = schema.MapAttribute{
+				ElementType: a.ElementType,
+				Required:    a.Required,
+				Optional:    a.Optional,
+				Sensitive:   a.Sensitive,
+				Description: a.Description,
+				Validators:  a.Validators,
+			}
+		case resourceschema.SingleNestedAttribute:
+			result[name] = schema.SingleNestedAttribute{
+				Attributes:  providerAttributes(a.Attributes),
+				Required:    a.Required,
+				Optional:    a.Optional,
+				Sensitive:   a.Sensitive,
+				Description: a.Description,
+				Validators:  a.Validators,
+			}
+		default:
+			panic(fmt.Sprintf("attribute %s has unsupported type %T", name, attr))
+		}
+	}
+	return result
+}
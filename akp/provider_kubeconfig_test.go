@@ -0,0 +1,28 @@
+//go:build !acc
+
+package akp
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderKubeconfigAttributes(t *testing.T) {
+	resourceAttrs := getKubeconfigAttributes()
+	providerAttrs := getProviderKubeconfigAttributes()
+
+	require.Len(t, providerAttrs, len(resourceAttrs))
+	for name, attr := range resourceAttrs {
+		p, ok := providerAttrs[name]
+		require.True(t, ok, name)
+		assert.Equal(t, attr.IsOptional(), p.IsOptional(), name)
+		assert.Equal(t, attr.IsSensitive(), p.IsSensitive(), name)
+		assert.Equal(t, attr.GetDescription(), p.GetDescription(), name)
+	}
+	assert.Len(t, providerAttrs["token_file"].(schema.StringAttribute).Validators, 1)
+	exec := providerAttrs["exec"].(schema.SingleNestedAttribute)
+	assert.True(t, exec.Attributes["command"].IsRequired())
+}
@@ -0,0 +1,98 @@
+package akp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// gatewayTransportConfig holds the TLS and proxy settings of the connection
+// to the Akuity Platform API.
+type gatewayTransportConfig struct {
+	SkipTLSVerify bool
+	// CACertPEM and CACertFile add a CA bundle to the system roots, for
+	// self-hosted platforms behind an internal CA.
+	CACertPEM  string
+	CACertFile string
+	// ClientCert and ClientKey are the PEM-encoded client certificate that
+	// is presented to ingresses that require mutual TLS.
+	ClientCert string
+	ClientKey  string
+	// HTTPSProxy overrides the proxy taken from the HTTPS_PROXY environment
+	// variable.
+	HTTPSProxy string
+}
+
+// newGatewayTransportConfig returns the transport settings of the provider
+// configuration. Like the other provider attributes, the AKUITY_*
+// environment variables take precedence over the configuration.
+func newGatewayTransportConfig(config AkpProviderModel) gatewayTransportConfig {
+	envOr := func(env, value string) string {
+		if v := os.Getenv(env); v != "" {
+			return v
+		}
+		return value
+	}
+	return gatewayTransportConfig{
+		SkipTLSVerify: config.SkipTLSVerify.ValueBool(),
+		CACertPEM:     envOr("AKUITY_CA_CERT_PEM", config.CACertPEM.ValueString()),
+		CACertFile:    envOr("AKUITY_CA_CERT_FILE", config.CACertFile.ValueString()),
+		ClientCert:    envOr("AKUITY_CLIENT_CERT", config.ClientCert.ValueString()),
+		ClientKey:     envOr("AKUITY_CLIENT_KEY", config.ClientKey.ValueString()),
+		HTTPSProxy:    envOr("AKUITY_HTTPS_PROXY", config.HTTPSProxy.ValueString()),
+	}
+}
+
+// httpClient returns an HTTP client that connects with these settings.
+func (c gatewayTransportConfig) httpClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.SkipTLSVerify, //nolint:gosec // explicitly requested with skip_tls_verify
+	}
+
+	if c.CACertPEM != "" && c.CACertFile != "" {
+		return nil, fmt.Errorf("only one of ca_cert_pem and ca_cert_file can be set")
+	}
+	caCert := []byte(c.CACertPEM)
+	if c.CACertFile != "" {
+		var err error
+		if caCert, err = os.ReadFile(c.CACertFile); err != nil {
+			return nil, fmt.Errorf("unable to read ca_cert_file: %w", err)
+		}
+	}
+	if len(caCert) > 0 {
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("the CA bundle contains no PEM-encoded certificate")
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return nil, fmt.Errorf("client_cert and client_key must be set together")
+	}
+	if c.ClientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if c.HTTPSProxy != "" {
+		proxyURL, err := url.Parse(c.HTTPSProxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid https_proxy %q: must be a URL such as http://proxy.example.com:3128", c.HTTPSProxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,205 @@
+//go:build !acc
+
+package akp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCertificate is a certificate and its key, PEM-encoded.
+type testCertificate struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certPEM  string
+	keyPEM   string
+	keyPair  tls.Certificate
+	rootPool *x509.CertPool
+}
+
+// newTestCertificate returns a certificate signed by parent, or a
+// self-signed CA when parent is nil.
+func newTestCertificate(t *testing.T, parent *testCertificate, tmpl *x509.Certificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl.SerialNumber = big.NewInt(time.Now().UnixNano())
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	c := &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+	c.keyPair, err = tls.X509KeyPair([]byte(c.certPEM), []byte(c.keyPEM))
+	require.NoError(t, err)
+	c.rootPool = x509.NewCertPool()
+	c.rootPool.AddCert(cert)
+	return c
+}
+
+// newMutualTLSServer returns a server with a certificate signed by a private
+// CA, which requires a client certificate signed by the same CA.
+func newMutualTLSServer(t *testing.T) (srv *httptest.Server, ca, client *testCertificate) {
+	t.Helper()
+	ca = newTestCertificate(t, nil, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+	serverCert := newTestCertificate(t, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "127.0.0.1"},
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	client = newTestCertificate(t, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "terraform"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	srv = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert.keyPair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    ca.rootPool,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv, ca, client
+}
+
+func TestGatewayTransportConfigMutualTLS(t *testing.T) {
+	srv, ca, client := newMutualTLSServer(t)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte(ca.certPEM), 0o600))
+
+	testCases := map[string]struct {
+		config      gatewayTransportConfig
+		expectError bool
+	}{
+		"ca pem and client certificate": {
+			config: gatewayTransportConfig{CACertPEM: ca.certPEM, ClientCert: client.certPEM, ClientKey: client.keyPEM},
+		},
+		"ca file and client certificate": {
+			config: gatewayTransportConfig{CACertFile: caFile, ClientCert: client.certPEM, ClientKey: client.keyPEM},
+		},
+		"skip tls verify": {
+			config: gatewayTransportConfig{SkipTLSVerify: true, ClientCert: client.certPEM, ClientKey: client.keyPEM},
+		},
+		"untrusted server": {
+			config:      gatewayTransportConfig{ClientCert: client.certPEM, ClientKey: client.keyPEM},
+			expectError: true,
+		},
+		"no client certificate": {
+			config:      gatewayTransportConfig{CACertPEM: ca.certPEM},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			hc, err := tc.config.httpClient()
+			require.NoError(t, err)
+			resp, err := hc.Get(srv.URL)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestGatewayTransportConfigProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	hc, err := gatewayTransportConfig{HTTPSProxy: proxy.URL}.httpClient()
+	require.NoError(t, err)
+	resp, err := hc.Get("http://akuity.example.com/api/v1/orgs")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "http://akuity.example.com/api/v1/orgs", proxied)
+
+	transport := hc.Transport.(*http.Transport)
+	proxyURL, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "akuity.cloud"}})
+	require.NoError(t, err)
+	assert.Equal(t, proxy.URL, proxyURL.String())
+}
+
+func TestGatewayTransportConfigErrors(t *testing.T) {
+	_, ca, client := newMutualTLSServer(t)
+
+	testCases := map[string]gatewayTransportConfig{
+		"invalid ca pem":           {CACertPEM: "not a certificate"},
+		"missing ca file":          {CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"both ca pem and ca file":  {CACertPEM: ca.certPEM, CACertFile: "ca.pem"},
+		"client cert without key":  {ClientCert: client.certPEM},
+		"mismatched client key":    {ClientCert: client.certPEM, ClientKey: ca.keyPEM},
+		"proxy without scheme":     {HTTPSProxy: "proxy.example.com:3128"},
+		"proxy with invalid value": {HTTPSProxy: "http://%zz"},
+	}
+
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := config.httpClient()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewGatewayTransportConfig(t *testing.T) {
+	config := AkpProviderModel{
+		SkipTLSVerify: types.BoolValue(true),
+		CACertFile:    types.StringValue("/etc/akuity/ca.pem"),
+		HTTPSProxy:    types.StringValue("http://config-proxy:3128"),
+	}
+	t.Setenv("AKUITY_HTTPS_PROXY", "http://env-proxy:3128")
+	transport := newGatewayTransportConfig(config)
+	assert.Equal(t, gatewayTransportConfig{
+		SkipTLSVerify: true,
+		CACertFile:    "/etc/akuity/ca.pem",
+		HTTPSProxy:    "http://env-proxy:3128",
+	}, transport)
+}
@@ -0,0 +1,128 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// workspacesScope is the readCache scope of the organization's workspace
+	// list.
+	workspacesScope = "workspaces"
+	// readFetchTimeout bounds a fetch shared by the callers of cachedRead,
+	// which runs past the deadline of the caller that started it.
+	readFetchTimeout = 2 * time.Minute
+)
+
+// readCache holds the responses of read calls that many resources repeat
+// within one Terraform run, such as the GetInstance every akp_cluster makes
+// against its instance. The provider process lives for a single plan or apply,
+// so entries are only dropped when a write invalidates the scope they were
+// read from: an instance ID or name, or workspacesScope. Concurrent reads of
+// an entry that is not cached yet share a single request.
+type readCache struct {
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]map[string]proto.Message
+	// aliases links the ID and the name of an instance, so that a write that
+	// refers to the instance by one invalidates reads made by the other.
+	aliases map[string]string
+	// generation counts invalidations. A read that overlaps one is returned to
+	// its callers but not stored, as it may predate the write.
+	generation uint64
+}
+
+func newReadCache() *readCache {
+	return &readCache{
+		entries: map[string]map[string]proto.Message{},
+		aliases: map[string]string{},
+	}
+}
+
+// cachedRead returns the response of call in scope from the cache, or from
+// fetch if it is not cached yet. Callers get their own copy of the response.
+// A nil cache always calls fetch.
+func cachedRead[T proto.Message](ctx context.Context, c *readCache, scope, call string, fetch func(context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	if resp, ok := c.entries[scope][call]; ok {
+		c.mu.Unlock()
+		return proto.Clone(resp).(T), nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	// The request is shared by every caller that joins it, so it must not be
+	// cancelled with the caller that happened to start it. It has a deadline
+	// of its own instead, so that a hung request is not left running once
+	// every caller has given up on it.
+	flightCtx := context.WithoutCancel(ctx)
+	ch := c.group.DoChan(fmt.Sprintf("%d/%s/%s", generation, scope, call), func() (any, error) {
+		ctx, cancel := context.WithTimeout(flightCtx, readFetchTimeout)
+		defer cancel()
+		resp, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.store(generation, scope, call, resp)
+		return resp, nil
+	})
+
+	var zero T
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return proto.Clone(res.Val.(T)).(T), nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+func (c *readCache) store(generation uint64, scope, call string, resp proto.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation {
+		return
+	}
+	if c.entries[scope] == nil {
+		c.entries[scope] = map[string]proto.Message{}
+	}
+	c.entries[scope][call] = resp
+}
+
+// alias records that id and name refer to the same instance.
+func (c *readCache) alias(id, name string) {
+	if c == nil || id == "" || name == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.aliases[id] = name
+	c.aliases[name] = id
+}
+
+// invalidate drops the entries read from scope, and from its alias if it has
+// one.
+func (c *readCache) invalidate(scope string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, scope)
+	if alias, ok := c.aliases[scope]; ok {
+		delete(c.entries, alias)
+	}
+	c.generation++
+}
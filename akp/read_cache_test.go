@@ -0,0 +1,157 @@
+//go:build !acc
+
+package akp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type countingFetch struct {
+	calls atomic.Int32
+	value string
+	err   error
+}
+
+func (f *countingFetch) fetch(context.Context) (*wrapperspb.StringValue, error) {
+	f.calls.Add(1)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return wrapperspb.String(f.value), nil
+}
+
+func TestReadCacheServesRepeatedReads(t *testing.T) {
+	c := newReadCache()
+	f := &countingFetch{value: "v1"}
+
+	for range 3 {
+		resp, err := cachedRead(context.Background(), c, "inst-1", "GetInstance", f.fetch)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", resp.GetValue())
+		// Callers get their own copy, so mutating one must not leak into the cache.
+		resp.Value = "mutated"
+	}
+	assert.EqualValues(t, 1, f.calls.Load())
+}
+
+func TestReadCacheDeduplicatesConcurrentReads(t *testing.T) {
+	c := newReadCache()
+	var calls atomic.Int32
+	fetch := func(context.Context) (*wrapperspb.StringValue, error) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return wrapperspb.String("v1"), nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := cachedRead(context.Background(), c, "inst-1", "GetInstance", fetch)
+			assert.NoError(t, err)
+			assert.Equal(t, "v1", resp.GetValue())
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestReadCacheInvalidate(t *testing.T) {
+	c := newReadCache()
+	byName := &countingFetch{value: "by-name"}
+	byID := &countingFetch{value: "by-id"}
+	other := &countingFetch{value: "other"}
+
+	read := func(scope string, f *countingFetch) {
+		_, err := cachedRead(context.Background(), c, scope, "call", f.fetch)
+		require.NoError(t, err)
+	}
+	read("name-1", byName)
+	read("id-1", byID)
+	read("id-2", other)
+	c.alias("id-1", "name-1")
+
+	// A write by name drops the reads made by ID, and the other way around.
+	c.invalidate("name-1")
+	read("name-1", byName)
+	read("id-1", byID)
+	read("id-2", other)
+	assert.EqualValues(t, 2, byName.calls.Load())
+	assert.EqualValues(t, 2, byID.calls.Load())
+	assert.EqualValues(t, 1, other.calls.Load())
+}
+
+func TestReadCacheDropsReadOverlappingWrite(t *testing.T) {
+	c := newReadCache()
+	var calls atomic.Int32
+	fetch := func(context.Context) (*wrapperspb.StringValue, error) {
+		if calls.Add(1) == 1 {
+			c.invalidate("inst-1")
+		}
+		return wrapperspb.String("v1"), nil
+	}
+
+	for range 2 {
+		_, err := cachedRead(context.Background(), c, "inst-1", "GetInstance", fetch)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestReadCacheDoesNotCacheErrors(t *testing.T) {
+	c := newReadCache()
+	f := &countingFetch{err: errors.New("unavailable")}
+
+	for range 2 {
+		_, err := cachedRead(context.Background(), c, "inst-1", "GetInstance", f.fetch)
+		require.Error(t, err)
+	}
+	assert.EqualValues(t, 2, f.calls.Load())
+}
+
+func TestReadCacheNil(t *testing.T) {
+	var c *readCache
+	f := &countingFetch{value: "v1"}
+
+	for range 2 {
+		_, err := cachedRead(context.Background(), c, "inst-1", "GetInstance", f.fetch)
+		require.NoError(t, err)
+	}
+	c.invalidate("inst-1")
+	assert.EqualValues(t, 2, f.calls.Load())
+}
+
+func TestReadCacheFetchHasDeadline(t *testing.T) {
+	c := newReadCache()
+	ctx, cancel := context.WithCancel(context.Background())
+	var deadline time.Time
+	var fetchErr error
+	fetch := func(ctx context.Context) (*wrapperspb.StringValue, error) {
+		// The fetch outlives the caller that started it, but not its own deadline.
+		cancel()
+		deadline, _ = ctx.Deadline()
+		time.Sleep(10 * time.Millisecond)
+		fetchErr = ctx.Err()
+		return wrapperspb.String("v1"), nil
+	}
+
+	_, err := cachedRead(ctx, c, "inst-1", "GetInstance", fetch)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The shared fetch still completes and is cached for later callers.
+	resp, err := cachedRead(context.Background(), c, "inst-1", "GetInstance", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", resp.GetValue())
+	require.NoError(t, fetchErr)
+	assert.WithinDuration(t, time.Now().Add(readFetchTimeout), deadline, 5*time.Second)
+}
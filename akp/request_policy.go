@@ -0,0 +1,236 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// Defaults and limits of the provider's request attributes.
+const (
+	defaultMaxRetries    = 5
+	defaultRetryMaxDelay = 30 * time.Second
+	// minRequestsPerSecond is the lowest requests_per_second accepted.
+	minRequestsPerSecond = 0.01
+)
+
+// requestPolicy controls how API requests are retried and paced.
+type requestPolicy struct {
+	maxRetries int
+	maxDelay   time.Duration
+	// limiter paces requests to a steady rate. Requests are not paced when it
+	// is nil.
+	limiter *rate.Limiter
+	// slots bounds the requests in flight. Requests are not bounded when it is
+	// nil.
+	slots chan struct{}
+}
+
+var defaultRequestPolicy = newRequestPolicy(defaultMaxRetries, defaultRetryMaxDelay, 0, 0)
+
+type requestPolicyKey struct{}
+
+// newRequestPolicy returns a policy that retries a request up to maxRetries
+// times, waiting at most maxDelay between attempts. maxConcurrent and
+// requestsPerSecond are left unlimited when zero.
+func newRequestPolicy(maxRetries int, maxDelay time.Duration, maxConcurrent int, requestsPerSecond float64) *requestPolicy {
+	p := &requestPolicy{
+		maxRetries: maxRetries,
+		maxDelay:   maxDelay,
+	}
+	if maxConcurrent > 0 {
+		p.slots = make(chan struct{}, maxConcurrent)
+	}
+	if requestsPerSecond > 0 {
+		p.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Ceil(requestsPerSecond)))
+	}
+	return p
+}
+
+// buildRequestPolicy returns the request policy set by the provider
+// configuration. The schema validates the values; unknown ones are left at
+// their defaults.
+func buildRequestPolicy(config AkpProviderModel) *requestPolicy {
+	maxRetries := int64(defaultMaxRetries)
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+	maxDelay := defaultRetryMaxDelay
+	if d, err := time.ParseDuration(config.RetryMaxDelay.ValueString()); err == nil && d > 0 {
+		maxDelay = d
+	}
+	return newRequestPolicy(int(maxRetries), maxDelay, int(config.MaxConcurrentRequests.ValueInt64()), config.RequestsPerSecond.ValueFloat64())
+}
+
+// positiveDurationValidator checks that a string is a positive duration such
+// as "30s".
+type positiveDurationValidator struct{}
+
+func (positiveDurationValidator) Description(context.Context) string {
+	return "value must be a positive duration such as `30s` or `2m`"
+}
+
+func (v positiveDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (positiveDurationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	s := req.ConfigValue.ValueString()
+	if d, err := time.ParseDuration(s); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%s must be a positive duration such as \"30s\", got %q.", req.Path, s),
+		)
+	}
+}
+
+// withRequestPolicy returns ctx carrying p, for retryWithBackoff to follow.
+func withRequestPolicy(ctx context.Context, p *requestPolicy) context.Context {
+	if p == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, requestPolicyKey{}, p)
+}
+
+// requestPolicyFrom returns the policy ctx carries, or the default one.
+func requestPolicyFrom(ctx context.Context) *requestPolicy {
+	if p, ok := ctx.Value(requestPolicyKey{}).(*requestPolicy); ok {
+		return p
+	}
+	return defaultRequestPolicy
+}
+
+// paces reports whether the policy limits requests at all.
+func (p *requestPolicy) paces() bool {
+	return p.slots != nil || p.limiter != nil
+}
+
+// pacedTransport makes every request of the API client wait for its
+// provider's policy, whichever code path sends it. The policy is per
+// provider configuration, so aliased providers keep their own limits. It also
+// hands the Retry-After header of throttled responses to retryWithBackoff,
+// as the gateway client only returns their status code.
+type pacedTransport struct {
+	base   http.RoundTripper
+	policy *requestPolicy
+}
+
+func (t *pacedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.policy.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	// The request stops counting against max_concurrent_requests once the
+	// API has answered, so that a streamed response does not hold its slot.
+	defer release()
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if hint, ok := req.Context().Value(retryAfterKey{}).(*retryAfterHint); ok {
+			if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				hint.set(delay)
+			}
+		}
+	}
+	return resp, err
+}
+
+type retryAfterKey struct{}
+
+// retryAfterHint receives the Retry-After delay of the responses to one
+// attempt of a request.
+type retryAfterHint struct {
+	mu    sync.Mutex
+	delay time.Duration
+	ok    bool
+}
+
+// withRetryAfterHint returns ctx carrying a new hint for pacedTransport to
+// fill in.
+func withRetryAfterHint(ctx context.Context) (context.Context, *retryAfterHint) {
+	hint := &retryAfterHint{}
+	return context.WithValue(ctx, retryAfterKey{}, hint), hint
+}
+
+func (h *retryAfterHint) set(delay time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.delay, h.ok = delay, true
+}
+
+func (h *retryAfterHint) get() (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.delay, h.ok
+}
+
+// parseRetryAfter returns the delay of a Retry-After header value, which is
+// either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(min(seconds, math.MaxInt64/int64(time.Second))) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// acquire waits until the policy allows another request and returns the
+// function that ends it.
+func (p *requestPolicy) acquire(ctx context.Context) (func(), error) {
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if p.slots != nil {
+			<-p.slots
+		}
+	}
+	if p.limiter != nil {
+		if err := p.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// retryDelayHint returns the delay the API asked for before a request is
+// retried, if the error carries one.
+func retryDelayHint(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
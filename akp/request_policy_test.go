@@ -0,0 +1,354 @@
+//go:build !acc
+
+package akp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestRetryWithBackoffMaxRetries(t *testing.T) {
+	ctx := withRequestPolicy(context.Background(), newRequestPolicy(2, time.Millisecond, 0, 0))
+
+	var calls int
+	_, err := retryWithBackoff(ctx, func(context.Context) (any, error) {
+		calls++
+		return nil, status.Error(codes.Unavailable, "bad gateway")
+	}, "Test")
+
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryWithBackoffHonorsRetryDelayHint(t *testing.T) {
+	ctx := withRequestPolicy(context.Background(), newRequestPolicy(1, 200*time.Millisecond, 0, 0))
+
+	st, err := status.New(codes.ResourceExhausted, "rate limited").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(100 * time.Millisecond),
+	})
+	require.NoError(t, err)
+
+	var calls int
+	start := time.Now()
+	_, err = retryWithBackoff(ctx, func(context.Context) (any, error) {
+		calls++
+		if calls == 1 {
+			return nil, st.Err()
+		}
+		return nil, nil
+	}, "Test")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRetryDelayHint(t *testing.T) {
+	withHint, err := status.New(codes.ResourceExhausted, "rate limited").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(3 * time.Second),
+	})
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		err      error
+		expected time.Duration
+		ok       bool
+	}{
+		"retry info":      {err: withHint.Err(), expected: 3 * time.Second, ok: true},
+		"no details":      {err: status.Error(codes.ResourceExhausted, "rate limited")},
+		"non-grpc error":  {err: errors.New("plain error")},
+		"wrapped details": {err: fmt.Errorf("apply: %w", withHint.Err()), expected: 3 * time.Second, ok: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			delay, ok := retryDelayHint(tc.err)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, delay)
+		})
+	}
+}
+
+func TestRequestPolicyLimitsConcurrency(t *testing.T) {
+	p := newRequestPolicy(defaultMaxRetries, defaultRetryMaxDelay, 2, 0)
+
+	var inFlight, peak atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := p.acquire(context.Background())
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer release()
+			n := inFlight.Add(1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			inFlight.Add(-1)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 2, peak.Load())
+}
+
+func TestRequestPolicyLimitsRate(t *testing.T) {
+	p := newRequestPolicy(defaultMaxRetries, defaultRetryMaxDelay, 0, 20)
+
+	start := time.Now()
+	// The first 20 requests are the burst, the next 10 take half a second.
+	for range 30 {
+		release, err := p.acquire(context.Background())
+		require.NoError(t, err)
+		release()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
+
+func TestRequestPolicyAcquireCancelled(t *testing.T) {
+	p := newRequestPolicy(defaultMaxRetries, defaultRetryMaxDelay, 1, 0)
+	release, err := p.acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = p.acquire(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPacedTransport(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &pacedTransport{
+		base:   http.DefaultTransport,
+		policy: newRequestPolicy(defaultMaxRetries, defaultRetryMaxDelay, 2, 0),
+	}}
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 2, peak.Load())
+}
+
+func TestPacedTransportRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	// The gateway client turns a 429 into ResourceExhausted without details.
+	client := &http.Client{Transport: &pacedTransport{
+		base:   http.DefaultTransport,
+		policy: newRequestPolicy(defaultMaxRetries, defaultRetryMaxDelay, 0, 0),
+	}}
+	get := func(ctx context.Context) (any, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return nil, status.Error(codes.ResourceExhausted, "rate limited")
+		}
+		return nil, nil
+	}
+
+	start := time.Now()
+	_, err := retryWithBackoff(withRequestPolicy(context.Background(), newRequestPolicy(1, 5*time.Second, 0, 0)), get, "Test")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	// The delay is still capped by retry_max_delay.
+	calls.Store(0)
+	start = time.Now()
+	_, err = retryWithBackoff(withRequestPolicy(context.Background(), newRequestPolicy(1, 50*time.Millisecond, 0, 0)), get, "Test")
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	testCases := map[string]struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		"seconds":     {value: "120", expected: 2 * time.Minute, ok: true},
+		"http date":   {value: "Fri, 02 Jan 2026 15:04:35 GMT", expected: 30 * time.Second, ok: true},
+		"past date":   {value: "Fri, 02 Jan 2026 15:00:00 GMT", expected: 0, ok: true},
+		"empty":       {value: ""},
+		"negative":    {value: "-1"},
+		"not a delay": {value: "soon"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			delay, ok := parseRetryAfter(tc.value, now)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, delay)
+		})
+	}
+}
+
+func TestRequestPolicyFrom(t *testing.T) {
+	assert.Same(t, defaultRequestPolicy, requestPolicyFrom(context.Background()))
+	assert.Same(t, defaultRequestPolicy, requestPolicyFrom(withRequestPolicy(context.Background(), nil)))
+
+	p := newRequestPolicy(1, time.Second, 0, 0)
+	assert.Same(t, p, requestPolicyFrom(withRequestPolicy(context.Background(), p)))
+}
+
+func TestIsRetryableError(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"nil":                    {err: nil, expected: false},
+		"unavailable":            {err: status.Error(codes.Unavailable, "bad gateway"), expected: true},
+		"resource exhausted":     {err: status.Error(codes.ResourceExhausted, "rate limited"), expected: true},
+		"still provisioning":     {err: status.Error(codes.InvalidArgument, "instance is still being provisioned"), expected: true},
+		"invalid argument":       {err: status.Error(codes.InvalidArgument, "bad spec"), expected: false},
+		"not found":              {err: status.Error(codes.NotFound, "instance not found"), expected: false},
+		"connection refused":     {err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, expected: true},
+		"connection reset":       {err: fmt.Errorf("read: %w", syscall.ECONNRESET), expected: true},
+		"timeout":                {err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}, expected: true},
+		"temporary dns failure":  {err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}, expected: true},
+		"permanent dns failure":  {err: &net.DNSError{Err: "no such host", IsNotFound: true}, expected: false},
+		"plain error":            {err: errors.New("invalid configuration"), expected: false},
+		"timeout in the message": {err: errors.New("timeout must be positive"), expected: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isRetryableError(tc.err))
+		})
+	}
+}
+
+func TestBuildRequestPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		config        AkpProviderModel
+		maxRetries    int
+		maxDelay      time.Duration
+		maxConcurrent int
+		rateLimited   bool
+	}{
+		"defaults": {
+			config:     AkpProviderModel{},
+			maxRetries: defaultMaxRetries,
+			maxDelay:   defaultRetryMaxDelay,
+		},
+		"configured": {
+			config: AkpProviderModel{
+				MaxRetries:            types.Int64Value(0),
+				RetryMaxDelay:         types.StringValue("2m"),
+				MaxConcurrentRequests: types.Int64Value(8),
+				RequestsPerSecond:     types.Float64Value(2.5),
+			},
+			maxRetries:    0,
+			maxDelay:      2 * time.Minute,
+			maxConcurrent: 8,
+			rateLimited:   true,
+		},
+		"unknown": {
+			config: AkpProviderModel{
+				MaxRetries:            types.Int64Unknown(),
+				RetryMaxDelay:         types.StringUnknown(),
+				MaxConcurrentRequests: types.Int64Unknown(),
+				RequestsPerSecond:     types.Float64Unknown(),
+			},
+			maxRetries: defaultMaxRetries,
+			maxDelay:   defaultRetryMaxDelay,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := buildRequestPolicy(tc.config)
+			assert.Equal(t, tc.maxRetries, p.maxRetries)
+			assert.Equal(t, tc.maxDelay, p.maxDelay)
+			assert.Equal(t, tc.maxConcurrent, cap(p.slots))
+			assert.Equal(t, tc.rateLimited, p.limiter != nil)
+		})
+	}
+}
+
+func TestPositiveDurationValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"duration": {value: types.StringValue("30s")},
+		"null":     {value: types.StringNull()},
+		"unknown":  {value: types.StringUnknown()},
+		"invalid":  {value: types.StringValue("soon"), expectError: true},
+		"zero":     {value: types.StringValue("0s"), expectError: true},
+		"negative": {value: types.StringValue("-1m"), expectError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("retry_max_delay"), ConfigValue: tc.value}
+			resp := &validator.StringResponse{}
+			positiveDurationValidator{}.ValidateString(context.Background(), req, resp)
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
@@ -2,6 +2,8 @@ package akp
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -38,6 +40,7 @@ func NewAkpClusterResource() resource.Resource {
 		ReadFunc:       clusterRead,
 		UpdateFunc:     clusterUpdate,
 		DeleteFunc:     clusterDelete,
+		ModifyPlanFunc: planMetadataDefaults,
 		ImportStateFunc: func(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 			idParts := strings.Split(req.ID, "/")
 			if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
@@ -67,13 +70,26 @@ func NewAkpClusterResource() resource.Resource {
 }
 
 func clusterCreate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.Cluster) (*types.Cluster, error) {
-	result, err := clusterUpsert(ctx, cli, diags, plan, true)
+	timeout, d := plan.Timeouts.Create(ctx, defaultClusterTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil
+	}
+	// The timeout bounds the whole create, so every phase gets what is left
+	// of it.
+	upsertCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := clusterUpsert(upsertCtx, cli, diags, plan, true, timeout)
 	if err != nil {
 		diags.AddError("Client Error", err.Error())
-		tflog.Warn(ctx, fmt.Sprintf("refreshClusterState failed during create, cleaning up cluster %s", plan.Name.ValueString()))
-		cleanupErr := deleteCluster(ctx, cli, plan, plan.RemoveAgentResourcesOnDestroy.ValueBool(), true)
+		// Clean up the dangling cluster. The create deadline may be what
+		// failed it, so only the delete timeout bounds the cleanup.
+		tflog.Warn(ctx, fmt.Sprintf("Cluster create failed, cleaning up cluster %s", plan.Name.ValueString()))
+		cleanupErr := deleteCluster(context.WithoutCancel(ctx), cli, plan, plan.RemoveAgentResourcesOnDestroy.ValueBool(), true)
 		if cleanupErr != nil {
 			tflog.Error(ctx, fmt.Sprintf("Failed to clean up dangling cluster %s: %v", plan.Name.ValueString(), cleanupErr))
+			diags.AddError("Cleanup Error", fmt.Sprintf("Unable to clean up dangling cluster %s: %s", plan.Name.ValueString(), cleanupErr))
+			return nil, nil
 		}
 		tflog.Info(ctx, fmt.Sprintf("Successfully cleaned up dangling cluster %s", plan.Name.ValueString()))
 		return nil, nil // Return nil error - we already added the diagnostic
@@ -85,11 +101,26 @@ func clusterRead(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, data
 	if data.Spec == nil {
 		ctx = types.WithReadContext(ctx)
 	}
-	return refreshClusterState(ctx, diags, cli.Cli, data, cli.OrgId, data)
+	configuredLabels, configuredAnnotations := data.Labels, data.Annotations
+	if err := refreshClusterState(ctx, diags, cli.Cli, data, cli.OrgId, data); err != nil {
+		return err
+	}
+	cli.defaultMetadata.stripDefaults(ctx, diags, &data.Labels, &data.Annotations, configuredLabels, configuredAnnotations)
+	if cli.kubeconfigFor(data.Kubeconfig) != nil {
+		refreshAgentManifestHash(ctx, cli, diags, data)
+	}
+	return nil
 }
 
 func clusterUpdate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.Cluster) (*types.Cluster, error) {
-	result, err := clusterUpsert(ctx, cli, diags, plan, false)
+	timeout, d := plan.Timeouts.Update(ctx, defaultClusterTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := clusterUpsert(ctx, cli, diags, plan, false, timeout)
 	if err != nil {
 		diags.AddError("Client Error", err.Error())
 		return result, nil // Return nil error - we already added the diagnostic. Return result to commit partial state.
@@ -101,7 +132,7 @@ func clusterDelete(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, plan *
 	return deleteCluster(ctx, cli, plan, plan.RemoveAgentResourcesOnDestroy.ValueBool(), false)
 }
 
-func clusterUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.Cluster, isCreate bool) (*types.Cluster, error) {
+func clusterUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.Cluster, isCreate bool, timeout time.Duration) (*types.Cluster, error) {
 	validateClusterConfig(diagnostics, plan)
 	if diagnostics.HasError() {
 		return nil, nil
@@ -115,7 +146,7 @@ func clusterUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnosti
 			IdType:         idv1.Type_ID,
 		}
 		instResp, err := retryWithBackoff(ctx, func(ctx context.Context) (*argocdv1.GetInstanceResponse, error) {
-			return cli.Cli.GetInstance(ctx, instReq)
+			return cli.getInstance(ctx, instReq)
 		}, "GetInstance")
 		if err != nil {
 			return nil, errors.Wrap(err, "Unable to get Argo CD instance for capability check")
@@ -130,11 +161,23 @@ func clusterUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnosti
 		}
 	}
 
-	apiReq := buildClusterApplyRequest(ctx, diagnostics, plan, cli.OrgId)
+	apiReq := buildClusterApplyRequest(ctx, diagnostics, plan, cli.OrgId, cli.defaultMetadata)
 	if diagnostics.HasError() {
 		return nil, nil
 	}
-	result, err := applyCluster(ctx, cli, plan, apiReq, isCreate, cli.Cli.ApplyInstance, clusterUpsertKubeConfig, clusterWaitForReconciliation, clusterWaitForHealth)
+	// The refresh below overwrites the plan's labels and annotations with
+	// those read back, defaults included.
+	configuredLabels, configuredAnnotations := plan.Labels, plan.Annotations
+	upsertKubeConfig := func(ctx context.Context, cli *AkpCli, plan *types.Cluster) error {
+		return clusterUpsertKubeConfig(ctx, cli, diagnostics, plan, timeout)
+	}
+	waitForReconciliation := func(ctx context.Context, cli *AkpCli, plan *types.Cluster) error {
+		return clusterWaitForReconciliation(ctx, cli, plan, timeout)
+	}
+	waitForHealth := func(ctx context.Context, cli *AkpCli, plan *types.Cluster) error {
+		return clusterWaitForHealth(ctx, cli, plan, timeout)
+	}
+	result, err := applyCluster(ctx, cli, plan, apiReq, isCreate, cli.applyClusters, upsertKubeConfig, waitForReconciliation, waitForHealth)
 	if result != nil && akiSanitized {
 		plan.Spec.Data.MultiClusterK8SDashboardEnabled = tftypes.BoolValue(true)
 	}
@@ -146,6 +189,9 @@ func clusterUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnosti
 	// Always refresh cluster state to ensure we have consistent state, even if kubeconfig application failed
 	if result != nil {
 		refreshErr := refreshClusterState(ctx, diagnostics, cli.Cli, result, cli.OrgId, plan)
+		if refreshErr == nil {
+			cli.defaultMetadata.stripDefaults(ctx, diagnostics, &result.Labels, &result.Annotations, configuredLabels, configuredAnnotations)
+		}
 		if refreshErr != nil && err == nil {
 			// If we didn't have an error before but refresh failed, return the refresh error
 			return result, refreshErr
@@ -200,7 +246,7 @@ func resolveClusterWorkspaceID(ctx context.Context, cli *AkpCli, instanceID stri
 	}
 
 	resp, err := retryWithBackoff(ctx, func(ctx context.Context) (*argocdv1.GetInstanceResponse, error) {
-		return cli.Cli.GetInstance(ctx, &argocdv1.GetInstanceRequest{
+		return cli.getInstance(ctx, &argocdv1.GetInstanceRequest{
 			OrganizationId: cli.OrgId,
 			Id:             instanceID,
 			IdType:         idv1.Type_ID,
@@ -238,48 +284,24 @@ func applyCluster(
 	_, err := retryWithBackoff(ctx, func(ctx context.Context) (*argocdv1.ApplyInstanceResponse, error) {
 		return applyInstance(ctx, apiReq)
 	}, "ApplyInstance")
+	// A failed create is cleaned up once by clusterCreate.
 	if err != nil {
-		// If this is a create operation and kubeconfig application fails,
-		// clean up the dangling cluster from the API
-		if isCreate {
-			tflog.Warn(ctx, fmt.Sprintf("applyInstance failed during create, cleaning up cluster %s", plan.Name.ValueString()))
-			cleanupErr := deleteCluster(ctx, cli, plan, plan.RemoveAgentResourcesOnDestroy.ValueBool(), true)
-			if cleanupErr != nil {
-				tflog.Error(ctx, fmt.Sprintf("Failed to clean up dangling cluster %s: %v", plan.Name.ValueString(), cleanupErr))
-				return nil, fmt.Errorf("unable to create Argo CD instance %s (and failed to clean up cluster: %s)", err, cleanupErr)
-			}
-			tflog.Info(ctx, fmt.Sprintf("Successfully cleaned up dangling cluster %s", plan.Name.ValueString()))
-		}
 		return nil, fmt.Errorf("unable to create Argo CD instance: %s", err)
 	}
 
 	if err := waitForReconciliation(ctx, cli, plan); err != nil {
-		if isCreate {
-			tflog.Warn(ctx, fmt.Sprintf("Cluster reconciliation failed during create, cleaning up cluster %s", plan.Name.ValueString()))
-			cleanupErr := deleteCluster(ctx, cli, plan, plan.RemoveAgentResourcesOnDestroy.ValueBool(), true)
-			if cleanupErr != nil {
-				tflog.Error(ctx, fmt.Sprintf("Failed to clean up dangling cluster %s: %v", plan.Name.ValueString(), cleanupErr))
-				return nil, fmt.Errorf("cluster reconciliation failed: %s (and failed to clean up cluster: %s)", err, cleanupErr)
-			}
-			tflog.Info(ctx, fmt.Sprintf("Successfully cleaned up dangling cluster %s", plan.Name.ValueString()))
-		}
 		return nil, fmt.Errorf("cluster reconciliation failed: %w", err)
 	}
 
-	if kubeconfig != nil {
+	if cli.kubeconfigFor(kubeconfig) != nil {
 		plan.Kubeconfig = kubeconfig
-		shouldApply := isCreate || plan.ReapplyManifestsOnUpdate.ValueBool()
+		// An unknown agent_manifest_hash means Read found the installed agent
+		// out of sync with the expected manifests, or the image rewrite changed.
+		shouldApply := isCreate || plan.ReapplyManifestsOnUpdate.ValueBool() || plan.AgentManifestHash.IsUnknown()
 		if shouldApply {
 			err = upsertKubeConfig(ctx, cli, plan)
 			if err != nil {
 				if isCreate {
-					tflog.Warn(ctx, fmt.Sprintf("Kubeconfig application failed during create, cleaning up cluster %s", plan.Name.ValueString()))
-					cleanupErr := deleteCluster(ctx, cli, plan, plan.RemoveAgentResourcesOnDestroy.ValueBool(), true)
-					if cleanupErr != nil {
-						tflog.Error(ctx, fmt.Sprintf("Failed to clean up dangling cluster %s: %v", plan.Name.ValueString(), cleanupErr))
-						return nil, fmt.Errorf("unable to apply manifests: %s (and failed to clean up cluster: %s)", err, cleanupErr)
-					}
-					tflog.Info(ctx, fmt.Sprintf("Successfully cleaned up dangling cluster %s", plan.Name.ValueString()))
 					return nil, fmt.Errorf("unable to apply manifests: %s", err)
 				}
 				plan.Kubeconfig = nil
@@ -291,8 +313,10 @@ func applyCluster(
 			}
 		}
 	} else {
+		// Without an explicit wait_for, only wait for the agent when this
+		// apply upgrades it; it may be installed by other means much later.
 		agentWillAutoUpdate := !isCreate && plan.Spec != nil && !plan.Spec.Data.AutoUpgradeDisabled.ValueBool()
-		if agentWillAutoUpdate {
+		if agentWillAutoUpdate || isKnownNonEmptyString(plan.WaitFor) {
 			if err := waitForHealth(ctx, cli, plan); err != nil {
 				return plan, fmt.Errorf("cluster health check failed: %w", err)
 			}
@@ -302,35 +326,142 @@ func applyCluster(
 	return plan, nil
 }
 
-func clusterUpsertKubeConfig(ctx context.Context, cli *AkpCli, plan *types.Cluster) error {
+func clusterUpsertKubeConfig(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.Cluster, timeout time.Duration) error {
 	// Apply agent manifests to clusters if the kubeconfig is specified for cluster.
-	kubeconfig, err := getKubeconfig(ctx, plan.Kubeconfig)
+	kubeconfig, err := getKubeconfig(ctx, cli, plan.Kubeconfig)
 	if err != nil {
 		return err
 	}
 
 	// Apply the manifests
 	if kubeconfig != nil {
-		manifests, err := getManifests(ctx, cli.Cli, cli.OrgId, plan)
+		manifests, _, err := getManifests(ctx, cli.Cli, cli.OrgId, plan, timeout)
 		if err != nil {
 			return err
 		}
 
-		err = applyManifests(ctx, manifests, kubeconfig)
+		opts := agentManifestOpts{
+			apply:          agentApplyOpts(plan.ApplyStrategy, plan.ForceConflicts),
+			images:         agentImageRewrite(plan.ImageRegistryMirror, plan.ImagePullSecrets),
+			applySet:       kube.NewApplySet(plan.Namespace.ValueString(), "cluster", plan.InstanceID.ValueString(), plan.Name.ValueString()),
+			prune:          plan.PruneAgentResources.ValueBool(),
+			pruneDryRun:    plan.PruneDryRun.ValueBool(),
+			waitForRollout: plan.WaitForRollout.ValueBool(),
+		}
+		err = applyManifests(ctx, diagnostics, manifests, kubeconfig, opts, timeout)
 		if err != nil {
 			return err
 		}
-		return waitClusterHealthStatus(ctx, cli.Cli, cli.OrgId, plan, plan.EnsureHealthy.ValueBool())
+		if plan.AgentManifestHash.IsUnknown() {
+			plan.AgentManifestHash = tftypes.StringValue(agentManifestHash(manifests, opts.images))
+		}
+		return waitClusterHealthStatus(ctx, cli.Cli, cli.OrgId, plan, clusterWaitFor(plan), timeout)
 	}
 	return nil
 }
 
-func clusterWaitForReconciliation(ctx context.Context, cli *AkpCli, plan *types.Cluster) error {
-	return waitForClusterReconciliation(ctx, cli.Cli, cli.OrgId, plan)
+// refreshAgentManifestHash compares the agent installed in the cluster behind
+// kube_config with the manifests the platform currently expects. On drift it
+// clears agent_manifest_hash, which plans an update that re-applies them.
+// Failures only warn, so an unreachable cluster does not break refresh.
+func refreshAgentManifestHash(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, data *types.Cluster) {
+	drifted, manifests, err := detectAgentDrift(ctx, cli, data)
+	if err != nil {
+		diagnostics.AddWarning("Unable to check agent installation",
+			fmt.Sprintf("Drift of the agent installed in the cluster behind kube_config could not be checked: %s", err))
+		return
+	}
+	if manifests == "" {
+		return
+	}
+	if len(drifted) > 0 {
+		diagnostics.AddWarning("Agent installation drifted",
+			fmt.Sprintf("The following agent objects in the target cluster differ from the expected manifests and will be re-applied on the next apply:\n- %s", strings.Join(drifted, "\n- ")))
+		data.AgentManifestHash = tftypes.StringValue("")
+		return
+	}
+	data.AgentManifestHash = tftypes.StringValue(agentManifestHash(manifests, agentImageRewrite(data.ImageRegistryMirror, data.ImagePullSecrets)))
+}
+
+// detectAgentDrift returns the agent objects that differ from the manifests of
+// the cluster, and the manifests. Refresh does not wait for reconciliation, so
+// a cluster that is not reconciled yet is skipped: no manifests are returned
+// and agent_manifest_hash is left as is.
+func detectAgentDrift(ctx context.Context, cli *AkpCli, data *types.Cluster) ([]string, string, error) {
+	kubeconfig, err := getKubeconfig(ctx, cli, data.Kubeconfig)
+	if err != nil || kubeconfig == nil {
+		return nil, "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, agentDriftCheckTimeout)
+	defer cancel()
+	clusterResp, err := retryWithBackoff(ctx, func(ctx context.Context) (*argocdv1.GetInstanceClusterResponse, error) {
+		return cli.Cli.GetInstanceCluster(ctx, &argocdv1.GetInstanceClusterRequest{
+			OrganizationId: cli.OrgId,
+			InstanceId:     data.InstanceID.ValueString(),
+			Id:             data.Name.ValueString(),
+			IdType:         idv1.Type_NAME,
+		})
+	}, "GetInstanceCluster")
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Unable to read instance cluster")
+	}
+	c := clusterResp.GetCluster()
+	if c.GetReconciliationStatus().GetCode() != reconv1.StatusCode_STATUS_CODE_SUCCESSFUL {
+		tflog.Debug(ctx, fmt.Sprintf("Cluster %s is not reconciled, skipping the agent drift check", data.Name.ValueString()))
+		return nil, "", nil
+	}
+	manifests, err := downloadManifests(ctx, cli.Cli, cli.OrgId, data.InstanceID.ValueString(), c.GetId())
+	if err != nil {
+		return nil, "", err
+	}
+	resources, err := kube.SplitYAML([]byte(manifests))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to parse manifests")
+	}
+	images := agentImageRewrite(data.ImageRegistryMirror, data.ImagePullSecrets)
+	for i := range resources {
+		if err := images.Apply(&resources[i]); err != nil {
+			return nil, "", errors.Wrap(err, "failed to rewrite images")
+		}
+	}
+	kubectl, err := kube.NewKubectl(kubeconfig)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to create kubectl")
+	}
+	drifted, err := kubectl.Drift(ctx, resources)
+	return drifted, manifests, err
+}
+
+// agentManifestHash hashes the agent manifests as installed, i.e. with the
+// image rewrite applied to them. Manifests without a rewrite hash as before
+// the rewrite existed.
+func agentManifestHash(manifests string, images kube.ImageRewrite) string {
+	h := sha256.New()
+	h.Write([]byte(manifests))
+	if images.RegistryMirror != "" || len(images.PullSecrets) > 0 {
+		fmt.Fprintf(h, "\x00%s\x00%s", images.RegistryMirror, strings.Join(images.PullSecrets, ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// clusterWaitFor returns the wait_for mode of a cluster, falling back to the
+// deprecated ensure_healthy.
+func clusterWaitFor(plan *types.Cluster) string {
+	if plan.EnsureHealthy.ValueBool() {
+		return waitForMode(plan.WaitFor, types.WaitForHealthy)
+	}
+	return waitForMode(plan.WaitFor, types.WaitForReconciled)
+}
+
+func clusterWaitForReconciliation(ctx context.Context, cli *AkpCli, plan *types.Cluster, timeout time.Duration) error {
+	if !waitsFor(clusterWaitFor(plan), types.WaitForReconciled) {
+		return nil
+	}
+	return waitForClusterReconciliation(ctx, cli.Cli, cli.OrgId, plan, timeout)
 }
 
-func clusterWaitForHealth(ctx context.Context, cli *AkpCli, plan *types.Cluster) error {
-	return waitClusterHealthStatus(ctx, cli.Cli, cli.OrgId, plan, plan.EnsureHealthy.ValueBool())
+func clusterWaitForHealth(ctx context.Context, cli *AkpCli, plan *types.Cluster, timeout time.Duration) error {
+	return waitClusterHealthStatus(ctx, cli.Cli, cli.OrgId, plan, clusterWaitFor(plan), timeout)
 }
 
 func refreshClusterState(ctx context.Context, diagnostics *diag.Diagnostics, client argocdv1.ArgoCDServiceGatewayClient, cluster *types.Cluster,
@@ -392,21 +523,23 @@ func normalizeMissingClusterReadError(
 	return err
 }
 
-func buildClusterApplyRequest(ctx context.Context, diagnostics *diag.Diagnostics, cluster *types.Cluster, orgId string) *argocdv1.ApplyInstanceRequest {
+func buildClusterApplyRequest(ctx context.Context, diagnostics *diag.Diagnostics, cluster *types.Cluster, orgId string, defaults metadataDefaults) *argocdv1.ApplyInstanceRequest {
 	applyReq := &argocdv1.ApplyInstanceRequest{
 		OrganizationId: orgId,
 		IdType:         idv1.Type_ID,
 		Id:             cluster.InstanceID.ValueString(),
-		Clusters:       buildClusters(ctx, diagnostics, cluster),
+		Clusters:       buildClusters(ctx, diagnostics, cluster, defaults),
 	}
 	return applyReq
 }
 
-func buildClusters(ctx context.Context, diagnostics *diag.Diagnostics, cluster *types.Cluster) []*structpb.Struct {
+func buildClusters(ctx context.Context, diagnostics *diag.Diagnostics, cluster *types.Cluster, defaults metadataDefaults) []*structpb.Struct {
 	var labels map[string]string
 	var annotations map[string]string
 	diagnostics.Append(cluster.Labels.ElementsAs(ctx, &labels, true)...)
 	diagnostics.Append(cluster.Annotations.ElementsAs(ctx, &annotations, true)...)
+	labels = withDefaults(defaults.Labels, labels)
+	annotations = withDefaults(defaults.Annotations, annotations)
 
 	// Validate directClusterSpec if present
 	if cluster.Spec.Data.DirectClusterSpec != nil {
@@ -490,7 +623,10 @@ func buildClusters(ctx context.Context, diagnostics *diag.Diagnostics, cluster *
 	return []*structpb.Struct{s}
 }
 
-func getKubeconfig(ctx context.Context, kubeConfig *types.Kubeconfig) (*rest.Config, error) {
+// getKubeconfig returns the client config for a resource's kube_config, with
+// unset attributes taken from the provider's kube_config.
+func getKubeconfig(ctx context.Context, cli *AkpCli, kubeConfig *types.Kubeconfig) (*rest.Config, error) {
+	kubeConfig = cli.kubeconfigFor(kubeConfig)
 	if kubeConfig == nil {
 		return nil, nil
 	}
@@ -501,7 +637,7 @@ func getKubeconfig(ctx context.Context, kubeConfig *types.Kubeconfig) (*rest.Con
 	return kcfg, nil
 }
 
-func getManifests(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClient, orgId string, cluster *types.Cluster) (string, error) {
+func getManifests(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClient, orgId string, cluster *types.Cluster, timeout time.Duration) (string, string, error) {
 	clusterReq := &argocdv1.GetInstanceClusterRequest{
 		OrganizationId: orgId,
 		InstanceId:     cluster.InstanceID.ValueString(),
@@ -512,16 +648,26 @@ func getManifests(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClien
 		return client.GetInstanceCluster(ctx, clusterReq)
 	}, "GetInstanceCluster")
 	if err != nil {
-		return "", errors.Wrap(err, "Unable to read instance cluster")
+		return "", "", errors.Wrap(err, "Unable to read instance cluster")
 	}
-	c, err := waitClusterReconStatus(ctx, client, clusterResp.GetCluster(), orgId, cluster.InstanceID.ValueString())
+	c, err := waitClusterReconStatus(ctx, client, clusterResp.GetCluster(), orgId, cluster.InstanceID.ValueString(), timeout)
 	if err != nil {
-		return "", errors.Wrap(err, "Unable to check cluster reconciliation status")
+		return "", "", errors.Wrap(err, "Unable to check cluster reconciliation status")
 	}
+	manifests, err := downloadManifests(ctx, client, orgId, cluster.InstanceID.ValueString(), c.Id)
+	if err != nil {
+		return "", "", err
+	}
+	return manifests, c.Id, nil
+}
+
+// downloadManifests downloads the agent manifests of a cluster as generated
+// by its last reconciliation.
+func downloadManifests(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClient, orgId, instanceId, clusterId string) (string, error) {
 	apiReq := &argocdv1.GetInstanceClusterManifestsRequest{
 		OrganizationId: orgId,
-		InstanceId:     cluster.InstanceID.ValueString(),
-		Id:             c.Id,
+		InstanceId:     instanceId,
+		Id:             clusterId,
 	}
 	resChan, errChan, err := client.GetInstanceClusterManifests(ctx, apiReq)
 	if err != nil {
@@ -531,11 +677,48 @@ func getManifests(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClien
 	if err != nil {
 		return "", errors.Wrap(err, "Unable to parse manifests")
 	}
-
 	return string(res), nil
 }
 
-func applyManifests(ctx context.Context, manifests string, cfg *rest.Config) error {
+// agentApplyOpts translates the apply_strategy and force_conflicts attributes
+// into the options used to apply agent manifests.
+func agentApplyOpts(strategy tftypes.String, forceConflicts tftypes.Bool) kube.ApplyOpts {
+	if strategy.ValueString() != types.ApplyStrategyServerSide {
+		return kube.ApplyOpts{}
+	}
+	return kube.ApplyOpts{
+		ServerSide:     true,
+		ForceConflicts: forceConflicts.ValueBool(),
+	}
+}
+
+// agentImageRewrite translates the image_registry_mirror and
+// image_pull_secrets attributes into the rewrite of the agent manifests.
+func agentImageRewrite(mirror tftypes.String, pullSecrets tftypes.List) kube.ImageRewrite {
+	rewrite := kube.ImageRewrite{RegistryMirror: mirror.ValueString()}
+	for _, v := range pullSecrets.Elements() {
+		if s, ok := v.(tftypes.String); ok && s.ValueString() != "" {
+			rewrite.PullSecrets = append(rewrite.PullSecrets, s.ValueString())
+		}
+	}
+	return rewrite
+}
+
+// agentManifestOpts controls how agent manifests are applied to the target
+// cluster.
+type agentManifestOpts struct {
+	apply kube.ApplyOpts
+	// images rewrites the container images of every object before it is
+	// applied.
+	images kube.ImageRewrite
+	// applySet labels the applied objects so that stale ones can be pruned.
+	applySet       kube.ApplySet
+	prune          bool
+	pruneDryRun    bool
+	waitForRollout bool
+}
+
+func applyManifests(ctx context.Context, diagnostics *diag.Diagnostics, manifests string, cfg *rest.Config, opts agentManifestOpts, timeout time.Duration) error {
 	kubectl, err := kube.NewKubectl(cfg)
 	if err != nil {
 		return errors.Wrap(err, "Failed to create Kubectl")
@@ -545,17 +728,57 @@ func applyManifests(ctx context.Context, manifests string, cfg *rest.Config) err
 		return errors.Wrap(err, "Failed to parse manifests")
 	}
 
-	for _, un := range resources {
-		msg, err := kubectl.ApplyResource(ctx, &un, kube.ApplyOpts{})
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for i := range resources {
+		if ctx.Err() != nil {
+			return fmt.Errorf("applying manifests did not complete within %v", timeout)
+		}
+		if err := opts.images.Apply(&resources[i]); err != nil {
+			return errors.Wrap(err, "failed to rewrite images")
+		}
+		// Label every object, even with pruning disabled, so that enabling it
+		// later finds everything applied so far.
+		opts.applySet.Label(&resources[i])
+		msg, err := kubectl.ApplyResource(ctx, &resources[i], opts.apply)
 		if err != nil {
 			return errors.Wrap(err, "failed to apply manifest")
 		}
 		tflog.Debug(ctx, msg)
 	}
+
+	if opts.prune {
+		pruned, err := kubectl.Prune(ctx, opts.applySet, resources, opts.pruneDryRun)
+		reportPrunedResources(diagnostics, pruned, opts.pruneDryRun)
+		if err != nil {
+			return errors.Wrap(err, "failed to prune stale agent resources")
+		}
+	}
+	if opts.waitForRollout {
+		if err := kubectl.WaitForRollout(ctx, resources); err != nil {
+			return errors.Wrap(err, "agent rollout did not complete")
+		}
+	}
 	return nil
 }
 
-func deleteManifests(ctx context.Context, manifests string, cfg *rest.Config) error {
+// reportPrunedResources surfaces the objects removed by pruning as a warning,
+// so they show up in the apply output rather than only in the debug log.
+func reportPrunedResources(diagnostics *diag.Diagnostics, pruned []string, dryRun bool) {
+	if len(pruned) == 0 {
+		return
+	}
+	list := "- " + strings.Join(pruned, "\n- ")
+	if dryRun {
+		diagnostics.AddWarning("Stale agent resources found",
+			fmt.Sprintf("The following objects are no longer part of the agent manifests and would be pruned if prune_dry_run were false:\n%s", list))
+		return
+	}
+	diagnostics.AddWarning("Pruned stale agent resources",
+		fmt.Sprintf("The following objects were no longer part of the agent manifests and have been deleted:\n%s", list))
+}
+
+func deleteManifests(ctx context.Context, manifests string, cfg *rest.Config, timeout time.Duration) error {
 	kubectl, err := kube.NewKubectl(cfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to create kubectl")
@@ -566,12 +789,21 @@ func deleteManifests(ctx context.Context, manifests string, cfg *rest.Config) er
 		return errors.Wrap(err, "failed to parse manifests")
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
 	// Delete the resources in reverse order
 	for i := len(resources) - 1; i >= 0; i-- {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("deleting manifests did not complete within %v", timeout)
+		}
 		msg, err := kubectl.DeleteResource(ctx, &resources[i], kube.DeleteOpts{
 			IgnoreNotFound:  true,
 			WaitForDeletion: true,
 			Force:           false,
+			Timeout:         remaining,
 		})
 		if err != nil {
 			return errors.Wrap(err, fmt.Sprintf("failed to delete manifest: %s", resources[i]))
@@ -581,21 +813,15 @@ func deleteManifests(ctx context.Context, manifests string, cfg *rest.Config) er
 	return nil
 }
 
-func waitClusterHealthStatus(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClient, orgID string, c *types.Cluster, ensureHealthy bool) error {
-	const (
-		healthStatusTimeout      = 10 * time.Minute
-		healthStatusPollInterval = 5 * time.Second
-	)
+func waitClusterHealthStatus(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClient, orgID string, c *types.Cluster, waitFor string, timeout time.Duration) error {
+	const healthStatusPollInterval = 5 * time.Second
 
-	targetStatuses := []healthv1.StatusCode{
-		healthv1.StatusCode_STATUS_CODE_HEALTHY,
-	}
-	if !ensureHealthy {
+	targetStatuses := agentHealthTargetStatuses(waitFor)
+	if len(targetStatuses) == 0 {
 		return nil
 	}
 
-	var lastHealthMessage string
-	if err := waitForStatus(
+	if err := waitForStatusWithMessage(
 		ctx,
 		func(ctx context.Context) (*argocdv1.Cluster, error) {
 			resp, err := retryWithBackoff(ctx, func(ctx context.Context) (*argocdv1.GetInstanceClusterResponse, error) {
@@ -611,26 +837,19 @@ func waitClusterHealthStatus(ctx context.Context, client argocdv1.ArgoCDServiceG
 			}
 			return resp.GetCluster(), nil
 		},
-		func(cluster *argocdv1.Cluster) healthv1.StatusCode {
+		func(cluster *argocdv1.Cluster) (healthv1.StatusCode, string) {
 			healthStatus := cluster.GetHealthStatus()
-			if msg := healthStatus.GetMessage(); msg != "" {
-				lastHealthMessage = msg
-			}
-			return healthStatus.GetCode()
+			return healthStatus.GetCode(), healthStatus.GetMessage()
 		},
 		targetStatuses,
 		healthStatusPollInterval,
-		healthStatusTimeout,
+		timeout,
 		c.Name.ValueString(),
 		"health",
 	); err != nil {
 		var errMsg strings.Builder
 		errMsg.WriteString(err.Error())
 
-		if lastHealthMessage != "" {
-			fmt.Fprintf(&errMsg, "\n\nHealth status message: %s", lastHealthMessage)
-		}
-
 		errMsg.WriteString("\n\nTroubleshooting steps:")
 		errMsg.WriteString("\n  1. Check the cluster health in the Akuity Console")
 		errMsg.WriteString("\n  2. Verify the Akuity agent is running in the cluster (for example in akuity namespace):")
@@ -645,11 +864,8 @@ func waitClusterHealthStatus(ctx context.Context, client argocdv1.ArgoCDServiceG
 	return nil
 }
 
-func waitClusterReconStatus(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClient, cluster *argocdv1.Cluster, orgId, instanceId string) (*argocdv1.Cluster, error) {
-	const (
-		reconStatusTimeout      = 10 * time.Minute
-		reconStatusPollInterval = 5 * time.Second
-	)
+func waitClusterReconStatus(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClient, cluster *argocdv1.Cluster, orgId, instanceId string, timeout time.Duration) (*argocdv1.Cluster, error) {
+	const reconStatusPollInterval = 5 * time.Second
 
 	targetStatuses := []reconv1.StatusCode{
 		reconv1.StatusCode_STATUS_CODE_SUCCESSFUL,
@@ -680,7 +896,7 @@ func waitClusterReconStatus(ctx context.Context, client argocdv1.ArgoCDServiceGa
 		},
 		targetStatuses,
 		reconStatusPollInterval,
-		reconStatusTimeout,
+		timeout,
 		cluster.Name,
 		"reconciliation",
 	)
@@ -691,7 +907,7 @@ func waitClusterReconStatus(ctx context.Context, client argocdv1.ArgoCDServiceGa
 	return finalCluster, nil
 }
 
-func waitForClusterReconciliation(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClient, orgID string, plan *types.Cluster) error {
+func waitForClusterReconciliation(ctx context.Context, client argocdv1.ArgoCDServiceGatewayClient, orgID string, plan *types.Cluster, timeout time.Duration) error {
 	clusterReq := &argocdv1.GetInstanceClusterRequest{
 		OrganizationId: orgID,
 		InstanceId:     plan.InstanceID.ValueString(),
@@ -706,7 +922,7 @@ func waitForClusterReconciliation(ctx context.Context, client argocdv1.ArgoCDSer
 		return errors.Wrap(err, "unable to get cluster for reconciliation check")
 	}
 
-	finalCluster, err := waitClusterReconStatus(ctx, client, clusterResp.GetCluster(), orgID, plan.InstanceID.ValueString())
+	finalCluster, err := waitClusterReconStatus(ctx, client, clusterResp.GetCluster(), orgID, plan.InstanceID.ValueString(), timeout)
 	if err != nil {
 		return errors.Wrap(err, "unable to wait for cluster reconciliation")
 	}
@@ -747,9 +963,18 @@ func readStream(resChan <-chan *httpbody.HttpBody, errChan <-chan error) ([]byte
 
 // deleteCluster handles the deletion of a cluster and optionally its manifests.
 // If getIdByName is true, it will lookup the cluster ID by name before deletion.
+// The delete timeout from the plan bounds the whole deletion, from the manifest
+// cleanup to the deletion wait.
 func deleteCluster(ctx context.Context, cli *AkpCli, plan *types.Cluster, includeManifests, getIdByName bool) error {
 	var clusterID string
 
+	timeout, d := plan.Timeouts.Delete(ctx, defaultClusterTimeout)
+	if d.HasError() {
+		return fmt.Errorf("invalid delete timeout: %s", d.Errors()[0].Detail())
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	if getIdByName {
 		existingClusterReq := &argocdv1.GetInstanceClusterRequest{
 			OrganizationId: cli.OrgId,
@@ -783,18 +1008,18 @@ func deleteCluster(ctx context.Context, cli *AkpCli, plan *types.Cluster, includ
 
 	// Delete the manifests if requested and kubeconfig is available
 	if includeManifests {
-		kubeconfig, err := getKubeconfig(ctx, plan.Kubeconfig)
+		kubeconfig, err := getKubeconfig(ctx, cli, plan.Kubeconfig)
 		if err != nil {
 			return fmt.Errorf("failed to get kubeconfig: %s", err)
 		}
 
 		if kubeconfig != nil {
-			manifests, err := getManifests(ctx, cli.Cli, cli.OrgId, plan)
+			manifests, _, err := getManifests(ctx, cli.Cli, cli.OrgId, plan, timeout)
 			if err != nil {
 				return fmt.Errorf("failed to get manifests: %s", err)
 			}
 
-			err = deleteManifests(ctx, manifests, kubeconfig)
+			err = deleteManifests(ctx, manifests, kubeconfig, timeout)
 			if err != nil {
 				tflog.Error(ctx, fmt.Sprintf("failed to delete manifests while deleting cluster: %s", err))
 			}
@@ -829,16 +1054,15 @@ func deleteCluster(ctx context.Context, cli *AkpCli, plan *types.Cluster, includ
 	}
 
 	// Wait for the cluster to actually be deleted with exponential backoff
-	return waitForClusterDeletion(ctx, cli, plan.InstanceID.ValueString(), clusterID)
+	return waitForClusterDeletion(ctx, cli, plan.InstanceID.ValueString(), clusterID, timeout)
 }
 
 // waitForClusterDeletion polls the API to verify the cluster is actually deleted,
-// using exponential backoff with a maximum wait time of 10 minutes.
-func waitForClusterDeletion(ctx context.Context, cli *AkpCli, instanceID, clusterID string) error {
+// using exponential backoff for at most maxWait or until ctx is done.
+func waitForClusterDeletion(ctx context.Context, cli *AkpCli, instanceID, clusterID string, maxWait time.Duration) error {
 	const (
 		initialDelay  = 500 * time.Millisecond
 		maxDelay      = 8 * time.Second
-		maxWait       = 10 * time.Minute
 		backoffFactor = 2.0
 	)
 
@@ -847,7 +1071,7 @@ func waitForClusterDeletion(ctx context.Context, cli *AkpCli, instanceID, cluste
 
 	for {
 		// Check if we've exceeded the maximum wait time
-		if time.Since(start) > maxWait {
+		if time.Since(start) > maxWait || ctx.Err() != nil {
 			return fmt.Errorf("cluster deletion did not complete within %v", maxWait)
 		}
 
@@ -882,7 +1106,10 @@ func waitForClusterDeletion(ctx context.Context, cli *AkpCli, instanceID, cluste
 
 		// Cluster still exists, wait before retrying
 		tflog.Debug(ctx, fmt.Sprintf("Waiting %v before next deletion check for cluster %s", delay, clusterID))
-		time.Sleep(delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
 
 		// Exponential backoff with cap
 		delay = min(time.Duration(float64(delay)*backoffFactor), maxDelay)
@@ -926,6 +1153,19 @@ func (v clusterConfigValidator) ValidateResource(ctx context.Context, req resour
 		maintenanceMode,
 		maintenanceModeExpiry,
 	)
+
+	// Read separately so that the errors above do not hide kustomization
+	// problems.
+	var kustomization, namespace tftypes.String
+	var allowUnmatched tftypes.Bool
+	d := req.Config.GetAttribute(ctx, dataPath.AtName("kustomization"), &kustomization)
+	d.Append(req.Config.GetAttribute(ctx, path.Root("namespace"), &namespace)...)
+	d.Append(req.Config.GetAttribute(ctx, allowUnmatchedPatchTargetsPath, &allowUnmatched)...)
+	resp.Diagnostics.Append(d...)
+	if d.HasError() {
+		return
+	}
+	validateAgentKustomization(&resp.Diagnostics, dataPath.AtName("kustomization"), kustomization, namespace, allowUnmatched, kube.ArgoCDAgentManifests)
 }
 
 func validateClusterConfig(diagnostics *diag.Diagnostics, plan *types.Cluster) {
@@ -1211,6 +1211,8 @@ resource "akp_cluster" "test" {
   instance_id = %q
   name      = %q
   namespace = "test"
+  # The patch targets a workload the agent manifests do not include.
+  allow_unmatched_patch_targets = true
   spec = {
     namespace_scoped = true
     description      = "Custom agent size with kustomization test"
@@ -1261,6 +1263,8 @@ resource "akp_cluster" "test" {
   instance_id = %q
   name      = %q
   namespace = "test"
+  # The patch targets a workload the agent manifests do not include.
+  allow_unmatched_patch_targets = true
   spec = {
     namespace_scoped = true
     description      = "Custom agent size with kustomization test - updated"
@@ -1483,6 +1487,25 @@ func TestAkpClusterResource_reApplyManifests(t *testing.T) {
 			want:  nil,
 			error: fmt.Errorf("cluster health check failed: health check timed out"),
 		},
+		{
+			name: "no kubeconfig with wait_for - waits for health",
+			args: args{
+				plan: &types.Cluster{
+					Kubeconfig: nil,
+					WaitFor:    hashitype.StringValue(types.WaitForHealthy),
+				},
+				applyInstance: func(ctx context.Context, request *argocdv1.ApplyInstanceRequest) (*argocdv1.ApplyInstanceResponse, error) {
+					return &argocdv1.ApplyInstanceResponse{}, nil
+				},
+				upsertKubeConfig:      nil,
+				waitForReconciliation: noopReconciliation,
+				waitForHealth: func(ctx context.Context, cli *AkpCli, plan *types.Cluster) error {
+					return errors.New("health check timed out")
+				},
+			},
+			want:  nil,
+			error: fmt.Errorf("cluster health check failed: health check timed out"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,105 @@
+package akp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akuity/terraform-provider-akp/akp/kube"
+)
+
+func TestAgentApplyOpts(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy tftypes.String
+		force    tftypes.Bool
+		want     kube.ApplyOpts
+	}{
+		{
+			name:     "null strategy uses client-side apply",
+			strategy: tftypes.StringNull(),
+			force:    tftypes.BoolNull(),
+			want:     kube.ApplyOpts{},
+		},
+		{
+			name:     "client-side ignores force_conflicts",
+			strategy: tftypes.StringValue("client-side"),
+			force:    tftypes.BoolValue(true),
+			want:     kube.ApplyOpts{},
+		},
+		{
+			name:     "server-side",
+			strategy: tftypes.StringValue("server-side"),
+			force:    tftypes.BoolValue(false),
+			want:     kube.ApplyOpts{ServerSide: true},
+		},
+		{
+			name:     "server-side with force_conflicts",
+			strategy: tftypes.StringValue("server-side"),
+			force:    tftypes.BoolValue(true),
+			want:     kube.ApplyOpts{ServerSide: true, ForceConflicts: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, agentApplyOpts(tt.strategy, tt.force))
+		})
+	}
+}
+
+func TestReportPrunedResources(t *testing.T) {
+	t.Run("nothing pruned", func(t *testing.T) {
+		var diags diag.Diagnostics
+		reportPrunedResources(&diags, nil, false)
+		assert.Empty(t, diags)
+	})
+
+	t.Run("pruned", func(t *testing.T) {
+		var diags diag.Diagnostics
+		reportPrunedResources(&diags, []string{"Deployment akuity/akuity-agent", "ClusterRole akuity-agent"}, false)
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "Pruned stale agent resources", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "- Deployment akuity/akuity-agent\n- ClusterRole akuity-agent")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		var diags diag.Diagnostics
+		reportPrunedResources(&diags, []string{"ClusterRole akuity-agent"}, true)
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "Stale agent resources found", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "would be pruned")
+	})
+}
+
+func TestAgentImageRewrite(t *testing.T) {
+	assert.Equal(t, kube.ImageRewrite{}, agentImageRewrite(tftypes.StringNull(), tftypes.ListNull(tftypes.StringType)))
+
+	secrets := tftypes.ListValueMust(tftypes.StringType, []attr.Value{
+		tftypes.StringValue("mirror-creds"),
+		tftypes.StringValue("other"),
+	})
+	assert.Equal(t, kube.ImageRewrite{
+		RegistryMirror: "registry.example.com/akuity",
+		PullSecrets:    []string{"mirror-creds", "other"},
+	}, agentImageRewrite(tftypes.StringValue("registry.example.com/akuity"), secrets))
+}
+
+func TestAgentManifestHash(t *testing.T) {
+	const manifests = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: akuity\n"
+	plain := agentManifestHash(manifests, kube.ImageRewrite{})
+	// Hashes of manifests without a rewrite are those stored before the
+	// rewrite existed.
+	sum := sha256.Sum256([]byte(manifests))
+	assert.Equal(t, hex.EncodeToString(sum[:]), plain)
+
+	mirrored := agentManifestHash(manifests, kube.ImageRewrite{RegistryMirror: "registry.example.com/akuity"})
+	withSecrets := agentManifestHash(manifests, kube.ImageRewrite{RegistryMirror: "registry.example.com/akuity", PullSecrets: []string{"mirror-creds"}})
+	assert.NotEqual(t, plain, mirrored)
+	assert.NotEqual(t, mirrored, withSecrets)
+	assert.Equal(t, mirrored, agentManifestHash(manifests, kube.ImageRewrite{RegistryMirror: "registry.example.com/akuity"}))
+}
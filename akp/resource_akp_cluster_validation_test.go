@@ -4,9 +4,11 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/require"
 
+	"github.com/akuity/terraform-provider-akp/akp/kube"
 	tfakptypes "github.com/akuity/terraform-provider-akp/akp/types"
 )
 
@@ -60,3 +62,80 @@ func TestValidateClusterConfigAllowsValidCombinations(t *testing.T) {
 
 	require.False(t, diags.HasError())
 }
+
+func TestValidateAgentKustomization(t *testing.T) {
+	kustomizationPath := path.Root("spec").AtName("data").AtName("kustomization")
+
+	t.Run("unknown kustomization is skipped", func(t *testing.T) {
+		var diags diag.Diagnostics
+		validateAgentKustomization(&diags, kustomizationPath, tftypes.StringUnknown(), tftypes.StringNull(), tftypes.BoolNull(), kube.ArgoCDAgentManifests)
+
+		require.False(t, diags.HasError())
+	})
+
+	t.Run("patch target in the cluster namespace", func(t *testing.T) {
+		kustomization := `patches:
+  - patch: '[{"op": "add", "path": "/spec/replicas", "value": 2}]'
+    target:
+      kind: Deployment
+      name: argocd-repo-server
+      namespace: argocd-agent
+`
+		var diags diag.Diagnostics
+		validateAgentKustomization(&diags, kustomizationPath, tftypes.StringValue(kustomization), tftypes.StringValue("argocd-agent"), tftypes.BoolNull(), kube.ArgoCDAgentManifests)
+
+		require.False(t, diags.HasError())
+	})
+
+	unmatched := `patches:
+  - patch: '[{"op": "add", "path": "/spec/replicas", "value": 2}]'
+    target:
+      kind: Deployment
+      name: argocd-repo-srever
+`
+
+	t.Run("patch target that matches nothing fails", func(t *testing.T) {
+		var diags diag.Diagnostics
+		validateAgentKustomization(&diags, kustomizationPath, tftypes.StringValue(unmatched), tftypes.StringNull(), tftypes.BoolNull(), kube.ArgoCDAgentManifests)
+
+		require.True(t, diags.HasError())
+		require.Len(t, diags.Errors(), 1)
+		require.Contains(t, diags.Errors()[0].Detail(), "matches no agent object")
+		require.Contains(t, diags.Errors()[0].Detail(), "allow_unmatched_patch_targets")
+		withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+		require.True(t, ok)
+		require.Equal(t, kustomizationPath, withPath.Path())
+	})
+
+	t.Run("patch target that matches nothing warns when allowed", func(t *testing.T) {
+		var diags diag.Diagnostics
+		validateAgentKustomization(&diags, kustomizationPath, tftypes.StringValue(unmatched), tftypes.StringNull(), tftypes.BoolValue(true), kube.ArgoCDAgentManifests)
+
+		require.False(t, diags.HasError())
+		require.Equal(t, 1, diags.WarningsCount())
+		require.Contains(t, diags[0].Detail(), "matches no agent object")
+		withPath, ok := diags[0].(diag.DiagnosticWithPath)
+		require.True(t, ok)
+		require.Equal(t, kustomizationPath, withPath.Path())
+	})
+
+	t.Run("invalid patch", func(t *testing.T) {
+		kustomization := `patches:
+  - patch: |-
+      apiVersion: apps/v1
+      kind: Deployment
+      metadata:
+        name: argocd-repo-server
+      spec:
+        replicas: [
+`
+		var diags diag.Diagnostics
+		validateAgentKustomization(&diags, kustomizationPath, tftypes.StringValue(kustomization), tftypes.StringNull(), tftypes.BoolNull(), kube.ArgoCDAgentManifests)
+
+		require.True(t, diags.HasError())
+		require.Equal(t, "Invalid kustomization", diags.Errors()[0].Summary())
+		withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+		require.True(t, ok)
+		require.Equal(t, kustomizationPath, withPath.Path())
+	})
+}
@@ -8,17 +8,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
-	"github.com/akuity/api-client-go/pkg/api/argocdexport"
 	argocdv1 "github.com/akuity/api-client-go/pkg/api/gen/argocd/v1"
 	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
 	healthv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/health/v1"
 	reconv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/reconciliation/v1"
+	"github.com/akuity/terraform-provider-akp/akp/kube"
 	"github.com/akuity/terraform-provider-akp/akp/marshal"
 	"github.com/akuity/terraform-provider-akp/akp/types"
 )
@@ -47,19 +49,61 @@ func NewAkpInstanceResource() resource.Resource {
 	return &GenericResource[types.Instance]{
 		TypeNameSuffix: "instance",
 		SchemaFunc:     instanceSchema,
-		CreateFunc:     instanceCreateOrUpdate,
+		CreateFunc:     instanceCreate,
 		ReadFunc:       instanceRead,
-		UpdateFunc:     instanceCreateOrUpdate,
+		UpdateFunc:     instanceUpdate,
 		DeleteFunc:     instanceDelete,
+		WriteOnlyFunc:  instanceWriteOnly,
 		ImportStateFunc: func(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 			resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 		},
+		ConfigValidatorsFunc: func() []resource.ConfigValidator {
+			return []resource.ConfigValidator{
+				agentKustomizationValidator{
+					kustomization: path.Root("argocd").AtName("spec").AtName("instance_spec").AtName("cluster_customization_defaults").AtName("kustomization"),
+					manifests:     kube.ArgoCDAgentManifests,
+				},
+			}
+		},
+	}
+}
+
+func instanceCreate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.Instance) (*types.Instance, error) {
+	timeout, d := plan.Timeouts.Create(ctx, defaultInstanceTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return instanceCreateOrUpdate(ctx, cli, diags, plan, timeout)
+}
+
+func instanceUpdate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.Instance) (*types.Instance, error) {
+	timeout, d := plan.Timeouts.Update(ctx, defaultInstanceTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return instanceCreateOrUpdate(ctx, cli, diags, plan, timeout)
+}
+
+func instanceWriteOnly(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics, plan *types.Instance) {
+	readWriteOnlyMaps(ctx, config, diags, map[string]*tftypes.Map{
+		"argocd_secret_wo":                    &plan.ArgoCDSecretWO,
+		"application_set_secret_wo":           &plan.ApplicationSetSecretWO,
+		"argocd_notifications_secret_wo":      &plan.NotificationsSecretWO,
+		"argocd_image_updater_secret_wo":      &plan.ImageUpdaterSecretWO,
+		"repo_credential_secrets_wo":          &plan.RepoCredentialSecretsWO,
+		"repo_template_credential_secrets_wo": &plan.RepoTemplateCredentialSecretsWO,
+	})
 }
 
-func instanceCreateOrUpdate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.Instance) (*types.Instance, error) {
+func instanceCreateOrUpdate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.Instance, timeout time.Duration) (*types.Instance, error) {
 	plannedCM := plan.ArgoCDConfigMap
-	applied, err := instanceUpsert(ctx, cli, diags, plan)
+	applied, err := instanceUpsert(ctx, cli, diags, plan, timeout)
 	if applied {
 		plan.ArgoCDConfigMap = types.FilterMapToPlannedKeys(ctx, diags, plan.ArgoCDConfigMap, plannedCM)
 		return plan, err
@@ -79,13 +123,14 @@ func instanceRead(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, dat
 	}, false)
 }
 
-func instanceDelete(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, state *types.Instance) error {
-	deleteCtx := ctx
-	if _, deadlineSet := ctx.Deadline(); !deadlineSet {
-		var cancel context.CancelFunc
-		deleteCtx, cancel = context.WithTimeout(ctx, 2*time.Minute)
-		defer cancel()
+func instanceDelete(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, state *types.Instance) error {
+	timeout, d := state.Timeouts.Delete(ctx, defaultInstanceDeleteTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil
 	}
+	deleteCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	for {
 		err := deleteWithCooldown(deleteCtx, func(ctx context.Context) (*argocdv1.DeleteInstanceResponse, error) {
@@ -134,7 +179,7 @@ func validateInstanceAIFeatures(ctx context.Context, plan *types.Instance) error
 	return nil
 }
 
-func instanceUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.Instance) (applied bool, err error) {
+func instanceUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.Instance, timeout time.Duration) (applied bool, err error) {
 	lc := &ResourceLifecycle[types.Instance, *argocdv1.GetInstanceResponse, healthv1.StatusCode]{
 		Apply: func(ctx context.Context, diagnostics *diag.Diagnostics, plan *types.Instance) error {
 			tflog.MaskLogStrings(ctx, plan.GetSensitiveStrings(ctx, diagnostics)...)
@@ -178,6 +223,12 @@ func instanceUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnost
 			}
 			return resp.Instance.GetHealthStatus().GetCode()
 		},
+		GetStatusMessage: func(resp *argocdv1.GetInstanceResponse) string {
+			if resp == nil || resp.Instance == nil {
+				return ""
+			}
+			return combineStatusMessages(resp.Instance.GetHealthStatus().GetMessage(), resp.Instance.GetReconciliationStatus().GetMessage())
+		},
 		GetGeneration: func(resp *argocdv1.GetInstanceResponse) uint32 {
 			if resp == nil || resp.Instance == nil {
 				return 0
@@ -210,7 +261,8 @@ func instanceUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnost
 		},
 		StatusName:   "health",
 		PollInterval: 10 * time.Second,
-		Timeout:      5 * time.Minute,
+		Timeout:      timeout,
+		WaitFor:      waitForMode(plan.WaitFor, types.WaitForHealthy),
 	}
 
 	return lc.Upsert(ctx, diagnostics, plan)
@@ -233,17 +285,17 @@ func buildApplyRequest(ctx context.Context, diagnostics *diag.Diagnostics, insta
 		Argocd:                        buildArgoCD(ctx, diagnostics, instance),
 		ArgocdConfigmap:               buildConfigMap(ctx, diagnostics, instance.ArgoCDConfigMap, "argocd-cm"),
 		ArgocdRbacConfigmap:           buildConfigMap(ctx, diagnostics, instance.ArgoCDRBACConfigMap, "argocd-rbac-cm"),
-		ArgocdSecret:                  buildSecret(ctx, diagnostics, instance.ArgoCDSecret, "argocd-secret", nil),
-		ApplicationSetSecret:          buildSecret(ctx, diagnostics, instance.ApplicationSetSecret, "argocd-application-set-secret", nil),
+		ArgocdSecret:                  buildSecret(ctx, diagnostics, instance.ArgoCDSecret, instance.ArgoCDSecretWO, "argocd-secret", nil),
+		ApplicationSetSecret:          buildSecret(ctx, diagnostics, instance.ApplicationSetSecret, instance.ApplicationSetSecretWO, "argocd-application-set-secret", nil),
 		NotificationsConfigmap:        buildConfigMap(ctx, diagnostics, instance.NotificationsConfigMap, "argocd-notifications-cm"),
-		NotificationsSecret:           buildSecret(ctx, diagnostics, instance.NotificationsSecret, "argocd-notifications-secret", nil),
+		NotificationsSecret:           buildSecret(ctx, diagnostics, instance.NotificationsSecret, instance.NotificationsSecretWO, "argocd-notifications-secret", nil),
 		ImageUpdaterConfigmap:         buildConfigMap(ctx, diagnostics, instance.ImageUpdaterConfigMap, "argocd-image-updater-config"),
 		ImageUpdaterSshConfigmap:      buildConfigMap(ctx, diagnostics, instance.ImageUpdaterSSHConfigMap, "argocd-image-updater-ssh-config"),
-		ImageUpdaterSecret:            buildSecret(ctx, diagnostics, instance.ImageUpdaterSecret, "argocd-image-updater-secret", nil),
+		ImageUpdaterSecret:            buildSecret(ctx, diagnostics, instance.ImageUpdaterSecret, instance.ImageUpdaterSecretWO, "argocd-image-updater-secret", nil),
 		ArgocdKnownHostsConfigmap:     buildConfigMap(ctx, diagnostics, instance.ArgoCDKnownHostsConfigMap, "argocd-ssh-known-hosts-cm"),
 		ArgocdTlsCertsConfigmap:       buildConfigMap(ctx, diagnostics, instance.ArgoCDTLSCertsConfigMap, "argocd-tls-certs-cm"),
-		RepoCredentialSecrets:         buildSecrets(ctx, diagnostics, instance.RepoCredentialSecrets, map[string]string{"argocd.argoproj.io/secret-type": "repository"}),
-		RepoTemplateCredentialSecrets: buildSecrets(ctx, diagnostics, instance.RepoTemplateCredentialSecrets, map[string]string{"argocd.argoproj.io/secret-type": "repo-creds"}),
+		RepoCredentialSecrets:         buildSecrets(ctx, diagnostics, instance.RepoCredentialSecrets, instance.RepoCredentialSecretsWO, map[string]string{"argocd.argoproj.io/secret-type": "repository"}),
+		RepoTemplateCredentialSecrets: buildSecrets(ctx, diagnostics, instance.RepoTemplateCredentialSecrets, instance.RepoTemplateCredentialSecretsWO, map[string]string{"argocd.argoproj.io/secret-type": "repo-creds"}),
 		ConfigManagementPlugins:       buildCMPs(ctx, diagnostics, instance.ConfigManagementPlugins),
 		PruneResourceTypes:            []argocdv1.PruneResourceType{argocdv1.PruneResourceType_PRUNE_RESOURCE_TYPE_CONFIG_MANAGEMENT_PLUGINS},
 	}
@@ -271,6 +323,10 @@ func buildArgoCD(_ context.Context, diag *diag.Diagnostics, instance *types.Inst
 	rawMap["metadata"] = map[string]any{
 		"name": instance.Name.ValueString(),
 	}
+	if err := addImageRewriteDefaults(rawMap, kube.ArgoCDAgentManifests, argoCDCustomizationDefaultsPath...); err != nil {
+		diag.AddError("Client Error", fmt.Sprintf("Unable to build the cluster customization defaults. %s", err))
+		return nil
+	}
 
 	s, err := structpb.NewStruct(rawMap)
 	if err != nil {
@@ -280,15 +336,27 @@ func buildArgoCD(_ context.Context, diag *diag.Diagnostics, instance *types.Inst
 	return s
 }
 
-func buildSecrets(ctx context.Context, diagnostics *diag.Diagnostics, secrets tftypes.Map, labels map[string]string) []*structpb.Struct {
+func buildSecrets(ctx context.Context, diagnostics *diag.Diagnostics, secrets, writeOnly tftypes.Map, labels map[string]string) []*structpb.Struct {
 	var res []*structpb.Struct
-	var sMap map[string]tftypes.Map
-	if secrets.IsNull() {
-		return res
+	var sMap, woMap map[string]tftypes.Map
+	if !secrets.IsNull() {
+		diagnostics.Append(secrets.ElementsAs(ctx, &sMap, true)...)
+	}
+	if !writeOnly.IsNull() {
+		diagnostics.Append(writeOnly.ElementsAs(ctx, &woMap, true)...)
 	}
-	diagnostics.Append(secrets.ElementsAs(ctx, &sMap, true)...)
 	for name, secret := range sMap {
-		res = append(res, buildSecret(ctx, diagnostics, secret, name, labels))
+		wo, ok := woMap[name]
+		if !ok {
+			wo = tftypes.MapNull(tftypes.StringType)
+		}
+		res = append(res, buildSecret(ctx, diagnostics, secret, wo, name, labels))
+	}
+	for name, wo := range woMap {
+		if _, ok := sMap[name]; ok {
+			continue
+		}
+		res = append(res, buildSecret(ctx, diagnostics, tftypes.MapNull(tftypes.StringType), wo, name, labels))
 	}
 	return res
 }
@@ -306,11 +374,13 @@ func buildConfigMap(ctx context.Context, diagnostics *diag.Diagnostics, cm tftyp
 	return configMap
 }
 
-func buildSecret(ctx context.Context, diagnostics *diag.Diagnostics, secret tftypes.Map, name string, labels map[string]string) *structpb.Struct {
-	if secret.IsNull() {
+// buildSecret builds the named Secret from a secret attribute and its
+// write-only counterpart. It returns nil when neither is set.
+func buildSecret(ctx context.Context, diagnostics *diag.Diagnostics, secret, writeOnly tftypes.Map, name string, labels map[string]string) *structpb.Struct {
+	if secret.IsNull() && writeOnly.IsNull() {
 		return nil
 	}
-	apiModel := types.ToSecretAPIModel(ctx, diagnostics, name, labels, secret)
+	apiModel := types.ToSecretAPIModel(ctx, diagnostics, name, labels, secret, writeOnly)
 	s, err := marshal.ApiModelToPBStruct(apiModel)
 	if err != nil {
 		diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Secret. %s", err))
@@ -336,7 +406,7 @@ func buildCMPs(_ context.Context, diagnostics *diag.Diagnostics, cmps map[string
 func refreshState(ctx context.Context, diagnostics *diag.Diagnostics, cli *AkpCli, instance *types.Instance, getInstanceReq *argocdv1.GetInstanceRequest, isDataSource bool) error {
 	tflog.Debug(ctx, fmt.Sprintf("Get instance request: %s", getInstanceReq))
 	getInstanceResp, err := retryWithBackoff(ctx, func(ctx context.Context) (*argocdv1.GetInstanceResponse, error) {
-		return cli.Cli.GetInstance(ctx, getInstanceReq)
+		return cli.getInstance(ctx, getInstanceReq)
 	}, "GetInstance")
 	if err != nil {
 		return errors.Wrap(err, "Unable to read Argo CD instance")
@@ -357,11 +427,21 @@ func refreshState(ctx context.Context, diagnostics *diag.Diagnostics, cli *AkpCl
 	}
 	tflog.Debug(ctx, fmt.Sprintf("Export instance request: %s", exportReq))
 	exportResp, err := retryWithBackoff(ctx, func(ctx context.Context) (*argocdv1.ExportInstanceResponse, error) {
-		return argocdexport.ExportInstance(ctx, cli.Cli, exportReq)
+		return cli.exportInstance(ctx, exportReq)
 	}, "ExportInstance")
 	if err != nil {
 		return errors.Wrap(err, "Unable to export Argo CD instance")
 	}
+	// The response is shared through the read cache, so it is copied before
+	// the image rewrite is taken out of it.
+	argocd, err := takeImageRewriteDefaults(exportResp.Argocd, kube.ArgoCDAgentManifests, argoCDCustomizationDefaultsPath...)
+	if err != nil {
+		return errors.Wrap(err, "Unable to read the cluster customization defaults")
+	}
+	if argocd != exportResp.Argocd {
+		exportResp = proto.Clone(exportResp).(*argocdv1.ExportInstanceResponse)
+		exportResp.Argocd = argocd
+	}
 	err = instance.Update(ctx, diagnostics, exportResp, isDataSource)
 	return err
 }
@@ -102,7 +102,10 @@ func (r *AkpInstanceIPAllowListResource) Create(ctx context.Context, req resourc
 		return
 	}
 
-	ctx = r.AuthCtx(ctx)
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if _, _, err := getInstanceIPAllowList(ctx, r.akpCli, plan.InstanceID.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get instance: %s", err))
@@ -177,7 +180,10 @@ func (r *AkpInstanceIPAllowListResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	ctx = r.AuthCtx(ctx)
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	currentEntries, _, err := getInstanceIPAllowList(ctx, r.akpCli, data.InstanceID.ValueString())
 	if err != nil {
@@ -220,7 +226,10 @@ func (r *AkpInstanceIPAllowListResource) Update(ctx context.Context, req resourc
 		return
 	}
 
-	ctx = r.AuthCtx(ctx)
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if _, _, err := getInstanceIPAllowList(ctx, r.akpCli, plan.InstanceID.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get instance: %s", err))
@@ -342,7 +351,10 @@ func (r *AkpInstanceIPAllowListResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	ctx = r.AuthCtx(ctx)
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if _, _, err := getInstanceIPAllowList(ctx, r.akpCli, state.InstanceID.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get instance: %s", err))
@@ -434,7 +446,10 @@ func (r *AkpInstanceIPAllowListResource) Delete(ctx context.Context, req resourc
 }
 
 func (r *AkpInstanceIPAllowListResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ctx = r.AuthCtx(ctx)
+	ctx = r.AuthCtx(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	entries, _, err := getInstanceIPAllowList(ctx, r.akpCli, req.ID)
 	if err != nil {
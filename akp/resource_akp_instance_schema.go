@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -27,6 +28,9 @@ func instanceSchema() schema.Schema {
 	return schema.Schema{
 		MarkdownDescription: "Manages an Argo CD instance",
 		Attributes:          getAKPInstanceAttributes(),
+		Blocks: map[string]schema.Block{
+			"timeouts": getTimeoutsBlock(),
+		},
 	}
 }
 
@@ -165,6 +169,92 @@ func getAKPInstanceAttributes() map[string]schema.Attribute {
 				mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile("repo-.+"), "invalid secret name, repo template credential secret name should start with 'repo-'")),
 			},
 		},
+		"argocd_secret_wo": schema.MapAttribute{
+			MarkdownDescription: "Write-only alternative to `argocd_secret` that is never stored in the plan or state. Keys set here take precedence over the same keys in `argocd_secret`. Requires Terraform 1.11 or later and `argocd_secret_wo_version`.",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				mapvalidator.AlsoRequires(path.MatchRoot("argocd_secret_wo_version")),
+			},
+		},
+		"argocd_secret_wo_version": schema.Int64Attribute{
+			MarkdownDescription: "Version of `argocd_secret_wo`. Terraform cannot detect changes to write-only values, so change this value to update the `argocd-secret` Secret.",
+			Optional:            true,
+		},
+		"application_set_secret_wo": schema.MapAttribute{
+			MarkdownDescription: "Write-only alternative to `application_set_secret` that is never stored in the plan or state. Keys set here take precedence over the same keys in `application_set_secret`. Requires Terraform 1.11 or later and `application_set_secret_wo_version`.",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				mapvalidator.AlsoRequires(path.MatchRoot("application_set_secret_wo_version")),
+			},
+		},
+		"application_set_secret_wo_version": schema.Int64Attribute{
+			MarkdownDescription: "Version of `application_set_secret_wo`. Terraform cannot detect changes to write-only values, so change this value to update the ApplicationSet secret.",
+			Optional:            true,
+		},
+		"argocd_notifications_secret_wo": schema.MapAttribute{
+			MarkdownDescription: "Write-only alternative to `argocd_notifications_secret` that is never stored in the plan or state. Keys set here take precedence over the same keys in `argocd_notifications_secret`. Requires Terraform 1.11 or later and `argocd_notifications_secret_wo_version`.",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				mapvalidator.AlsoRequires(path.MatchRoot("argocd_notifications_secret_wo_version")),
+			},
+		},
+		"argocd_notifications_secret_wo_version": schema.Int64Attribute{
+			MarkdownDescription: "Version of `argocd_notifications_secret_wo`. Terraform cannot detect changes to write-only values, so change this value to update the `argocd-notifications-secret` Secret.",
+			Optional:            true,
+		},
+		"argocd_image_updater_secret_wo": schema.MapAttribute{
+			MarkdownDescription: "Write-only alternative to `argocd_image_updater_secret` that is never stored in the plan or state. Keys set here take precedence over the same keys in `argocd_image_updater_secret`. Requires Terraform 1.11 or later and `argocd_image_updater_secret_wo_version`.",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				mapvalidator.AlsoRequires(path.MatchRoot("argocd_image_updater_secret_wo_version")),
+			},
+		},
+		"argocd_image_updater_secret_wo_version": schema.Int64Attribute{
+			MarkdownDescription: "Version of `argocd_image_updater_secret_wo`. Terraform cannot detect changes to write-only values, so change this value to update the image updater secret.",
+			Optional:            true,
+		},
+		"repo_credential_secrets_wo": schema.MapAttribute{
+			MarkdownDescription: "Write-only alternative to `repo_credential_secrets` that is never stored in the plan or state. Keys set here take precedence over the same keys in `repo_credential_secrets`. Requires Terraform 1.11 or later and `repo_credential_secrets_wo_version`.",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+			ElementType:         types.MapType{ElemType: types.StringType},
+			Validators: []validator.Map{
+				mapvalidator.AlsoRequires(path.MatchRoot("repo_credential_secrets_wo_version")),
+				mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile("repo-.+"), "invalid secret name, repo credential secret name should start with 'repo-'")),
+			},
+		},
+		"repo_credential_secrets_wo_version": schema.Int64Attribute{
+			MarkdownDescription: "Version of `repo_credential_secrets_wo`. Terraform cannot detect changes to write-only values, so change this value to update the repo credential secrets.",
+			Optional:            true,
+		},
+		"repo_template_credential_secrets_wo": schema.MapAttribute{
+			MarkdownDescription: "Write-only alternative to `repo_template_credential_secrets` that is never stored in the plan or state. Keys set here take precedence over the same keys in `repo_template_credential_secrets`. Requires Terraform 1.11 or later and `repo_template_credential_secrets_wo_version`.",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+			ElementType:         types.MapType{ElemType: types.StringType},
+			Validators: []validator.Map{
+				mapvalidator.AlsoRequires(path.MatchRoot("repo_template_credential_secrets_wo_version")),
+				mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile("repo-.+"), "invalid secret name, repo template credential secret name should start with 'repo-'")),
+			},
+		},
+		"repo_template_credential_secrets_wo_version": schema.Int64Attribute{
+			MarkdownDescription: "Version of `repo_template_credential_secrets_wo`. Terraform cannot detect changes to write-only values, so change this value to update the repo template credential secrets.",
+			Optional:            true,
+		},
 		"config_management_plugins": schema.MapNestedAttribute{
 			MarkdownDescription: "is a map of [Config Management Plugins](https://argo-cd.readthedocs.io/en/stable/operator-manual/config-management-plugins/#config-management-plugins), the key of map entry is the `name` of the plugin, and the value is the definition of the Config Management Plugin(v2).",
 			Optional:            true,
@@ -180,6 +270,17 @@ func getAKPInstanceAttributes() map[string]schema.Attribute {
 				mapplanmodifier.UseStateForUnknown(),
 			},
 		},
+		"allow_unmatched_patch_targets": schema.BoolAttribute{
+			MarkdownDescription: "If true, patches of `argocd.spec.instance_spec.cluster_customization_defaults.kustomization` whose target matches no object of the representative agent manifests only warn instead of failing the plan, e.g. for a patch of an object that the agent installs only in some configurations.",
+			Optional:            true,
+		},
+		"wait_for": schema.StringAttribute{
+			MarkdownDescription: "Which phases of the wait run after the instance is applied: `none` returns as soon as the platform accepts the change, `reconciled` waits for the platform to reconcile it and `healthy` (default) also waits for the instance to become healthy.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(instanceWaitForModes...),
+			},
+		},
 	}
 }
 
@@ -615,7 +716,7 @@ func getClusterCustomizationAttributes() map[string]schema.Attribute {
 			},
 		},
 		"kustomization": schema.StringAttribute{
-			MarkdownDescription: "Kustomize configuration that will be applied to generated agent installation manifests",
+			MarkdownDescription: "Kustomize configuration that will be applied to generated agent installation manifests. It is built at plan time against a representative set of agent objects: invalid patches fail the plan, and so do patch targets that match no agent object unless `allow_unmatched_patch_targets` is set.",
 			Optional:            true,
 			Computed:            true,
 			PlanModifiers: []planmodifier.String{
@@ -666,6 +767,21 @@ func getClusterCustomizationAttributes() map[string]schema.Attribute {
 				stringplanmodifier2.SuppressProtobufDefault(),
 			},
 		},
+		"image_registry_mirror": schema.StringAttribute{
+			MarkdownDescription: "Default registry that mirrors the agent images, applied to new clusters, e.g. `registry.example.com/akuity`. It is stored in `kustomization` as an `images` entry for each agent image known to the provider, which replaces the registry and keeps the repository path and tag.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(imageRegistryMirrorDefaultsRegex, imageRegistryMirrorDefaultsRegexDescription),
+			},
+		},
+		"image_pull_secrets": schema.ListAttribute{
+			MarkdownDescription: "Default names of secrets in the agent namespace that are added to the `imagePullSecrets` of every agent pod of new clusters, e.g. credentials for `image_registry_mirror`. It is stored in `kustomization` as patches. The secrets are not created by the provider.",
+			ElementType:         types.StringType,
+			Optional:            true,
+			Validators: []validator.List{
+				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+			},
+		},
 	}
 }
 
@@ -14,7 +14,7 @@ import (
 // If this test fails, a field has been added/removed to the AKP Instance type.
 // Update the schema attribute accordingly.
 func TestNoNewAKPInstanceFields(t *testing.T) {
-	assert.Equal(t, reflect.TypeFor[types.Instance]().NumField(), len(getAKPInstanceAttributes()))
+	assert.Equal(t, reflect.TypeFor[types.Instance]().NumField(), len(getAKPInstanceAttributes())+len(instanceSchema().Blocks))
 }
 
 // If this test fails, a field has been added/removed to the ArgoCD related type.
@@ -0,0 +1,56 @@
+package akp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func repoSecretsValue(t *testing.T, secrets map[string]map[string]string) tftypes.Map {
+	t.Helper()
+	elems := make(map[string]attr.Value, len(secrets))
+	for name, data := range secrets {
+		m, d := tftypes.MapValueFrom(context.Background(), tftypes.StringType, data)
+		require.False(t, d.HasError(), d)
+		elems[name] = m
+	}
+	v, d := tftypes.MapValue(tftypes.MapType{ElemType: tftypes.StringType}, elems)
+	require.False(t, d.HasError(), d)
+	return v
+}
+
+func TestBuildSecretsMergesWriteOnly(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+	secrets := repoSecretsValue(t, map[string]map[string]string{
+		"repo-a": {"url": "https://a.example.com", "password": "plain"},
+	})
+	writeOnly := repoSecretsValue(t, map[string]map[string]string{
+		"repo-a": {"password": "wo"},
+		"repo-b": {"url": "https://b.example.com", "password": "wo"},
+	})
+	labels := map[string]string{"argocd.argoproj.io/secret-type": "repository"}
+
+	res := buildSecrets(ctx, &diags, secrets, writeOnly, labels)
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, res, 2)
+
+	byName := map[string]map[string]any{}
+	for _, s := range res {
+		m := s.AsMap()
+		byName[m["metadata"].(map[string]any)["name"].(string)] = m["stringData"].(map[string]any)
+	}
+	assert.Equal(t, map[string]any{"url": "https://a.example.com", "password": "wo"}, byName["repo-a"])
+	assert.Equal(t, map[string]any{"url": "https://b.example.com", "password": "wo"}, byName["repo-b"])
+}
+
+func TestBuildSecretNull(t *testing.T) {
+	var diags diag.Diagnostics
+	assert.Nil(t, buildSecret(context.Background(), &diags, tftypes.MapNull(tftypes.StringType), tftypes.MapNull(tftypes.StringType), "argocd-secret", nil))
+	assert.False(t, diags.HasError())
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/pkg/errors"
@@ -22,6 +23,7 @@ import (
 	idv1 "github.com/akuity/api-client-go/pkg/api/gen/types/id/v1"
 	healthv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/health/v1"
 	reconv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/reconciliation/v1"
+	"github.com/akuity/terraform-provider-akp/akp/kube"
 	"github.com/akuity/terraform-provider-akp/akp/types"
 )
 
@@ -29,18 +31,55 @@ func NewAkpKargoInstanceResource() resource.Resource {
 	return &GenericResource[types.KargoInstance]{
 		TypeNameSuffix: "kargo_instance",
 		SchemaFunc:     kargoInstanceSchema,
-		CreateFunc:     kargoInstanceCreateOrUpdate,
+		CreateFunc:     kargoInstanceCreate,
 		ReadFunc:       kargoInstanceRead,
-		UpdateFunc:     kargoInstanceCreateOrUpdate,
+		UpdateFunc:     kargoInstanceUpdate,
 		DeleteFunc:     kargoInstanceDelete,
+		WriteOnlyFunc:  kargoInstanceWriteOnly,
 		ImportStateFunc: func(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 			resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 		},
+		ConfigValidatorsFunc: func() []resource.ConfigValidator {
+			return []resource.ConfigValidator{
+				agentKustomizationValidator{
+					kustomization: path.Root("kargo").AtName("spec").AtName("kargo_instance_spec").AtName("agent_customization_defaults").AtName("kustomization"),
+					manifests:     kube.KargoAgentManifests,
+				},
+			}
+		},
+	}
+}
+
+func kargoInstanceCreate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.KargoInstance) (*types.KargoInstance, error) {
+	timeout, d := plan.Timeouts.Create(ctx, defaultInstanceTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return kargoInstanceCreateOrUpdate(ctx, cli, diags, plan, timeout)
+}
+
+func kargoInstanceUpdate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.KargoInstance) (*types.KargoInstance, error) {
+	timeout, d := plan.Timeouts.Update(ctx, defaultInstanceTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return kargoInstanceCreateOrUpdate(ctx, cli, diags, plan, timeout)
+}
+
+func kargoInstanceWriteOnly(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics, plan *types.KargoInstance) {
+	readWriteOnlyMaps(ctx, config, diags, map[string]*tftypes.Map{
+		"kargo_secret_wo": &plan.KargoSecretWO,
+	})
 }
 
-func kargoInstanceCreateOrUpdate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.KargoInstance) (*types.KargoInstance, error) {
-	applied, err := kargoInstanceUpsert(ctx, cli, diags, plan)
+func kargoInstanceCreateOrUpdate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.KargoInstance, timeout time.Duration) (*types.KargoInstance, error) {
+	applied, err := kargoInstanceUpsert(ctx, cli, diags, plan, timeout)
 	if applied {
 		return plan, err
 	}
@@ -54,7 +93,15 @@ func kargoInstanceRead(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics
 	return refreshKargoState(ctx, diags, cli, data, cli.OrgId, false)
 }
 
-func kargoInstanceDelete(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, state *types.KargoInstance) error {
+func kargoInstanceDelete(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, state *types.KargoInstance) error {
+	timeout, d := state.Timeouts.Delete(ctx, defaultInstanceDeleteTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	err := deleteWithCooldown(ctx, func(ctx context.Context) (*kargov1.DeleteInstanceResponse, error) {
 		return cli.KargoCli.DeleteInstance(ctx, &kargov1.DeleteInstanceRequest{
 			Id:             state.ID.ValueString(),
@@ -91,7 +138,7 @@ func validateKargoInstanceAIFeatures(ctx context.Context, plan *types.KargoInsta
 	return nil
 }
 
-func kargoInstanceUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.KargoInstance) (applied bool, err error) {
+func kargoInstanceUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.KargoInstance, timeout time.Duration) (applied bool, err error) {
 	lc := &ResourceLifecycle[types.KargoInstance, *kargov1.GetKargoInstanceResponse, healthv1.StatusCode]{
 		Apply: func(ctx context.Context, diagnostics *diag.Diagnostics, plan *types.KargoInstance) error {
 			if err := validateKargoInstanceAIFeatures(ctx, plan); err != nil {
@@ -137,6 +184,12 @@ func kargoInstanceUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Dia
 			}
 			return resp.Instance.GetHealthStatus().GetCode()
 		},
+		GetStatusMessage: func(resp *kargov1.GetKargoInstanceResponse) string {
+			if resp == nil || resp.Instance == nil {
+				return ""
+			}
+			return combineStatusMessages(resp.Instance.GetHealthStatus().GetMessage(), resp.Instance.GetReconciliationStatus().GetMessage())
+		},
 		GetGeneration: func(resp *kargov1.GetKargoInstanceResponse) uint32 {
 			if resp == nil || resp.Instance == nil {
 				return 0
@@ -165,7 +218,8 @@ func kargoInstanceUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Dia
 		},
 		StatusName:   "health",
 		PollInterval: 10 * time.Second,
-		Timeout:      5 * time.Minute,
+		Timeout:      timeout,
+		WaitFor:      waitForMode(plan.WaitFor, types.WaitForHealthy),
 	}
 
 	return lc.Upsert(ctx, diagnostics, plan)
@@ -189,7 +243,7 @@ func buildKargoApplyRequest(ctx context.Context, diagnostics *diag.Diagnostics,
 		WorkspaceId:    workspaceID,
 		Kargo:          buildKargo(ctx, diagnostics, kargo, agentMaps),
 		KargoConfigmap: buildConfigMap(ctx, diagnostics, kargo.KargoConfigMap, "kargo-cm"),
-		KargoSecret:    buildSecret(ctx, diagnostics, kargo.KargoSecret, "kargo-secret", nil),
+		KargoSecret:    buildSecret(ctx, diagnostics, kargo.KargoSecret, kargo.KargoSecretWO, "kargo-secret", nil),
 	}
 
 	if !kargo.KargoResources.IsUnknown() {
@@ -371,6 +425,10 @@ func buildKargo(_ context.Context, diagnostics *diag.Diagnostics, kargo *types.K
 			spec["fqdn"] = ""
 		}
 	}
+	if err := addImageRewriteDefaults(rawMap, kube.KargoAgentManifests, kargoCustomizationDefaultsPath...); err != nil {
+		diagnostics.AddError("Client Error", fmt.Sprintf("Unable to build the agent customization defaults. %s", err))
+		return nil
+	}
 
 	s, err := structpb.NewStruct(rawMap)
 	if err != nil {
@@ -416,6 +474,11 @@ func refreshKargoState(ctx context.Context, diagnostics *diag.Diagnostics, cli *
 		return errors.Wrap(err, "Unable to export Kargo instance")
 	}
 	tflog.Debug(ctx, fmt.Sprintf("Export Kargo instance response: %s", exportResp))
+	spec, err := takeImageRewriteDefaults(exportResp.Kargo, kube.KargoAgentManifests, kargoCustomizationDefaultsPath...)
+	if err != nil {
+		return errors.Wrap(err, "Unable to read the agent customization defaults")
+	}
+	exportResp.Kargo = spec
 	return kargo.Update(ctx, diagnostics, exportResp, agentMaps, isDataSource)
 }
 
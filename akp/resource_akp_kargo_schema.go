@@ -1,7 +1,10 @@
 package akp
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -21,6 +24,9 @@ func kargoInstanceSchema() schema.Schema {
 	return schema.Schema{
 		MarkdownDescription: "Manages an AKP Kargo instance.",
 		Attributes:          getAKPKargoInstanceAttributes(),
+		Blocks: map[string]schema.Block{
+			"timeouts": getTimeoutsBlock(),
+		},
 	}
 }
 
@@ -61,6 +67,20 @@ func getAKPKargoInstanceAttributes() map[string]schema.Attribute {
 			Optional:            true,
 			Sensitive:           true,
 		},
+		"kargo_secret_wo": schema.MapAttribute{
+			MarkdownDescription: "Write-only alternative to `kargo_secret` that is never stored in the plan or state. Keys set here take precedence over the same keys in `kargo_secret`. Requires Terraform 1.11 or later and `kargo_secret_wo_version`.",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				mapvalidator.AlsoRequires(path.MatchRoot("kargo_secret_wo_version")),
+			},
+		},
+		"kargo_secret_wo_version": schema.Int64Attribute{
+			MarkdownDescription: "Version of `kargo_secret_wo`. Terraform cannot detect changes to write-only values, so change this value to update the `kargo-secret` Secret.",
+			Optional:            true,
+		},
 		"workspace": schema.StringAttribute{
 			Optional:            true,
 			Computed:            true,
@@ -78,6 +98,17 @@ func getAKPKargoInstanceAttributes() map[string]schema.Attribute {
 				mapplanmodifier.UseStateForUnknown(),
 			},
 		},
+		"allow_unmatched_patch_targets": schema.BoolAttribute{
+			MarkdownDescription: "If true, patches of `kargo.spec.kargo_instance_spec.agent_customization_defaults.kustomization` whose target matches no object of the representative agent manifests only warn instead of failing the plan, e.g. for a patch of an object that the agent installs only in some configurations.",
+			Optional:            true,
+		},
+		"wait_for": schema.StringAttribute{
+			MarkdownDescription: "Which phases of the wait run after the Kargo instance is applied: `none` returns as soon as the platform accepts the change, `reconciled` waits for the platform to reconcile it and `healthy` (default) also waits for the instance to become healthy.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(instanceWaitForModes...),
+			},
+		},
 	}
 }
 
@@ -275,7 +306,7 @@ func getKargoAgentCustomizationAttributes() map[string]schema.Attribute {
 			Computed:            true,
 		},
 		"kustomization": schema.StringAttribute{
-			MarkdownDescription: "Kustomization that will be applied to the Kargo agent to generate agent installation manifests",
+			MarkdownDescription: "Kustomization that will be applied to the Kargo agent to generate agent installation manifests. It is built at plan time against a representative set of agent objects: invalid patches fail the plan, and so do patch targets that match no agent object unless `allow_unmatched_patch_targets` is set.",
 			Optional:            true,
 		},
 		"connectivity": schema.StringAttribute{
@@ -298,6 +329,21 @@ func getKargoAgentCustomizationAttributes() map[string]schema.Attribute {
 				stringplanmodifier2.SuppressProtobufDefault(),
 			},
 		},
+		"image_registry_mirror": schema.StringAttribute{
+			MarkdownDescription: "Default registry that mirrors the agent images, applied to new agents, e.g. `registry.example.com/akuity`. It is stored in `kustomization` as an `images` entry for each agent image known to the provider, which replaces the registry and keeps the repository path and tag.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(imageRegistryMirrorDefaultsRegex, imageRegistryMirrorDefaultsRegexDescription),
+			},
+		},
+		"image_pull_secrets": schema.ListAttribute{
+			MarkdownDescription: "Default names of secrets in the agent namespace that are added to the `imagePullSecrets` of every agent pod of new agents, e.g. credentials for `image_registry_mirror`. It is stored in `kustomization` as patches. The secrets are not created by the provider.",
+			ElementType:         types.StringType,
+			Optional:            true,
+			Validators: []validator.List{
+				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+			},
+		},
 	}
 }
 
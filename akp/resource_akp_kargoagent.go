@@ -21,6 +21,7 @@ import (
 	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
 	healthv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/health/v1"
 	reconv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/reconciliation/v1"
+	"github.com/akuity/terraform-provider-akp/akp/kube"
 	"github.com/akuity/terraform-provider-akp/akp/types"
 )
 
@@ -32,6 +33,7 @@ func NewAkpKargoAgentResource() resource.Resource {
 		ReadFunc:       kargoAgentRead,
 		UpdateFunc:     kargoAgentUpdate,
 		DeleteFunc:     kargoAgentDelete,
+		ModifyPlanFunc: planMetadataDefaults,
 		ImportStateFunc: func(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 			idParts := strings.Split(req.ID, "/")
 			if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
@@ -53,22 +55,49 @@ func NewAkpKargoAgentResource() resource.Resource {
 }
 
 func kargoAgentCreate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.KargoAgent) (*types.KargoAgent, error) {
-	return kargoAgentUpsert(ctx, cli, diags, plan, true)
+	timeout, d := plan.Timeouts.Create(ctx, defaultKargoAgentTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return kargoAgentUpsert(ctx, cli, diags, plan, true, timeout)
 }
 
 func kargoAgentRead(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, data *types.KargoAgent) error {
 	if data.Spec == nil {
 		ctx = types.WithReadContext(ctx)
 	}
-	return refreshKargoAgentState(ctx, diags, cli, data, data)
+	configuredLabels, configuredAnnotations := data.Labels, data.Annotations
+	if err := refreshKargoAgentState(ctx, diags, cli, data, data); err != nil {
+		return err
+	}
+	cli.defaultMetadata.stripDefaults(ctx, diags, &data.Labels, &data.Annotations, configuredLabels, configuredAnnotations)
+	return nil
 }
 
 func kargoAgentUpdate(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.KargoAgent) (*types.KargoAgent, error) {
-	return kargoAgentUpsert(ctx, cli, diags, plan, false)
+	timeout, d := plan.Timeouts.Update(ctx, defaultKargoAgentTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return kargoAgentUpsert(ctx, cli, diags, plan, false, timeout)
 }
 
-func kargoAgentDelete(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, plan *types.KargoAgent) error {
-	kubeconfig, err := getKubeconfig(ctx, plan.Kubeconfig)
+func kargoAgentDelete(ctx context.Context, cli *AkpCli, diags *diag.Diagnostics, plan *types.KargoAgent) error {
+	timeout, d := plan.Timeouts.Delete(ctx, defaultKargoAgentTimeout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	kubeconfig, err := getKubeconfig(ctx, cli, plan.Kubeconfig)
 	if err != nil {
 		return fmt.Errorf("unable to get kubeconfig: %s", err)
 	}
@@ -81,12 +110,12 @@ func kargoAgentDelete(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, pla
 
 	// Delete the manifests
 	if kubeconfig != nil && plan.RemoveAgentResourcesOnDestroy.ValueBool() {
-		manifests, _, err := getKargoManifests(ctx, cli.KargoCli, cli.OrgId, plan)
+		manifests, _, err := getKargoManifests(ctx, cli.KargoCli, cli.OrgId, plan, timeout)
 		if err != nil {
 			return fmt.Errorf("unable to get kargo manifests: %s", err)
 		}
 
-		err = deleteManifests(ctx, manifests, kubeconfig)
+		err = deleteManifests(ctx, manifests, kubeconfig, timeout)
 		if err != nil {
 			return fmt.Errorf("unable to delete manifests: %s", err)
 		}
@@ -113,7 +142,7 @@ func kargoAgentDelete(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, pla
 	return nil
 }
 
-func kargoAgentUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.KargoAgent, isCreate bool) (*types.KargoAgent, error) {
+func kargoAgentUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.KargoAgent, isCreate bool, timeout time.Duration) (*types.KargoAgent, error) {
 	validateKargoAgentConfig(diagnostics, plan)
 	if diagnostics.HasError() {
 		return nil, nil
@@ -124,11 +153,14 @@ func kargoAgentUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagno
 		diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get workspace. %s", err))
 		return nil, errors.New("Unable to get workspace")
 	}
-	apiReq := buildKargoAgentApplyRequest(ctx, diagnostics, plan, cli.OrgId, workspace.Id)
+	apiReq := buildKargoAgentApplyRequest(ctx, diagnostics, plan, cli.OrgId, workspace.Id, cli.defaultMetadata)
 	if diagnostics.HasError() {
 		return nil, nil
 	}
-	result, err := applyKargoAgent(ctx, cli, plan, apiReq, isCreate)
+	// The refresh below overwrites the plan's labels and annotations with
+	// those read back, defaults included.
+	configuredLabels, configuredAnnotations := plan.Labels, plan.Annotations
+	result, err := applyKargoAgent(ctx, cli, diagnostics, plan, apiReq, isCreate, timeout)
 	if err != nil {
 		return result, err
 	}
@@ -144,10 +176,14 @@ func kargoAgentUpsert(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagno
 		tflog.Warn(ctx, fmt.Sprintf("Failed to auto-set defaultShardAgent: %s", err))
 	}
 
-	return result, refreshKargoAgentState(ctx, diagnostics, cli, result, plan)
+	if err := refreshKargoAgentState(ctx, diagnostics, cli, result, plan); err != nil {
+		return result, err
+	}
+	cli.defaultMetadata.stripDefaults(ctx, diagnostics, &result.Labels, &result.Annotations, configuredLabels, configuredAnnotations)
+	return result, nil
 }
 
-func applyKargoAgent(ctx context.Context, cli *AkpCli, plan *types.KargoAgent, apiReq *kargov1.ApplyKargoInstanceRequest, isCreate bool) (*types.KargoAgent, error) {
+func applyKargoAgent(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.KargoAgent, apiReq *kargov1.ApplyKargoInstanceRequest, isCreate bool, timeout time.Duration) (*types.KargoAgent, error) {
 	kubeconfig := plan.Kubeconfig
 	plan.Kubeconfig = nil
 	tflog.Debug(ctx, fmt.Sprintf("Apply Kargo agent request: %s", apiReq))
@@ -159,22 +195,59 @@ func applyKargoAgent(ctx context.Context, cli *AkpCli, plan *types.KargoAgent, a
 		return nil, fmt.Errorf("unable to create Kargo agent: %s", err)
 	}
 
-	if kubeconfig != nil {
+	installed := false
+	if cli.kubeconfigFor(kubeconfig) != nil {
 		plan.Kubeconfig = kubeconfig
-		shouldApply := isCreate || plan.ReapplyManifestsOnUpdate.ValueBool()
+		// An unknown agent_manifest_hash means the image rewrite changed.
+		shouldApply := isCreate || plan.ReapplyManifestsOnUpdate.ValueBool() || plan.AgentManifestHash.IsUnknown()
 		if shouldApply {
-			err = kargoAgentUpsertKubeConfig(ctx, cli, plan)
+			err = kargoAgentUpsertKubeConfig(ctx, cli, diagnostics, plan, timeout)
 			if err != nil {
 				// Ensure kubeconfig won't be committed to state by setting it to nil
 				plan.Kubeconfig = nil
 				return plan, fmt.Errorf("unable to apply kargo manifests: %s", err)
 			}
+			installed = true
+		}
+	}
+	// Without an explicit wait_for, only wait for the agent when this apply
+	// installed it; it may be installed by other means much later.
+	if !installed && isKnownNonEmptyString(plan.WaitFor) {
+		if err := waitForKargoAgent(ctx, cli, plan, plan.WaitFor.ValueString(), timeout); err != nil {
+			return plan, fmt.Errorf("waiting for Kargo agent failed: %w", err)
 		}
 	}
 
 	return plan, nil
 }
 
+// waitForKargoAgent runs the phases of the wait selected by waitFor for an
+// agent whose manifests were not applied by this apply.
+func waitForKargoAgent(ctx context.Context, cli *AkpCli, plan *types.KargoAgent, waitFor string, timeout time.Duration) error {
+	if !waitsFor(waitFor, types.WaitForReconciled) {
+		return nil
+	}
+	agent, err := findKargoAgent(ctx, cli.KargoCli, cli.OrgId, plan)
+	if err != nil {
+		return err
+	}
+	if plan.ID.ValueString() == "" {
+		plan.ID = tftypes.StringValue(agent.GetId())
+	}
+	agent, err = waitKargoAgentReconStatus(ctx, cli.KargoCli, agent, cli.OrgId, plan.InstanceID.ValueString(), timeout)
+	if err != nil {
+		return errors.Wrap(err, "unable to wait for Kargo agent reconciliation")
+	}
+	if agent.GetReconciliationStatus().GetCode() == reconv1.StatusCode_STATUS_CODE_FAILED {
+		msg := agent.GetReconciliationStatus().GetMessage()
+		if msg == "" {
+			msg = "Kargo agent reconciliation failed"
+		}
+		return errors.New(msg)
+	}
+	return waitKargoAgentHealthStatus(ctx, cli.KargoCli, cli.OrgId, plan, waitFor, timeout)
+}
+
 func syncKargoAgentMaintenanceMode(ctx context.Context, cli *AkpCli, workspaceID string, plan *types.KargoAgent) error {
 	if cli == nil || cli.KargoCli == nil || plan == nil || plan.Spec == nil {
 		return nil
@@ -210,16 +283,16 @@ func syncKargoAgentMaintenanceMode(ctx context.Context, cli *AkpCli, workspaceID
 	return nil
 }
 
-func kargoAgentUpsertKubeConfig(ctx context.Context, cli *AkpCli, plan *types.KargoAgent) error {
+func kargoAgentUpsertKubeConfig(ctx context.Context, cli *AkpCli, diagnostics *diag.Diagnostics, plan *types.KargoAgent, timeout time.Duration) error {
 	// Apply agent manifests to clusters if the kubeconfig is specified for cluster.
-	kubeconfig, err := getKubeconfig(ctx, plan.Kubeconfig)
+	kubeconfig, err := getKubeconfig(ctx, cli, plan.Kubeconfig)
 	if err != nil {
 		return err
 	}
 
 	// Apply the manifests
 	if kubeconfig != nil {
-		manifests, id, err := getKargoManifests(ctx, cli.KargoCli, cli.OrgId, plan)
+		manifests, id, err := getKargoManifests(ctx, cli.KargoCli, cli.OrgId, plan, timeout)
 		if err != nil {
 			return err
 		}
@@ -227,11 +300,22 @@ func kargoAgentUpsertKubeConfig(ctx context.Context, cli *AkpCli, plan *types.Ka
 			plan.ID = tftypes.StringValue(id)
 		}
 
-		err = applyManifests(ctx, manifests, kubeconfig)
+		opts := agentManifestOpts{
+			apply:          agentApplyOpts(plan.ApplyStrategy, plan.ForceConflicts),
+			images:         agentImageRewrite(plan.ImageRegistryMirror, plan.ImagePullSecrets),
+			applySet:       kube.NewApplySet(plan.Namespace.ValueString(), "kargo-agent", plan.InstanceID.ValueString(), plan.Name.ValueString()),
+			prune:          plan.PruneAgentResources.ValueBool(),
+			pruneDryRun:    plan.PruneDryRun.ValueBool(),
+			waitForRollout: plan.WaitForRollout.ValueBool(),
+		}
+		err = applyManifests(ctx, diagnostics, manifests, kubeconfig, opts, timeout)
 		if err != nil {
 			return err
 		}
-		return waitKargoAgentHealthStatus(ctx, cli.KargoCli, cli.OrgId, plan)
+		if plan.AgentManifestHash.IsUnknown() {
+			plan.AgentManifestHash = tftypes.StringValue(agentManifestHash(manifests, opts.images))
+		}
+		return waitKargoAgentHealthStatus(ctx, cli.KargoCli, cli.OrgId, plan, waitForMode(plan.WaitFor, types.WaitForAgentConnected), timeout)
 	}
 	return nil
 }
@@ -426,21 +510,23 @@ func hydrateKargoAgentFieldsFromExport(kargoAgent *types.KargoAgent, exportedAge
 	return true
 }
 
-func buildKargoAgentApplyRequest(ctx context.Context, diagnostics *diag.Diagnostics, kargoAgent *types.KargoAgent, orgId, workspaceId string) *kargov1.ApplyKargoInstanceRequest {
+func buildKargoAgentApplyRequest(ctx context.Context, diagnostics *diag.Diagnostics, kargoAgent *types.KargoAgent, orgId, workspaceId string, defaults metadataDefaults) *kargov1.ApplyKargoInstanceRequest {
 	applyReq := &kargov1.ApplyKargoInstanceRequest{
 		OrganizationId: orgId,
 		Id:             kargoAgent.InstanceID.ValueString(),
 		WorkspaceId:    workspaceId,
-		Agents:         buildKargoAgents(ctx, diagnostics, kargoAgent),
+		Agents:         buildKargoAgents(ctx, diagnostics, kargoAgent, defaults),
 	}
 	return applyReq
 }
 
-func buildKargoAgents(ctx context.Context, diagnostics *diag.Diagnostics, kargoAgent *types.KargoAgent) []*structpb.Struct {
+func buildKargoAgents(ctx context.Context, diagnostics *diag.Diagnostics, kargoAgent *types.KargoAgent, defaults metadataDefaults) []*structpb.Struct {
 	var labels map[string]string
 	var annotations map[string]string
 	diagnostics.Append(kargoAgent.Labels.ElementsAs(ctx, &labels, true)...)
 	diagnostics.Append(kargoAgent.Annotations.ElementsAs(ctx, &annotations, true)...)
+	labels = withDefaults(defaults.Labels, labels)
+	annotations = withDefaults(defaults.Annotations, annotations)
 
 	rawMap := types.TFToMapWithOverrides(kargoAgent.Spec, types.KargoOverridesMap, types.KargoRenamesMap)
 	if rawMap == nil {
@@ -574,6 +660,19 @@ func (v kargoAgentConfigValidator) ValidateResource(ctx context.Context, req res
 		maintenanceModeExpiry,
 		size,
 	)
+
+	// Read separately so that the errors above do not hide kustomization
+	// problems.
+	var kustomization, namespace tftypes.String
+	var allowUnmatched tftypes.Bool
+	d := req.Config.GetAttribute(ctx, dataPath.AtName("kustomization"), &kustomization)
+	d.Append(req.Config.GetAttribute(ctx, path.Root("namespace"), &namespace)...)
+	d.Append(req.Config.GetAttribute(ctx, allowUnmatchedPatchTargetsPath, &allowUnmatched)...)
+	resp.Diagnostics.Append(d...)
+	if d.HasError() {
+		return
+	}
+	validateAgentKustomization(&resp.Diagnostics, dataPath.AtName("kustomization"), kustomization, namespace, allowUnmatched, kube.KargoAgentManifests)
 }
 
 func validateKargoAgentConfig(diagnostics *diag.Diagnostics, plan *types.KargoAgent) {
@@ -647,7 +746,7 @@ func isKnownTrueBool(value tftypes.Bool) bool {
 	return !value.IsNull() && !value.IsUnknown() && value.ValueBool()
 }
 
-func getKargoManifests(ctx context.Context, client kargov1.KargoServiceGatewayClient, orgId string, kargoAgent *types.KargoAgent) (string, string, error) {
+func findKargoAgent(ctx context.Context, client kargov1.KargoServiceGatewayClient, orgId string, kargoAgent *types.KargoAgent) (*kargov1.KargoAgent, error) {
 	agents, err := retryWithBackoff(ctx, func(ctx context.Context) (*kargov1.ListKargoInstanceAgentsResponse, error) {
 		return client.ListKargoInstanceAgents(ctx, &kargov1.ListKargoInstanceAgentsRequest{
 			OrganizationId: orgId,
@@ -655,20 +754,23 @@ func getKargoManifests(ctx context.Context, client kargov1.KargoServiceGatewayCl
 		})
 	}, "ListKargoInstanceAgents")
 	if err != nil {
-		return "", "", errors.Wrap(err, "Unable to read Kargo agents")
+		return nil, errors.Wrap(err, "Unable to read Kargo agents")
 	}
-	var agent *kargov1.KargoAgent
 	for _, a := range agents.GetAgents() {
 		if a.GetName() == kargoAgent.Name.ValueString() {
-			agent = a
-			break
+			return a, nil
 		}
 	}
-	if agent == nil {
-		return "", "", errors.New("Unable to find Kargo agent")
+	return nil, errors.New("Unable to find Kargo agent")
+}
+
+func getKargoManifests(ctx context.Context, client kargov1.KargoServiceGatewayClient, orgId string, kargoAgent *types.KargoAgent, timeout time.Duration) (string, string, error) {
+	agent, err := findKargoAgent(ctx, client, orgId, kargoAgent)
+	if err != nil {
+		return "", "", err
 	}
 
-	k, err := waitKargoAgentReconStatus(ctx, client, agent, orgId, kargoAgent.InstanceID.ValueString())
+	k, err := waitKargoAgentReconStatus(ctx, client, agent, orgId, kargoAgent.InstanceID.ValueString(), timeout)
 	if err != nil {
 		return "", "", errors.Wrap(err, "Unable to check kargo agent health status")
 	}
@@ -689,7 +791,12 @@ func getKargoManifests(ctx context.Context, client kargov1.KargoServiceGatewayCl
 	return string(res), k.Id, nil
 }
 
-func waitKargoAgentHealthStatus(ctx context.Context, client kargov1.KargoServiceGatewayClient, orgID string, c *types.KargoAgent) error {
+func waitKargoAgentHealthStatus(ctx context.Context, client kargov1.KargoServiceGatewayClient, orgID string, c *types.KargoAgent, waitFor string, timeout time.Duration) error {
+	targetStatuses := agentHealthTargetStatuses(waitFor)
+	if len(targetStatuses) == 0 {
+		return nil
+	}
+
 	getResourceFunc := func(ctx context.Context) (*kargov1.GetKargoInstanceAgentResponse, error) {
 		return retryWithBackoff(ctx, func(ctx context.Context) (*kargov1.GetKargoInstanceAgentResponse, error) {
 			return client.GetKargoInstanceAgent(ctx, &kargov1.GetKargoInstanceAgentRequest{
@@ -700,26 +807,26 @@ func waitKargoAgentHealthStatus(ctx context.Context, client kargov1.KargoService
 		}, "GetKargoInstanceAgent")
 	}
 
-	getStatusFunc := func(resp *kargov1.GetKargoInstanceAgentResponse) healthv1.StatusCode {
+	getStatusFunc := func(resp *kargov1.GetKargoInstanceAgentResponse) (healthv1.StatusCode, string) {
 		if resp == nil || resp.Agent == nil {
-			return healthv1.StatusCode_STATUS_CODE_UNKNOWN
+			return healthv1.StatusCode_STATUS_CODE_UNKNOWN, ""
 		}
-		return resp.Agent.GetHealthStatus().GetCode()
+		return resp.Agent.GetHealthStatus().GetCode(), resp.Agent.GetHealthStatus().GetMessage()
 	}
 
-	return waitForStatus(
+	return waitForStatusWithMessage(
 		ctx,
 		getResourceFunc,
 		getStatusFunc,
-		[]healthv1.StatusCode{healthv1.StatusCode_STATUS_CODE_HEALTHY, healthv1.StatusCode_STATUS_CODE_DEGRADED},
+		targetStatuses,
 		5*time.Second,
-		5*time.Minute,
+		timeout,
 		fmt.Sprintf("KargoAgent %s", c.Name.ValueString()),
 		"health",
 	)
 }
 
-func waitKargoAgentReconStatus(ctx context.Context, client kargov1.KargoServiceGatewayClient, kargoAgent *kargov1.KargoAgent, orgId, instanceId string) (*kargov1.KargoAgent, error) {
+func waitKargoAgentReconStatus(ctx context.Context, client kargov1.KargoServiceGatewayClient, kargoAgent *kargov1.KargoAgent, orgId, instanceId string, timeout time.Duration) (*kargov1.KargoAgent, error) {
 	// Capture the last seen agent so the caller can use it after wait completes.
 	var lastAgent *kargov1.KargoAgent
 
@@ -750,7 +857,7 @@ func waitKargoAgentReconStatus(ctx context.Context, client kargov1.KargoServiceG
 		getStatusFunc,
 		[]reconv1.StatusCode{reconv1.StatusCode_STATUS_CODE_SUCCESSFUL, reconv1.StatusCode_STATUS_CODE_FAILED},
 		5*time.Second,
-		5*time.Minute,
+		timeout,
 		fmt.Sprintf("KargoAgent %s", kargoAgent.GetName()),
 		"reconciliation",
 	)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/require"
@@ -31,7 +32,7 @@ func TestBuildKargoAgentsOmitsNormalizedEmptyFields(t *testing.T) {
 	}
 
 	var diags diag.Diagnostics
-	agents := buildKargoAgents(context.Background(), &diags, agent)
+	agents := buildKargoAgents(context.Background(), &diags, agent, metadataDefaults{})
 	require.False(t, diags.HasError())
 	require.Len(t, agents, 1)
 
@@ -59,7 +60,7 @@ func TestBuildKargoAgentsPreservesConfiguredFields(t *testing.T) {
 	}
 
 	var diags diag.Diagnostics
-	agents := buildKargoAgents(context.Background(), &diags, agent)
+	agents := buildKargoAgents(context.Background(), &diags, agent, metadataDefaults{})
 	require.False(t, diags.HasError())
 	require.Len(t, agents, 1)
 
@@ -88,7 +89,7 @@ func TestBuildKargoAgentsOmitsSizeForAkuityManagedAgents(t *testing.T) {
 	}
 
 	var diags diag.Diagnostics
-	agents := buildKargoAgents(context.Background(), &diags, agent)
+	agents := buildKargoAgents(context.Background(), &diags, agent, metadataDefaults{})
 	require.False(t, diags.HasError())
 	require.Len(t, agents, 1)
 
@@ -114,10 +115,38 @@ func TestBuildKargoAgentsKeepsSizeForSelfManagedAgents(t *testing.T) {
 	}
 
 	var diags diag.Diagnostics
-	agents := buildKargoAgents(context.Background(), &diags, agent)
+	agents := buildKargoAgents(context.Background(), &diags, agent, metadataDefaults{})
 	require.False(t, diags.HasError())
 	require.Len(t, agents, 1)
 
 	data := agents[0].AsMap()["spec"].(map[string]any)["data"].(map[string]any)
 	require.Equal(t, "medium", data["size"])
 }
+
+func TestBuildKargoAgentsMergesDefaultMetadata(t *testing.T) {
+	agent := &tfakptypes.KargoAgent{
+		Name:        tftypes.StringValue("agent-name"),
+		Namespace:   tftypes.StringValue("agent-ns"),
+		Labels:      tftypes.MapValueMust(tftypes.StringType, map[string]attr.Value{"env": tftypes.StringValue("prod")}),
+		Annotations: tftypes.MapNull(tftypes.StringType),
+		Spec: &tfakptypes.KargoAgentSpec{
+			Data: tfakptypes.KargoAgentData{
+				Size:         tftypes.StringValue("small"),
+				RemoteArgocd: tftypes.StringValue("argocd-id"),
+			},
+		},
+	}
+	defaults := metadataDefaults{
+		Labels:      map[string]string{"env": "dev", "owner": "platform"},
+		Annotations: map[string]string{"cost-center": "1234"},
+	}
+
+	var diags diag.Diagnostics
+	agents := buildKargoAgents(context.Background(), &diags, agent, defaults)
+	require.False(t, diags.HasError())
+	require.Len(t, agents, 1)
+
+	metadata := agents[0].AsMap()["metadata"].(map[string]any)
+	require.Equal(t, map[string]any{"env": "prod", "owner": "platform"}, metadata["labels"])
+	require.Equal(t, map[string]any{"cost-center": "1234"}, metadata["annotations"])
+}
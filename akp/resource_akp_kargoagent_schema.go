@@ -1,12 +1,15 @@
 package akp
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,6 +22,9 @@ func kargoAgentSchema() schema.Schema {
 	return schema.Schema{
 		MarkdownDescription: "Manages an AKP Kargo agent.",
 		Attributes:          getAKPKargoAgentResourceAttributes(),
+		Blocks: map[string]schema.Block{
+			"timeouts": getTimeoutsBlock(),
+		},
 	}
 }
 
@@ -67,7 +73,7 @@ func getAKPKargoAgentResourceAttributes() map[string]schema.Attribute {
 		},
 		"labels": schema.MapAttribute{
 			ElementType:         types.StringType,
-			MarkdownDescription: "Labels",
+			MarkdownDescription: "Labels. The provider's `default_labels` are added to these, see `labels_all`.",
 			Optional:            true,
 			Computed:            true,
 			PlanModifiers: []planmodifier.Map{
@@ -76,20 +82,30 @@ func getAKPKargoAgentResourceAttributes() map[string]schema.Attribute {
 		},
 		"annotations": schema.MapAttribute{
 			ElementType:         types.StringType,
-			MarkdownDescription: "Annotations",
+			MarkdownDescription: "Annotations. The provider's `default_annotations` are added to these, see `annotations_all`.",
 			Optional:            true,
 			Computed:            true,
 			PlanModifiers: []planmodifier.Map{
 				mapplanmodifier.UseStateForUnknown(),
 			},
 		},
+		"labels_all": schema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Labels applied to the resource, including the provider's `default_labels`. Labels set in `labels` take precedence over the defaults.",
+			Computed:            true,
+		},
+		"annotations_all": schema.MapAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Annotations applied to the resource, including the provider's `default_annotations`. Annotations set in `annotations` take precedence over the defaults.",
+			Computed:            true,
+		},
 		"spec": schema.SingleNestedAttribute{
 			MarkdownDescription: "Spec of the Kargo agent",
 			Required:            true,
 			Attributes:          getAKPKargoAgentSpecAttributes(),
 		},
 		"kube_config": schema.SingleNestedAttribute{
-			MarkdownDescription: "Kubernetes connection settings. If configured, terraform will try to connect to the cluster and install the agent",
+			MarkdownDescription: "Kubernetes connection settings. If configured, terraform will try to connect to the cluster and install the agent. Unset attributes fall back to the provider's `kube_config`, which also applies when this block is omitted.",
 			Optional:            true,
 			Attributes:          getKubeconfigAttributes(),
 		},
@@ -108,6 +124,75 @@ func getAKPKargoAgentResourceAttributes() map[string]schema.Attribute {
 				boolplanmodifier2.SuppressProtobufDefault(),
 			},
 		},
+		"apply_strategy": schema.StringAttribute{
+			MarkdownDescription: "How generated agent manifests are applied to the target cluster when `kube_config` is provided. `client-side` (default) uses kubectl's three-way merge with the last-applied annotation; `server-side` uses server-side apply with the `akp-terraform` field manager.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("client-side"),
+			Validators: []validator.String{
+				stringvalidator.OneOf("client-side", "server-side"),
+			},
+		},
+		"force_conflicts": schema.BoolAttribute{
+			MarkdownDescription: "If true and `apply_strategy` is `server-side`, take ownership of fields managed by other field managers instead of failing the apply.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"prune_agent_resources": schema.BoolAttribute{
+			MarkdownDescription: "If true (default), agent objects applied by an earlier apply that are no longer part of the generated manifests are deleted from the target cluster after the manifests are applied. Objects are tracked with the `akuity.io/applied-by` label. Namespaces and CustomResourceDefinitions are never pruned.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+		},
+		"prune_dry_run": schema.BoolAttribute{
+			MarkdownDescription: "If true, stale agent objects are only reported as warnings instead of being deleted. Only used with `prune_agent_resources`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"allow_unmatched_patch_targets": schema.BoolAttribute{
+			MarkdownDescription: "If true, patches of `spec.data.kustomization` whose target matches no object of the representative agent manifests only warn instead of failing the plan, e.g. for a patch of an object that the agent installs only in some configurations.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"wait_for_rollout": schema.BoolAttribute{
+			MarkdownDescription: "If true, wait after applying the agent manifests until the agent Deployments and StatefulSets have finished rolling out in the target cluster. If the rollout does not complete within the timeout, the error includes pod failures such as `ImagePullBackOff` or `OOMKilled`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"wait_for": schema.StringAttribute{
+			MarkdownDescription: "Which phases of the wait run after the Kargo agent is applied: `none` returns as soon as the platform accepts the change, `reconciled` waits for the platform to reconcile it, `agent-connected` also waits for the agent to connect and report its health, and `healthy` waits for the agent to become healthy. Defaults to `agent-connected` on applies that install the agent manifests through `kube_config`, and to `none` otherwise. Once set, the agent phases run after every apply, so the agent must be installed through `kube_config` or by other means within the timeout. Installing the agent manifests through `kube_config` always waits for reconciliation, since the manifests are generated by it.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(waitForModes...),
+			},
+		},
+		"image_registry_mirror": schema.StringAttribute{
+			MarkdownDescription: "Registry that mirrors the agent images, for clusters that cannot pull from the public registries, e.g. `registry.example.com/akuity`. Before the agent manifests are applied, the registry of every container image is replaced with this value, keeping the repository path and tag. The rewrite is applied on top of any `kustomization`.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(imageRegistryMirrorRegex, imageRegistryMirrorRegexDescription),
+			},
+		},
+		"image_pull_secrets": schema.ListAttribute{
+			MarkdownDescription: "Names of secrets in the agent namespace to add to the `imagePullSecrets` of every agent pod, e.g. credentials for `image_registry_mirror`. The secrets are not created by the provider.",
+			ElementType:         types.StringType,
+			Optional:            true,
+			Validators: []validator.List{
+				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+			},
+		},
+		"agent_manifest_hash": schema.StringAttribute{
+			MarkdownDescription: "Hash of the agent manifests installed in the target cluster, set when `kube_config` is provided. Changing `image_registry_mirror` or `image_pull_secrets` re-applies the manifests.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier2.RecomputeWhenEmpty(),
+				stringplanmodifier2.RecomputeWhenChanged(path.Root("image_registry_mirror"), path.Root("image_pull_secrets")),
+			},
+		},
 	}
 }
 
@@ -157,7 +242,7 @@ func getAKPKargoAgentDataAttributes() map[string]schema.Attribute {
 			},
 		},
 		"kustomization": schema.StringAttribute{
-			MarkdownDescription: "Kustomize configuration that will be applied to generated agent installation manifests",
+			MarkdownDescription: "Kustomize configuration that will be applied to generated agent installation manifests. It is built at plan time against a representative set of agent objects: invalid patches fail the plan, and so do patch targets that match no agent object unless `allow_unmatched_patch_targets` is set.",
 			Optional:            true,
 			Computed:            true,
 			PlanModifiers: []planmodifier.String{
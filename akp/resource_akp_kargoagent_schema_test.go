@@ -14,7 +14,7 @@ import (
 // If this test fails, a field has been added/removed to the Kargo Agent related type.
 // Update the schema attribute accordingly.
 func TestNoNewKargoAgentFields(t *testing.T) {
-	assert.Equal(t, reflect.TypeFor[types.KargoAgent]().NumField(), len(getAKPKargoAgentResourceAttributes()))
+	assert.Equal(t, reflect.TypeFor[types.KargoAgent]().NumField(), len(getAKPKargoAgentResourceAttributes())+len(kargoAgentSchema().Blocks))
 	assert.Equal(t, reflect.TypeFor[types.KargoAgentSpec]().NumField(), len(getAKPKargoAgentSpecAttributes()))
 	assert.Equal(t, reflect.TypeFor[types.KargoAgentCustomization]().NumField(), len(getKargoAgentCustomizationAttributes()))
 	assert.Equal(t, reflect.TypeFor[types.KargoAgentData]().NumField(), len(getAKPKargoAgentDataAttributes()))
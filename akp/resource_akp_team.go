@@ -3,6 +3,7 @@ package akp
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -114,3 +115,61 @@ func applyTeamResponse(data *types.Team, userTeam *orgcv1.UserTeam) {
 	}
 	data.MemberCount = tftypes.Int64Value(team.GetMemberCount())
 }
+
+// listTeamMembers returns the users currently in the team. A missing team is
+// surfaced as NotFound so member resources drop out of state with it.
+func listTeamMembers(ctx context.Context, cli *AkpCli, teamName string) ([]*orgcv1.TeamMember, error) {
+	resp, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.ListTeamMembersResponse, error) {
+		return cli.OrgCli.ListTeamMembers(ctx, &orgcv1.ListTeamMembersRequest{
+			OrganizationId: cli.OrgId,
+			TeamName:       teamName,
+		})
+	}, "ListTeamMembers")
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetTeamMembers(), nil
+}
+
+// findTeamMember matches by email case-insensitively, as the backend
+// canonicalizes user emails.
+func findTeamMember(members []*orgcv1.TeamMember, email string) *orgcv1.TeamMember {
+	for _, m := range members {
+		if strings.EqualFold(m.GetEmail(), email) {
+			return m
+		}
+	}
+	return nil
+}
+
+func addTeamMember(ctx context.Context, cli *AkpCli, teamName, email string) (*orgcv1.TeamMember, error) {
+	resp, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.AddTeamMemberResponse, error) {
+		return cli.OrgCli.AddTeamMember(ctx, &orgcv1.AddTeamMemberRequest{
+			OrganizationId: cli.OrgId,
+			TeamName:       teamName,
+			Email:          email,
+		})
+	}, "AddTeamMember")
+	if err != nil {
+		return nil, fmt.Errorf("unable to add %q to team %q: %w", email, teamName, err)
+	}
+	return resp.GetTeamMember(), nil
+}
+
+func removeTeamMember(ctx context.Context, cli *AkpCli, teamName, id string) error {
+	_, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.RemoveTeamMemberResponse, error) {
+		resp, err := cli.OrgCli.RemoveTeamMember(ctx, &orgcv1.RemoveTeamMemberRequest{
+			OrganizationId: cli.OrgId,
+			TeamName:       teamName,
+			Id:             id,
+		})
+		if isGoneErr(err) {
+			return resp, nil
+		}
+		return resp, err
+	}, "RemoveTeamMember")
+	if err != nil {
+		return fmt.Errorf("unable to remove member %q from team %q: %w", id, teamName, err)
+	}
+	return nil
+}
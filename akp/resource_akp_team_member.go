@@ -0,0 +1,83 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+func NewAkpTeamMemberResource() resource.Resource {
+	return &GenericResource[types.TeamMember]{
+		TypeNameSuffix: "team_member",
+		SchemaFunc:     teamMemberSchema,
+		CreateFunc:     teamMemberCreate,
+		ReadFunc:       teamMemberRead,
+		UpdateFunc:     teamMemberUpdate,
+		DeleteFunc:     teamMemberDelete,
+		ImportStateFunc: func(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+			// Import ID: <team_name>/<email>. Read resolves the member ID.
+			teamName, email, ok := strings.Cut(req.ID, "/")
+			if !ok || teamName == "" || email == "" {
+				resp.Diagnostics.AddError(
+					"Unexpected Import Identifier",
+					fmt.Sprintf("Expected `team_name/email`. Got: %q", req.ID),
+				)
+				return
+			}
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_name"), teamName)...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("email"), email)...)
+		},
+	}
+}
+
+func teamMemberCreate(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, plan *types.TeamMember) (*types.TeamMember, error) {
+	member, err := addTeamMember(ctx, cli, plan.TeamName.ValueString(), plan.Email.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	applyTeamMemberResponse(plan, member)
+	return plan, nil
+}
+
+// teamMemberRead looks the user up in the team's member list rather than by
+// ID, so import by email works and a user removed in the UI drops out of state.
+func teamMemberRead(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, data *types.TeamMember) error {
+	members, err := listTeamMembers(ctx, cli, data.TeamName.ValueString())
+	if err != nil {
+		return err
+	}
+	member := findTeamMember(members, data.Email.ValueString())
+	if member == nil {
+		return status.Errorf(codes.NotFound, "%q is not a member of team %q", data.Email.ValueString(), data.TeamName.ValueString())
+	}
+	applyTeamMemberResponse(data, member)
+	return nil
+}
+
+func teamMemberUpdate(_ context.Context, _ *AkpCli, _ *diag.Diagnostics, plan *types.TeamMember) (*types.TeamMember, error) {
+	// Every configurable attribute is RequiresReplace, so there is nothing to
+	// update in place.
+	return plan, nil
+}
+
+func teamMemberDelete(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, state *types.TeamMember) error {
+	return removeTeamMember(ctx, cli, state.TeamName.ValueString(), state.ID.ValueString())
+}
+
+func applyTeamMemberResponse(data *types.TeamMember, member *orgcv1.TeamMember) {
+	if member == nil {
+		return
+	}
+	data.ID = tftypes.StringValue(member.GetId())
+	data.Email = hydrateIfUnset(data.Email, member.GetEmail())
+}
@@ -0,0 +1,45 @@
+package akp
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func teamMemberSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Adds a user to an Akuity Platform organization team. Other members of the team are left untouched; use `akp_team_members` to manage the full membership of a team instead. Do not use both resources for the same team.",
+		Attributes:          getTeamMemberAttributes(),
+	}
+}
+
+func getTeamMemberAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Team member ID",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"team_name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Name of the team to add the user to",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"email": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Email of the user to add to the team. The user must already be a member of the organization.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+	}
+}
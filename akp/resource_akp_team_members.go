@@ -0,0 +1,113 @@
+package akp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+func NewAkpTeamMembersResource() resource.Resource {
+	return &GenericResource[types.TeamMembers]{
+		TypeNameSuffix: "team_members",
+		SchemaFunc:     teamMembersSchema,
+		CreateFunc:     teamMembersApply,
+		ReadFunc:       teamMembersRead,
+		UpdateFunc:     teamMembersApply,
+		DeleteFunc:     teamMembersDelete,
+		ImportStateFunc: func(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+			// Import ID: <team_name>.
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_name"), req.ID)...)
+		},
+	}
+}
+
+// teamMembersApply converges the team onto the planned emails: missing users
+// are added and users not in the plan are removed.
+func teamMembersApply(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, plan *types.TeamMembers) (*types.TeamMembers, error) {
+	teamName := plan.TeamName.ValueString()
+	members, err := listTeamMembers(ctx, cli, teamName)
+	if err != nil {
+		return nil, err
+	}
+	desired := stringSliceFromTF(plan.Emails)
+	for _, email := range desired {
+		if findTeamMember(members, email) != nil {
+			continue
+		}
+		if _, err := addTeamMember(ctx, cli, teamName, email); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range members {
+		if containsEmail(desired, m.GetEmail()) {
+			continue
+		}
+		if err := removeTeamMember(ctx, cli, teamName, m.GetId()); err != nil {
+			return nil, err
+		}
+	}
+	plan.ID = tftypes.StringValue(teamName)
+	return plan, nil
+}
+
+// teamMembersRead reports the team's current users so that users added or
+// removed outside Terraform show up as drift. Emails already in state keep
+// their configured casing.
+func teamMembersRead(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, data *types.TeamMembers) error {
+	members, err := listTeamMembers(ctx, cli, data.TeamName.ValueString())
+	if err != nil {
+		return err
+	}
+	current := stringSliceFromTF(data.Emails)
+	emails := make([]tftypes.String, 0, len(members))
+	for _, m := range members {
+		email := m.GetEmail()
+		for _, c := range current {
+			if strings.EqualFold(c, email) {
+				email = c
+				break
+			}
+		}
+		emails = append(emails, tftypes.StringValue(email))
+	}
+	data.ID = tftypes.StringValue(data.TeamName.ValueString())
+	data.Emails = emails
+	return nil
+}
+
+func teamMembersDelete(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, state *types.TeamMembers) error {
+	teamName := state.TeamName.ValueString()
+	members, err := listTeamMembers(ctx, cli, teamName)
+	if err != nil {
+		// A deleted team takes its members with it.
+		if isGoneErr(err) {
+			return nil
+		}
+		return err
+	}
+	managed := stringSliceFromTF(state.Emails)
+	for _, m := range members {
+		if !containsEmail(managed, m.GetEmail()) {
+			continue
+		}
+		if err := removeTeamMember(ctx, cli, teamName, m.GetId()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsEmail(emails []string, email string) bool {
+	for _, e := range emails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package akp
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func teamMembersSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Authoritatively manages the users of an Akuity Platform organization team. Users not listed in `emails` are removed from the team, including users added in the UI. Do not combine with `akp_team_member` for the same team.",
+		Attributes:          getTeamMembersAttributes(),
+	}
+}
+
+func getTeamMembersAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Identifier of the resource, equal to `team_name`",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"team_name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Name of the team whose members are managed",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"emails": schema.SetAttribute{
+			Required:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Emails of the users in the team. The users must already be members of the organization. An empty set removes every user from the team.",
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+			},
+		},
+	}
+}
@@ -0,0 +1,226 @@
+package akp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+
+	orgcv1 "github.com/akuity/api-client-go/pkg/api/gen/organization/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+func NewAkpWorkspaceMembersResource() resource.Resource {
+	return &GenericResource[types.WorkspaceMembers]{
+		TypeNameSuffix: "workspace_members",
+		SchemaFunc:     workspaceMembersSchema,
+		CreateFunc:     workspaceMembersApply,
+		ReadFunc:       workspaceMembersRead,
+		UpdateFunc:     workspaceMembersApply,
+		DeleteFunc:     workspaceMembersDelete,
+		ImportStateFunc: func(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+			// Import ID: <workspace_name>.
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace"), req.ID)...)
+		},
+	}
+}
+
+// workspaceMembersApply converges the workspace onto the planned members.
+// Adds and role changes run before removals so the workspace always keeps at
+// least one member, which the backend enforces.
+func workspaceMembersApply(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, plan *types.WorkspaceMembers) (*types.WorkspaceMembers, error) {
+	if err := requireKnownWorkspace(plan.Workspace, "workspace_members"); err != nil {
+		return nil, err
+	}
+	workspace, err := getWorkspace(ctx, cli.OrgCli, cli.OrgId, plan.Workspace.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve workspace %q: %w", plan.Workspace.ValueString(), err)
+	}
+	workspaceID := workspace.GetId()
+	current, err := listWorkspaceMembers(ctx, cli, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := make(map[string]bool, len(plan.Members))
+	for i := range plan.Members {
+		entry := &plan.Members[i]
+		key := workspaceMembersEntryKey(entry.UserEmail.ValueString(), entry.TeamName.ValueString())
+		desired[key] = true
+		role, err := workspaceMemberRoleFromString(entry.Role.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		existing := current[key]
+		switch {
+		case existing == nil:
+			if err := addWorkspaceMembersEntry(ctx, cli, workspaceID, entry, role); err != nil {
+				return nil, err
+			}
+		case existing.GetRole() != role:
+			if _, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.UpdateWorkspaceMemberResponse, error) {
+				return cli.OrgCli.UpdateWorkspaceMember(ctx, &orgcv1.UpdateWorkspaceMemberRequest{
+					OrganizationId: cli.OrgId,
+					WorkspaceId:    workspaceID,
+					Id:             existing.GetId(),
+					Role:           role,
+				})
+			}, "UpdateWorkspaceMember"); err != nil {
+				return nil, fmt.Errorf("unable to update workspace member: %w", err)
+			}
+		}
+	}
+	for key, m := range current {
+		if desired[key] {
+			continue
+		}
+		if err := removeWorkspaceMembersEntry(ctx, cli, workspaceID, m.GetId()); err != nil {
+			return nil, err
+		}
+	}
+
+	plan.ID = tftypes.StringValue(workspaceID)
+	plan.WorkspaceID = tftypes.StringValue(workspaceID)
+	return plan, nil
+}
+
+// workspaceMembersRead replaces the member set with the workspace's current
+// members, so members added or removed outside Terraform show up as drift.
+// Emails that match an entry already in state keep their configured casing.
+func workspaceMembersRead(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, data *types.WorkspaceMembers) error {
+	workspace, err := getWorkspace(ctx, cli.OrgCli, cli.OrgId, data.Workspace.ValueString())
+	if err != nil {
+		return err
+	}
+	workspaceID := workspace.GetId()
+	current, err := listWorkspaceMembers(ctx, cli, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	emails := make(map[string]tftypes.String, len(data.Members))
+	for _, entry := range data.Members {
+		if !entry.UserEmail.IsNull() {
+			emails[strings.ToLower(entry.UserEmail.ValueString())] = entry.UserEmail
+		}
+	}
+	members := make([]types.WorkspaceMembersEntry, 0, len(current))
+	for _, m := range current {
+		entry := types.WorkspaceMembersEntry{
+			Role:      tftypes.StringValue(workspaceMemberRoleToString(m.GetRole())),
+			UserEmail: tftypes.StringNull(),
+			TeamName:  tftypes.StringNull(),
+		}
+		switch member := m.GetMember().(type) {
+		case *orgcv1.WorkspaceMember_User:
+			entry.UserEmail = hydrateIfUnset(emails[strings.ToLower(member.User.GetEmail())], member.User.GetEmail())
+		case *orgcv1.WorkspaceMember_Team:
+			entry.TeamName = tftypes.StringValue(member.Team.GetName())
+		}
+		members = append(members, entry)
+	}
+
+	data.ID = tftypes.StringValue(workspaceID)
+	data.WorkspaceID = tftypes.StringValue(workspaceID)
+	data.Members = members
+	return nil
+}
+
+func workspaceMembersDelete(ctx context.Context, cli *AkpCli, _ *diag.Diagnostics, state *types.WorkspaceMembers) error {
+	workspaceID := state.WorkspaceID.ValueString()
+	current, err := listWorkspaceMembers(ctx, cli, workspaceID)
+	if err != nil {
+		// A deleted workspace takes its members with it.
+		if isGoneErr(err) {
+			return nil
+		}
+		return err
+	}
+	for _, entry := range state.Members {
+		m := current[workspaceMembersEntryKey(entry.UserEmail.ValueString(), entry.TeamName.ValueString())]
+		if m == nil {
+			continue
+		}
+		if err := removeWorkspaceMembersEntry(ctx, cli, workspaceID, m.GetId()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listWorkspaceMembers returns the workspace's members keyed by
+// workspaceMembersEntryKey.
+func listWorkspaceMembers(ctx context.Context, cli *AkpCli, workspaceID string) (map[string]*orgcv1.WorkspaceMember, error) {
+	resp, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.ListWorkspaceMembersResponse, error) {
+		return cli.OrgCli.ListWorkspaceMembers(ctx, &orgcv1.ListWorkspaceMembersRequest{
+			OrganizationId: cli.OrgId,
+			WorkspaceId:    workspaceID,
+		})
+	}, "ListWorkspaceMembers")
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string]*orgcv1.WorkspaceMember, len(resp.GetWorkspaceMembers()))
+	for _, m := range resp.GetWorkspaceMembers() {
+		switch member := m.GetMember().(type) {
+		case *orgcv1.WorkspaceMember_User:
+			members[workspaceMembersEntryKey(member.User.GetEmail(), "")] = m
+		case *orgcv1.WorkspaceMember_Team:
+			members[workspaceMembersEntryKey("", member.Team.GetName())] = m
+		}
+	}
+	return members, nil
+}
+
+// workspaceMembersEntryKey identifies a member by its user email or team name.
+// Emails are compared case-insensitively, as the backend canonicalizes them.
+func workspaceMembersEntryKey(userEmail, teamName string) string {
+	if userEmail != "" {
+		return "user:" + strings.ToLower(userEmail)
+	}
+	return "team:" + teamName
+}
+
+func addWorkspaceMembersEntry(ctx context.Context, cli *AkpCli, workspaceID string, entry *types.WorkspaceMembersEntry, role orgcv1.WorkspaceMemberRole) error {
+	ref, err := buildWorkspaceMemberRef(&types.WorkspaceMember{UserEmail: entry.UserEmail, TeamName: entry.TeamName}, role)
+	if err != nil {
+		return err
+	}
+	_, err = retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.AddWorkspaceMemberResponse, error) {
+		return cli.OrgCli.AddWorkspaceMember(ctx, &orgcv1.AddWorkspaceMemberRequest{
+			OrganizationId: cli.OrgId,
+			WorkspaceId:    workspaceID,
+			MemberRef:      ref,
+		})
+	}, "AddWorkspaceMember")
+	if err != nil {
+		return fmt.Errorf("unable to add workspace member: %w", err)
+	}
+	return nil
+}
+
+// removeWorkspaceMembersEntry removes a member, treating the backend's
+// last-member rule as success like akp_workspace_member does: it can only
+// trigger while the workspace itself is being destroyed, since apply adds
+// members before removing any.
+func removeWorkspaceMembersEntry(ctx context.Context, cli *AkpCli, workspaceID, id string) error {
+	_, err := retryWithBackoff(ctx, func(ctx context.Context) (*orgcv1.RemoveWorkspaceMemberResponse, error) {
+		resp, err := cli.OrgCli.RemoveWorkspaceMember(ctx, &orgcv1.RemoveWorkspaceMemberRequest{
+			OrganizationId: cli.OrgId,
+			WorkspaceId:    workspaceID,
+			Id:             id,
+		})
+		if isGoneErr(err) || isLastWorkspaceMemberErr(err) {
+			return resp, nil
+		}
+		return resp, err
+	}, "RemoveWorkspaceMember")
+	if err != nil {
+		return fmt.Errorf("unable to remove workspace member: %w", err)
+	}
+	return nil
+}
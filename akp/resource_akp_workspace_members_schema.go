@@ -0,0 +1,81 @@
+package akp
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func workspaceMembersSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Authoritatively manages the members of an Akuity Platform workspace. Members not listed in `members`, including members added in the UI, are removed from the workspace. Do not combine with `akp_workspace_member` for the same workspace.",
+		Attributes:          getWorkspaceMembersAttributes(),
+	}
+}
+
+func getWorkspaceMembersAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Identifier of the resource, equal to `workspace_id`",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"workspace": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Name of the workspace whose members are managed",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"workspace_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "ID of the workspace (resolved from `workspace`)",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"members": schema.SetNestedAttribute{
+			Required:            true,
+			MarkdownDescription: "Members of the workspace. A workspace cannot be left without members, so at least one is required.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: getWorkspaceMembersEntryAttributes(),
+			},
+		},
+	}
+}
+
+func getWorkspaceMembersEntryAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"role": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Role granted to the member on the workspace. One of `member` or `admin`.",
+			Validators: []validator.String{
+				stringvalidator.OneOf("member", "admin"),
+			},
+		},
+		"user_email": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Email of the user to add as a member. Mutually exclusive with `team_name`.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("team_name")),
+			},
+		},
+		"team_name": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Name of the team to add as a member. Mutually exclusive with `user_email`.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !acc
+
+package akp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// If this test fails, a field has been added/removed to types.WorkspaceMembers.
+// Update the schema attribute accordingly.
+func TestNoNewWorkspaceMembersFields(t *testing.T) {
+	assert.Equal(t, reflect.TypeFor[types.WorkspaceMembers]().NumField(), len(getWorkspaceMembersAttributes()))
+	assert.Equal(t, reflect.TypeFor[types.WorkspaceMembersEntry]().NumField(), len(getWorkspaceMembersEntryAttributes()))
+}
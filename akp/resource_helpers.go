@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand/v2"
+	"net"
 	"slices"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -14,6 +17,69 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// statusTimelineMaxEntries bounds the timeline reported in wait errors, so a
+// status that flaps for the whole timeout does not flood the diagnostic.
+const statusTimelineMaxEntries = 10
+
+type statusTimelineEntry[StatusCodeType comparable] struct {
+	status  StatusCodeType
+	message string
+	since   time.Time
+}
+
+// statusTimeline records each distinct status and message observed while
+// waiting on a resource, so that wait errors can explain why the target status
+// was never reached without having to re-run with debug logging.
+type statusTimeline[StatusCodeType comparable] struct {
+	entries []statusTimelineEntry[StatusCodeType]
+	dropped int
+}
+
+// observe records status and message as seen at now. Consecutive observations
+// of the same status and message extend the current entry.
+func (t *statusTimeline[StatusCodeType]) observe(status StatusCodeType, message string, now time.Time) {
+	if n := len(t.entries); n > 0 && t.entries[n-1].status == status && t.entries[n-1].message == message {
+		return
+	}
+	t.entries = append(t.entries, statusTimelineEntry[StatusCodeType]{status: status, message: message, since: now})
+	if len(t.entries) > statusTimelineMaxEntries {
+		t.entries = t.entries[1:]
+		t.dropped++
+	}
+}
+
+// format renders the timeline, with the last entry held until end. It returns
+// an empty string if nothing was observed.
+func (t *statusTimeline[StatusCodeType]) format(end time.Time) string {
+	if len(t.entries) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Status timeline:")
+	if t.dropped > 0 {
+		fmt.Fprintf(&b, "\n  ... %d earlier status changes omitted", t.dropped)
+	}
+	for i, e := range t.entries {
+		until := end
+		if i+1 < len(t.entries) {
+			until = t.entries[i+1].since
+		}
+		fmt.Fprintf(&b, "\n  - %v for %v", e.status, until.Sub(e.since).Round(time.Second))
+		if e.message != "" {
+			fmt.Fprintf(&b, ": %s", e.message)
+		}
+	}
+	return b.String()
+}
+
+// withTimeline appends the rendered timeline to err, if anything was observed.
+func (t *statusTimeline[StatusCodeType]) withTimeline(err error, end time.Time) error {
+	if timeline := t.format(end); timeline != "" {
+		return fmt.Errorf("%w\n\n%s", err, timeline)
+	}
+	return err
+}
+
 func waitForStatus[ResourceType any, StatusCodeType comparable](
 	ctx context.Context,
 	getResourceFunc func(ctx context.Context) (ResourceType, error),
@@ -23,6 +89,31 @@ func waitForStatus[ResourceType any, StatusCodeType comparable](
 	timeout time.Duration,
 	resourceName string,
 	statusName string,
+) error {
+	return waitForStatusWithMessage(
+		ctx,
+		getResourceFunc,
+		func(resource ResourceType) (StatusCodeType, string) { return getStatusFunc(resource), "" },
+		targetStatuses,
+		pollInterval,
+		timeout,
+		resourceName,
+		statusName,
+	)
+}
+
+// waitForStatusWithMessage is waitForStatus for resources that report a status
+// message alongside the status code. The messages are included in the status
+// timeline of the error returned when the target status is not reached.
+func waitForStatusWithMessage[ResourceType any, StatusCodeType comparable](
+	ctx context.Context,
+	getResourceFunc func(ctx context.Context) (ResourceType, error),
+	getStatusFunc func(resource ResourceType) (StatusCodeType, string),
+	targetStatuses []StatusCodeType,
+	pollInterval time.Duration,
+	timeout time.Duration,
+	resourceName string,
+	statusName string,
 ) error {
 	tflog.Debug(ctx, fmt.Sprintf("Waiting for %s %s status to reach one of %v", resourceName, statusName, targetStatuses))
 
@@ -36,15 +127,16 @@ func waitForStatus[ResourceType any, StatusCodeType comparable](
 	startTime := time.Now()
 	lastStatusLog := time.Now()
 	var lastStatus StatusCodeType
+	var timeline statusTimeline[StatusCodeType]
 
 	for {
 		select {
 		case <-waitCtx.Done():
 			elapsed := time.Since(startTime)
 			if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
-				return fmt.Errorf("timed out after %v waiting for %s %s status (expected: %v, last seen: %v)", timeout, resourceName, statusName, targetStatuses, lastStatus)
+				return timeline.withTimeline(fmt.Errorf("timed out after %v waiting for %s %s status (expected: %v, last seen: %v)", timeout, resourceName, statusName, targetStatuses, lastStatus), time.Now())
 			}
-			return fmt.Errorf("context cancelled/done while waiting for %s %s status after %v: %w", resourceName, statusName, elapsed, waitCtx.Err())
+			return timeline.withTimeline(fmt.Errorf("context cancelled/done while waiting for %s %s status after %v: %w", resourceName, statusName, elapsed, waitCtx.Err()), time.Now())
 		default:
 		}
 
@@ -56,11 +148,12 @@ func waitForStatus[ResourceType any, StatusCodeType comparable](
 			} else {
 				elapsed := time.Since(startTime)
 				tflog.Error(ctx, fmt.Sprintf("Failed to get %s during status wait after %v: %v", resourceName, elapsed, err))
-				return fmt.Errorf("failed to get %s during status wait: %w", resourceName, err)
+				return timeline.withTimeline(fmt.Errorf("failed to get %s during status wait: %w", resourceName, err), time.Now())
 			}
 		} else {
-			currentStatus := getStatusFunc(resource)
+			currentStatus, message := getStatusFunc(resource)
 			lastStatus = currentStatus
+			timeline.observe(currentStatus, message, time.Now())
 
 			if time.Since(lastStatusLog) >= 30*time.Second {
 				elapsed := time.Since(startTime)
@@ -86,6 +179,19 @@ func waitForStatus[ResourceType any, StatusCodeType comparable](
 	}
 }
 
+// combineStatusMessages joins the health and reconciliation messages of a
+// resource into a single status timeline message.
+func combineStatusMessages(health, reconciliation string) string {
+	switch {
+	case reconciliation == "" || reconciliation == health:
+		return health
+	case health == "":
+		return "reconciliation: " + reconciliation
+	default:
+		return health + "; reconciliation: " + reconciliation
+	}
+}
+
 // isGoneErr reports whether err indicates the resource is no longer accessible
 // to the caller — either because it was deleted (NotFound) or because the
 // server revokes ACL access for deleted records and surfaces it as
@@ -104,31 +210,41 @@ func isGoneErr(err error) bool {
 	}
 }
 
-// retryWithBackoff executes a function with exponential backoff retry logic
+// retryWithBackoff executes a function with exponential backoff retry logic.
+// The number of retries and their delay follow the request policy of ctx.
 func retryWithBackoff[T any](
 	ctx context.Context,
 	operation func(ctx context.Context) (T, error),
 	operationName string,
 ) (T, error) {
 	const (
-		maxRetries    = 5
 		initialDelay  = 500 * time.Millisecond
-		maxDelay      = 30 * time.Second
 		backoffFactor = 2.0
 	)
+	policy := requestPolicyFrom(ctx)
+	maxRetries := policy.maxRetries
 
 	var result T
 	var lastErr error
-	delay := initialDelay
+	var retryAfter *retryAfterHint
+	delay := min(initialDelay, policy.maxDelay)
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
 			// Apply jitter to prevent thundering herd (10% jitter)
-			jitterRange := time.Duration(float64(delay) * 0.1)
-			jitter := time.Duration(rand.Int64N(int64(jitterRange*2))) - jitterRange
-			actualDelay := delay + jitter
-			if actualDelay < 0 {
-				actualDelay = delay
+			actualDelay := delay
+			if jitterRange := time.Duration(float64(delay) * 0.1); jitterRange > 0 {
+				jitter := time.Duration(rand.Int64N(int64(jitterRange*2))) - jitterRange
+				actualDelay = max(delay+jitter, 0)
+			}
+			// The API may ask for a longer delay, typically when rate limiting
+			// with ResourceExhausted, in the error or a Retry-After header.
+			hint, ok := retryDelayHint(lastErr)
+			if !ok {
+				hint, ok = retryAfter.get()
+			}
+			if ok {
+				actualDelay = min(max(actualDelay, hint), policy.maxDelay)
 			}
 
 			tflog.Debug(ctx, fmt.Sprintf("Retrying %s (attempt %d/%d) after %v", operationName, attempt, maxRetries, actualDelay))
@@ -141,7 +257,9 @@ func retryWithBackoff[T any](
 			}
 		}
 
-		result, lastErr = operation(ctx)
+		attemptCtx, hint := withRetryAfterHint(ctx)
+		result, lastErr = operation(attemptCtx)
+		retryAfter = hint
 		if lastErr == nil {
 			if attempt > 0 {
 				tflog.Info(ctx, fmt.Sprintf("%s succeeded after %d retries", operationName, attempt))
@@ -158,7 +276,7 @@ func retryWithBackoff[T any](
 		tflog.Debug(ctx, fmt.Sprintf("%s failed with retryable error (attempt %d/%d): %v", operationName, attempt+1, maxRetries+1, lastErr))
 
 		// Exponential backoff with cap
-		delay = min(time.Duration(float64(delay)*backoffFactor), maxDelay)
+		delay = min(time.Duration(float64(delay)*backoffFactor), policy.maxDelay)
 	}
 
 	tflog.Error(ctx, fmt.Sprintf("%s failed after %d retries, last error: %v", operationName, maxRetries+1, lastErr))
@@ -193,21 +311,21 @@ func isRetryableError(err error) bool {
 		}
 	}
 
-	// For non-gRPC errors, be conservative and retry only on clearly temporary issues
-	errStr := err.Error()
-
-	// Network-related errors that are usually temporary
-	if strings.Contains(errStr, "connection refused") ||
-		strings.Contains(errStr, "connection reset") ||
-		strings.Contains(errStr, "timeout") ||
-		strings.Contains(errStr, "temporary failure") ||
-		strings.Contains(errStr, "network is unreachable") ||
-		strings.Contains(errStr, "no route to host") ||
-		strings.Contains(errStr, "service unavailable") {
+	// For non-gRPC errors, be conservative and retry only on network errors
+	// that are usually temporary
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
 		return true
 	}
-
-	return false
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsTemporary {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ENETUNREACH) ||
+		errors.Is(err, syscall.EHOSTUNREACH) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
 }
 
 // isLastWorkspaceMemberErr reports whether err is the backend's refusal to
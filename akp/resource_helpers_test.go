@@ -3,10 +3,13 @@
 package akp
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -32,3 +35,80 @@ func TestIsLastWorkspaceMemberErr(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusTimeline(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var timeline statusTimeline[string]
+	assert.Empty(t, timeline.format(start))
+
+	timeline.observe("PROGRESSING", "", start)
+	timeline.observe("PROGRESSING", "", start.Add(10*time.Second))
+	timeline.observe("DEGRADED", "agent is not connected", start.Add(40*time.Second))
+	timeline.observe("DEGRADED", "agent is not connected", start.Add(2*time.Minute))
+	timeline.observe("DEGRADED", "repo server is crash looping", start.Add(3*time.Minute))
+
+	assert.Equal(t, `Status timeline:
+  - PROGRESSING for 40s
+  - DEGRADED for 2m20s: agent is not connected
+  - DEGRADED for 2m0s: repo server is crash looping`, timeline.format(start.Add(5*time.Minute)))
+}
+
+func TestStatusTimelineDropsOldestEntries(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var timeline statusTimeline[int]
+	for i := range statusTimelineMaxEntries + 2 {
+		timeline.observe(i, "", start.Add(time.Duration(i)*time.Second))
+	}
+
+	formatted := timeline.format(start.Add(time.Minute))
+	assert.Contains(t, formatted, "... 2 earlier status changes omitted")
+	assert.NotContains(t, formatted, "- 1 for")
+	assert.Contains(t, formatted, "- 2 for 1s")
+	assert.Contains(t, formatted, fmt.Sprintf("- %d for 49s", statusTimelineMaxEntries+1))
+}
+
+func TestWaitForStatusWithMessageTimeout(t *testing.T) {
+	statuses := []string{"PROGRESSING", "DEGRADED"}
+	polls := 0
+	err := waitForStatusWithMessage(
+		context.Background(),
+		func(context.Context) (string, error) {
+			status := statuses[min(polls, len(statuses)-1)]
+			polls++
+			return status, nil
+		},
+		func(status string) (string, string) {
+			if status == "DEGRADED" {
+				return status, "agent is not connected"
+			}
+			return status, ""
+		},
+		[]string{"HEALTHY"},
+		10*time.Millisecond,
+		100*time.Millisecond,
+		"Cluster test",
+		"health",
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out after 100ms waiting for Cluster test health status (expected: [HEALTHY], last seen: DEGRADED)")
+	assert.Contains(t, err.Error(), "Status timeline:\n  - PROGRESSING for 0s\n  - DEGRADED for ")
+	assert.Contains(t, err.Error(), ": agent is not connected")
+}
+
+func TestCombineStatusMessages(t *testing.T) {
+	testCases := map[string]struct {
+		health, reconciliation, expected string
+	}{
+		"none":                {expected: ""},
+		"health only":         {health: "agent is not connected", expected: "agent is not connected"},
+		"reconciliation only": {reconciliation: "invalid config", expected: "reconciliation: invalid config"},
+		"both":                {health: "degraded", reconciliation: "invalid config", expected: "degraded; reconciliation: invalid config"},
+		"same message":        {health: "invalid config", reconciliation: "invalid config", expected: "invalid config"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, combineStatusMessages(tc.health, tc.reconciliation))
+		})
+	}
+}
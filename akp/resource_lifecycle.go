@@ -2,6 +2,7 @@ package akp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
@@ -10,6 +11,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
 )
 
 // ResourceLifecycle encapsulates the common Apply → Wait → Refresh pipeline
@@ -22,6 +25,9 @@ type ResourceLifecycle[Plan any, APIResponse any, StatusCode comparable] struct
 	Get func(ctx context.Context, plan *Plan) (APIResponse, error)
 	// GetStatus extracts the status code from the API response.
 	GetStatus func(resp APIResponse) StatusCode
+	// GetStatusMessage extracts the status message from the API response, if
+	// available. Messages are reported in the status timeline of wait errors.
+	GetStatusMessage func(resp APIResponse) string
 	// GetGeneration extracts the generation or version number from the API response, if available.
 	GetGeneration func(resp APIResponse) uint32
 	// GetReconciliationDone returns true when the controller has successfully
@@ -42,6 +48,10 @@ type ResourceLifecycle[Plan any, APIResponse any, StatusCode comparable] struct
 	PollInterval time.Duration
 	// Timeout is the maximum time to wait for the target status. Default: 5m.
 	Timeout time.Duration
+	// WaitFor selects the phases of the wait: types.WaitForNone skips it,
+	// types.WaitForReconciled only waits for reconciliation and
+	// types.WaitForHealthy also waits for TargetStatuses. Default: healthy.
+	WaitFor string
 }
 
 // Upsert executes the full Apply → Wait → Refresh pipeline.
@@ -73,17 +83,25 @@ func (lc *ResourceLifecycle[Plan, APIResponse, StatusCode]) Upsert(ctx context.C
 		timeout = 5 * time.Minute
 	}
 
+	waitFor := lc.WaitFor
+	if waitFor == "" {
+		waitFor = types.WaitForHealthy
+	}
+
 	resourceName := lc.ResourceName(plan)
 
 	var waitErr error
+	reconciledOnly := !waitsFor(waitFor, types.WaitForAgentConnected) && lc.GetReconciliationDone != nil
 	useGenerationWait := lc.GetGeneration != nil && lc.GetReconciliationDone != nil && preApplyGeneration > 0
-	if useGenerationWait {
-		waitErr = lc.waitForReconciliation(ctx, plan, preApplyGeneration, pollInterval, timeout, resourceName)
+	if waitFor == types.WaitForNone {
+		tflog.Debug(ctx, fmt.Sprintf("%s: not waiting for the change to be reconciled", resourceName))
+	} else if useGenerationWait || reconciledOnly {
+		waitErr = lc.waitForReconciliation(ctx, plan, preApplyGeneration, pollInterval, timeout, resourceName, !reconciledOnly)
 	} else {
-		waitErr = waitForStatus(
+		waitErr = waitForStatusWithMessage(
 			ctx,
 			func(ctx context.Context) (APIResponse, error) { return lc.Get(ctx, plan) },
-			lc.GetStatus,
+			func(resp APIResponse) (StatusCode, string) { return lc.GetStatus(resp), lc.statusMessage(resp) },
 			lc.TargetStatuses,
 			pollInterval,
 			timeout,
@@ -104,6 +122,13 @@ func (lc *ResourceLifecycle[Plan, APIResponse, StatusCode]) Upsert(ctx context.C
 	return true, lc.Refresh(ctx, diagnostics, plan)
 }
 
+func (lc *ResourceLifecycle[Plan, APIResponse, StatusCode]) statusMessage(resp APIResponse) string {
+	if lc.GetStatusMessage == nil {
+		return ""
+	}
+	return lc.GetStatusMessage(resp)
+}
+
 const (
 	reconGracePeriod       = 15 * time.Second
 	reconGracePollInterval = 2 * time.Second
@@ -116,6 +141,7 @@ func (lc *ResourceLifecycle[Plan, APIResponse, StatusCode]) waitForReconciliatio
 	pollInterval time.Duration,
 	timeout time.Duration,
 	resourceName string,
+	requireTarget bool,
 ) error {
 	waitCtx := ctx
 	if _, deadlineSet := ctx.Deadline(); !deadlineSet {
@@ -127,6 +153,7 @@ func (lc *ResourceLifecycle[Plan, APIResponse, StatusCode]) waitForReconciliatio
 	startTime := time.Now()
 	lastStatusLog := time.Now()
 	sawReconciling := false
+	var timeline statusTimeline[StatusCode]
 
 	tflog.Info(ctx, fmt.Sprintf("%s waiting for reconciliation (pre-apply generation: %d)", resourceName, preApplyGeneration))
 
@@ -134,10 +161,10 @@ func (lc *ResourceLifecycle[Plan, APIResponse, StatusCode]) waitForReconciliatio
 		select {
 		case <-waitCtx.Done():
 			elapsed := time.Since(startTime)
-			if ctx.Err() != nil {
-				return fmt.Errorf("context cancelled while waiting for %s after %v", resourceName, elapsed)
+			if !errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+				return timeline.withTimeline(fmt.Errorf("context cancelled while waiting for %s after %v", resourceName, elapsed), time.Now())
 			}
-			return fmt.Errorf("timed out after %v waiting for %s reconciliation", timeout, resourceName)
+			return timeline.withTimeline(fmt.Errorf("timed out after %v waiting for %s reconciliation", timeout, resourceName), time.Now())
 		default:
 		}
 
@@ -147,14 +174,18 @@ func (lc *ResourceLifecycle[Plan, APIResponse, StatusCode]) waitForReconciliatio
 			if ok && st.Code() == codes.NotFound {
 				tflog.Debug(ctx, fmt.Sprintf("%s not found yet, retrying...", resourceName))
 			} else {
-				return fmt.Errorf("failed to get %s during reconciliation wait: %w", resourceName, err)
+				return timeline.withTimeline(fmt.Errorf("failed to get %s during reconciliation wait: %w", resourceName, err), time.Now())
 			}
 		} else {
 			currentStatus := lc.GetStatus(resp)
-			currentGen := lc.GetGeneration(resp)
-			isTarget := slices.Contains(lc.TargetStatuses, currentStatus)
+			var currentGen uint32
+			if lc.GetGeneration != nil {
+				currentGen = lc.GetGeneration(resp)
+			}
+			isTarget := !requireTarget || slices.Contains(lc.TargetStatuses, currentStatus)
 			reconDone := lc.GetReconciliationDone(resp)
 			genAdvanced := currentGen > preApplyGeneration
+			timeline.observe(currentStatus, lc.statusMessage(resp), time.Now())
 
 			if !reconDone {
 				sawReconciling = true
@@ -175,7 +206,7 @@ func (lc *ResourceLifecycle[Plan, APIResponse, StatusCode]) waitForReconciliatio
 			}
 
 			if lc.GetReconciliationFailed != nil && lc.GetReconciliationFailed(resp) {
-				return fmt.Errorf("%s reconciliation failed (health status: %v)", resourceName, currentStatus)
+				return timeline.withTimeline(fmt.Errorf("%s reconciliation failed (health status: %v)", resourceName, currentStatus), time.Now())
 			}
 
 			if reconcileConfirmed && reconDone && isTarget {
@@ -0,0 +1,30 @@
+package akp
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// Default operation timeouts, used when the `timeouts` block leaves a value unset.
+const (
+	defaultInstanceTimeout       = 5 * time.Minute
+	defaultInstanceDeleteTimeout = 2 * time.Minute
+	defaultClusterTimeout        = 10 * time.Minute
+	defaultKargoAgentTimeout     = 5 * time.Minute
+	// agentDriftCheckTimeout bounds the agent drift check made on refresh,
+	// which has no `timeouts` entry of its own.
+	agentDriftCheckTimeout = 2 * time.Minute
+)
+
+// getTimeoutsBlock returns the `timeouts { create, update, delete }` block shared by
+// resources that wait on the platform to converge.
+func getTimeoutsBlock() schema.Block {
+	return timeouts.Block(context.Background(), timeouts.Opts{
+		Create: true,
+		Update: true,
+		Delete: true,
+	})
+}
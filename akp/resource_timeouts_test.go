@@ -0,0 +1,27 @@
+//go:build !acc
+
+package akp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+func TestUnsetTimeoutsFallBackToDefaults(t *testing.T) {
+	ctx := context.Background()
+	plan := &types.Cluster{}
+
+	create, d := plan.Timeouts.Create(ctx, defaultClusterTimeout)
+	require.False(t, d.HasError())
+	assert.Equal(t, defaultClusterTimeout, create)
+
+	del, d := plan.Timeouts.Delete(ctx, time.Minute)
+	require.False(t, d.HasError())
+	assert.Equal(t, time.Minute, del)
+}
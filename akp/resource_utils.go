@@ -7,7 +7,9 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -110,3 +112,11 @@ func handleReadResourceError(ctx context.Context, resp *resource.ReadResponse, e
 		resp.Diagnostics.AddError("Client Error", err.Error())
 	}
 }
+
+// readWriteOnlyMaps reads the write-only map attributes named by the keys of
+// attrs from the configuration into the plan fields they point to.
+func readWriteOnlyMaps(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics, attrs map[string]*types.Map) {
+	for name, target := range attrs {
+		diags.Append(config.GetAttribute(ctx, path.Root(name), target)...)
+	}
+}
@@ -0,0 +1,54 @@
+package akp
+
+import (
+	"slices"
+
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+
+	healthv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/health/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+// waitForModes are the values of the `wait_for` attribute, ordered from the
+// weakest to the strongest guarantee.
+var waitForModes = []string{
+	types.WaitForNone,
+	types.WaitForReconciled,
+	types.WaitForAgentConnected,
+	types.WaitForHealthy,
+}
+
+// instanceWaitForModes are the `wait_for` values of instances, which have no
+// agent of their own to wait for.
+var instanceWaitForModes = []string{
+	types.WaitForNone,
+	types.WaitForReconciled,
+	types.WaitForHealthy,
+}
+
+// waitForMode returns the configured `wait_for` mode, or def when it is unset.
+func waitForMode(waitFor tftypes.String, def string) string {
+	if isKnownNonEmptyString(waitFor) {
+		return waitFor.ValueString()
+	}
+	return def
+}
+
+// waitsFor reports whether the wait selected by mode includes phase.
+func waitsFor(mode, phase string) bool {
+	return slices.Index(waitForModes, mode) >= slices.Index(waitForModes, phase)
+}
+
+// agentHealthTargetStatuses returns the agent health statuses that end the
+// wait selected by mode, or nil if it does not wait for the agent. A degraded
+// agent has connected, it just is not healthy.
+func agentHealthTargetStatuses(mode string) []healthv1.StatusCode {
+	switch {
+	case waitsFor(mode, types.WaitForHealthy):
+		return []healthv1.StatusCode{healthv1.StatusCode_STATUS_CODE_HEALTHY}
+	case waitsFor(mode, types.WaitForAgentConnected):
+		return []healthv1.StatusCode{healthv1.StatusCode_STATUS_CODE_HEALTHY, healthv1.StatusCode_STATUS_CODE_DEGRADED}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+//go:build !acc
+
+package akp
+
+import (
+	"testing"
+
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	healthv1 "github.com/akuity/api-client-go/pkg/api/gen/types/status/health/v1"
+	"github.com/akuity/terraform-provider-akp/akp/types"
+)
+
+func TestWaitsFor(t *testing.T) {
+	assert.True(t, waitsFor(types.WaitForHealthy, types.WaitForReconciled))
+	assert.True(t, waitsFor(types.WaitForHealthy, types.WaitForAgentConnected))
+	assert.True(t, waitsFor(types.WaitForAgentConnected, types.WaitForAgentConnected))
+	assert.False(t, waitsFor(types.WaitForReconciled, types.WaitForAgentConnected))
+	assert.False(t, waitsFor(types.WaitForNone, types.WaitForReconciled))
+}
+
+func TestAgentHealthTargetStatuses(t *testing.T) {
+	assert.Nil(t, agentHealthTargetStatuses(types.WaitForNone))
+	assert.Nil(t, agentHealthTargetStatuses(types.WaitForReconciled))
+	assert.Equal(t, []healthv1.StatusCode{healthv1.StatusCode_STATUS_CODE_HEALTHY, healthv1.StatusCode_STATUS_CODE_DEGRADED}, agentHealthTargetStatuses(types.WaitForAgentConnected))
+	assert.Equal(t, []healthv1.StatusCode{healthv1.StatusCode_STATUS_CODE_HEALTHY}, agentHealthTargetStatuses(types.WaitForHealthy))
+}
+
+func TestClusterWaitFor(t *testing.T) {
+	testCases := map[string]struct {
+		plan     types.Cluster
+		expected string
+	}{
+		"default": {
+			plan:     types.Cluster{WaitFor: tftypes.StringNull()},
+			expected: types.WaitForReconciled,
+		},
+		"ensure_healthy": {
+			plan:     types.Cluster{EnsureHealthy: tftypes.BoolValue(true), WaitFor: tftypes.StringNull()},
+			expected: types.WaitForHealthy,
+		},
+		"wait_for": {
+			plan:     types.Cluster{EnsureHealthy: tftypes.BoolValue(false), WaitFor: tftypes.StringValue(types.WaitForNone)},
+			expected: types.WaitForNone,
+		},
+		"unknown wait_for": {
+			plan:     types.Cluster{WaitFor: tftypes.StringUnknown()},
+			expected: types.WaitForReconciled,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, clusterWaitFor(&tc.plan))
+		})
+	}
+}
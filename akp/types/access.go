@@ -49,6 +49,23 @@ type Team struct {
 	MemberCount types.Int64    `tfsdk:"member_count"`
 }
 
+// TeamMember maps the API's TeamMember: a single user, identified by Email,
+// inside the team TeamName. ID is the server-assigned member ID used to remove
+// the user from the team.
+type TeamMember struct {
+	ID       types.String `tfsdk:"id"`
+	TeamName types.String `tfsdk:"team_name"`
+	Email    types.String `tfsdk:"email"`
+}
+
+// TeamMembers is the authoritative set of users in the team TeamName. ID is
+// the team name, since teams have no separate ID.
+type TeamMembers struct {
+	ID       types.String   `tfsdk:"id"`
+	TeamName types.String   `tfsdk:"team_name"`
+	Emails   []types.String `tfsdk:"emails"`
+}
+
 // WorkspaceMember maps the API's WorkspaceMember/WorkspaceMemberRef pair. A
 // member is a role plus exactly one of UserEmail or TeamName — the API's
 // `oneof member` also allows user_id, but operators have no way to discover a
@@ -63,3 +80,57 @@ type WorkspaceMember struct {
 	UserEmail   types.String `tfsdk:"user_email"`
 	TeamName    types.String `tfsdk:"team_name"`
 }
+
+// WorkspaceMembers is the authoritative member set of a workspace. Members
+// found on the workspace but not in Members are removed on apply. ID is the
+// workspace ID.
+type WorkspaceMembers struct {
+	ID          types.String            `tfsdk:"id"`
+	Workspace   types.String            `tfsdk:"workspace"`
+	WorkspaceID types.String            `tfsdk:"workspace_id"`
+	Members     []WorkspaceMembersEntry `tfsdk:"members"`
+}
+
+// WorkspaceMembersEntry is one member of WorkspaceMembers: a role plus exactly
+// one of UserEmail or TeamName, as in WorkspaceMember.
+type WorkspaceMembersEntry struct {
+	Role      types.String `tfsdk:"role"`
+	UserEmail types.String `tfsdk:"user_email"`
+	TeamName  types.String `tfsdk:"team_name"`
+}
+
+// Workspaces is the list of workspaces in the organization whose name matches
+// NameRegex. ID is the organization ID.
+type Workspaces struct {
+	ID         types.String `tfsdk:"id"`
+	NameRegex  types.String `tfsdk:"name_regex"`
+	Workspaces []Workspace  `tfsdk:"workspaces"`
+}
+
+// Teams is the list of organization teams whose name matches NameRegex. ID is
+// the organization ID.
+type Teams struct {
+	ID        types.String `tfsdk:"id"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	Teams     []Team       `tfsdk:"teams"`
+}
+
+// CustomRoles is the list of custom roles in one scope whose name matches
+// NameRegex. The scope is Workspace when set, the organization otherwise; ID
+// is the ID of that workspace or organization.
+type CustomRoles struct {
+	ID          types.String `tfsdk:"id"`
+	Workspace   types.String `tfsdk:"workspace"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	CustomRoles []CustomRole `tfsdk:"custom_roles"`
+}
+
+// ApiKeys is the list of API keys in one scope whose description matches
+// DescriptionRegex, scoped like CustomRoles. Secrets are never returned by
+// the API outside of key creation, so Secret is always empty.
+type ApiKeys struct {
+	ID               types.String `tfsdk:"id"`
+	Workspace        types.String `tfsdk:"workspace"`
+	DescriptionRegex types.String `tfsdk:"description_regex"`
+	ApiKeys          []ApiKey     `tfsdk:"api_keys"`
+}
@@ -32,6 +32,8 @@ type ClusterCustomization struct {
 	ServerSideDiffEnabled types.Bool   `tfsdk:"server_side_diff_enabled"`
 	Connectivity          types.String `tfsdk:"connectivity"`
 	CustomCaBundle        types.String `tfsdk:"custom_ca_bundle"`
+	ImageRegistryMirror   types.String `tfsdk:"image_registry_mirror"`
+	ImagePullSecrets      types.List   `tfsdk:"image_pull_secrets"`
 }
 
 type AppsetPolicy struct {
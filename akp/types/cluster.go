@@ -6,27 +6,41 @@ Copyright 2023 Akuity, Inc.
 package types
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type Cluster struct {
-	ID                            types.String `tfsdk:"id"`
-	InstanceID                    types.String `tfsdk:"instance_id"`
-	Name                          types.String `tfsdk:"name"`
-	Namespace                     types.String `tfsdk:"namespace"`
-	Labels                        types.Map    `tfsdk:"labels"`
-	Annotations                   types.Map    `tfsdk:"annotations"`
-	Spec                          *ClusterSpec `tfsdk:"spec"`
-	Kubeconfig                    *Kubeconfig  `tfsdk:"kube_config"`
-	RemoveAgentResourcesOnDestroy types.Bool   `tfsdk:"remove_agent_resources_on_destroy"`
-	ReapplyManifestsOnUpdate      types.Bool   `tfsdk:"reapply_manifests_on_update"`
-	EnsureHealthy                 types.Bool   `tfsdk:"ensure_healthy"`
+	ID                            types.String   `tfsdk:"id"`
+	InstanceID                    types.String   `tfsdk:"instance_id"`
+	Name                          types.String   `tfsdk:"name"`
+	Namespace                     types.String   `tfsdk:"namespace"`
+	Labels                        types.Map      `tfsdk:"labels"`
+	Annotations                   types.Map      `tfsdk:"annotations"`
+	LabelsAll                     types.Map      `tfsdk:"labels_all"`
+	AnnotationsAll                types.Map      `tfsdk:"annotations_all"`
+	Spec                          *ClusterSpec   `tfsdk:"spec"`
+	Kubeconfig                    *Kubeconfig    `tfsdk:"kube_config"`
+	RemoveAgentResourcesOnDestroy types.Bool     `tfsdk:"remove_agent_resources_on_destroy"`
+	ReapplyManifestsOnUpdate      types.Bool     `tfsdk:"reapply_manifests_on_update"`
+	ApplyStrategy                 types.String   `tfsdk:"apply_strategy"`
+	ForceConflicts                types.Bool     `tfsdk:"force_conflicts"`
+	PruneAgentResources           types.Bool     `tfsdk:"prune_agent_resources"`
+	PruneDryRun                   types.Bool     `tfsdk:"prune_dry_run"`
+	AllowUnmatchedPatchTargets    types.Bool     `tfsdk:"allow_unmatched_patch_targets"`
+	WaitForRollout                types.Bool     `tfsdk:"wait_for_rollout"`
+	ImageRegistryMirror           types.String   `tfsdk:"image_registry_mirror"`
+	ImagePullSecrets              types.List     `tfsdk:"image_pull_secrets"`
+	AgentManifestHash             types.String   `tfsdk:"agent_manifest_hash"`
+	EnsureHealthy                 types.Bool     `tfsdk:"ensure_healthy"`
+	WaitFor                       types.String   `tfsdk:"wait_for"`
+	Timeouts                      timeouts.Value `tfsdk:"timeouts"`
 }
 
 type Clusters struct {
-	ID         types.String `tfsdk:"id"`
-	InstanceID types.String `tfsdk:"instance_id"`
-	Clusters   []Cluster    `tfsdk:"clusters"`
+	ID         types.String        `tfsdk:"id"`
+	InstanceID types.String        `tfsdk:"instance_id"`
+	Clusters   []ClusterDataSource `tfsdk:"clusters"`
 }
 
 type ClusterSpec struct {
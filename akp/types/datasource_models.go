@@ -200,9 +200,154 @@ func NewKargoInstanceDataSourceModel(instance *KargoInstance) KargoInstanceDataS
 	return model
 }
 
+// ClusterDataSource is the model of the akp_cluster data source, and of each
+// cluster of akp_clusters. It is Cluster without the operation timeouts of
+// the managed resource.
+type ClusterDataSource struct {
+	ID                            types.String `tfsdk:"id"`
+	InstanceID                    types.String `tfsdk:"instance_id"`
+	Name                          types.String `tfsdk:"name"`
+	Namespace                     types.String `tfsdk:"namespace"`
+	Labels                        types.Map    `tfsdk:"labels"`
+	Annotations                   types.Map    `tfsdk:"annotations"`
+	LabelsAll                     types.Map    `tfsdk:"labels_all"`
+	AnnotationsAll                types.Map    `tfsdk:"annotations_all"`
+	Spec                          *ClusterSpec `tfsdk:"spec"`
+	Kubeconfig                    *Kubeconfig  `tfsdk:"kube_config"`
+	RemoveAgentResourcesOnDestroy types.Bool   `tfsdk:"remove_agent_resources_on_destroy"`
+	ReapplyManifestsOnUpdate      types.Bool   `tfsdk:"reapply_manifests_on_update"`
+	ApplyStrategy                 types.String `tfsdk:"apply_strategy"`
+	ForceConflicts                types.Bool   `tfsdk:"force_conflicts"`
+	PruneAgentResources           types.Bool   `tfsdk:"prune_agent_resources"`
+	PruneDryRun                   types.Bool   `tfsdk:"prune_dry_run"`
+	AllowUnmatchedPatchTargets    types.Bool   `tfsdk:"allow_unmatched_patch_targets"`
+	WaitForRollout                types.Bool   `tfsdk:"wait_for_rollout"`
+	ImageRegistryMirror           types.String `tfsdk:"image_registry_mirror"`
+	ImagePullSecrets              types.List   `tfsdk:"image_pull_secrets"`
+	AgentManifestHash             types.String `tfsdk:"agent_manifest_hash"`
+	EnsureHealthy                 types.Bool   `tfsdk:"ensure_healthy"`
+	WaitFor                       types.String `tfsdk:"wait_for"`
+}
+
+func NewClusterDataSourceModel(cluster *Cluster) ClusterDataSource {
+	return ClusterDataSource{
+		ID:                            cluster.ID,
+		InstanceID:                    cluster.InstanceID,
+		Name:                          cluster.Name,
+		Namespace:                     cluster.Namespace,
+		Labels:                        cluster.Labels,
+		Annotations:                   cluster.Annotations,
+		LabelsAll:                     cluster.LabelsAll,
+		AnnotationsAll:                cluster.AnnotationsAll,
+		Spec:                          cluster.Spec,
+		Kubeconfig:                    cluster.Kubeconfig,
+		RemoveAgentResourcesOnDestroy: cluster.RemoveAgentResourcesOnDestroy,
+		ReapplyManifestsOnUpdate:      cluster.ReapplyManifestsOnUpdate,
+		ApplyStrategy:                 cluster.ApplyStrategy,
+		ForceConflicts:                cluster.ForceConflicts,
+		PruneAgentResources:           cluster.PruneAgentResources,
+		PruneDryRun:                   cluster.PruneDryRun,
+		AllowUnmatchedPatchTargets:    cluster.AllowUnmatchedPatchTargets,
+		WaitForRollout:                cluster.WaitForRollout,
+		ImageRegistryMirror:           cluster.ImageRegistryMirror,
+		ImagePullSecrets:              cluster.ImagePullSecrets,
+		AgentManifestHash:             cluster.AgentManifestHash,
+		EnsureHealthy:                 cluster.EnsureHealthy,
+		WaitFor:                       cluster.WaitFor,
+	}
+}
+
+// KargoAgentDataSource is the model of the akp_kargo_agent data source, and of
+// each agent of akp_kargo_agents. It is KargoAgent without the operation
+// timeouts of the managed resource.
+type KargoAgentDataSource struct {
+	ID                            types.String    `tfsdk:"id"`
+	InstanceID                    types.String    `tfsdk:"instance_id"`
+	Workspace                     types.String    `tfsdk:"workspace"`
+	Name                          types.String    `tfsdk:"name"`
+	Namespace                     types.String    `tfsdk:"namespace"`
+	Labels                        types.Map       `tfsdk:"labels"`
+	Annotations                   types.Map       `tfsdk:"annotations"`
+	LabelsAll                     types.Map       `tfsdk:"labels_all"`
+	AnnotationsAll                types.Map       `tfsdk:"annotations_all"`
+	Spec                          *KargoAgentSpec `tfsdk:"spec"`
+	Kubeconfig                    *Kubeconfig     `tfsdk:"kube_config"`
+	RemoveAgentResourcesOnDestroy types.Bool      `tfsdk:"remove_agent_resources_on_destroy"`
+	ReapplyManifestsOnUpdate      types.Bool      `tfsdk:"reapply_manifests_on_update"`
+	ApplyStrategy                 types.String    `tfsdk:"apply_strategy"`
+	ForceConflicts                types.Bool      `tfsdk:"force_conflicts"`
+	PruneAgentResources           types.Bool      `tfsdk:"prune_agent_resources"`
+	PruneDryRun                   types.Bool      `tfsdk:"prune_dry_run"`
+	AllowUnmatchedPatchTargets    types.Bool      `tfsdk:"allow_unmatched_patch_targets"`
+	WaitForRollout                types.Bool      `tfsdk:"wait_for_rollout"`
+	ImageRegistryMirror           types.String    `tfsdk:"image_registry_mirror"`
+	ImagePullSecrets              types.List      `tfsdk:"image_pull_secrets"`
+	AgentManifestHash             types.String    `tfsdk:"agent_manifest_hash"`
+	WaitFor                       types.String    `tfsdk:"wait_for"`
+}
+
+func NewKargoAgentDataSourceModel(agent *KargoAgent) KargoAgentDataSource {
+	return KargoAgentDataSource{
+		ID:                            agent.ID,
+		InstanceID:                    agent.InstanceID,
+		Workspace:                     agent.Workspace,
+		Name:                          agent.Name,
+		Namespace:                     agent.Namespace,
+		Labels:                        agent.Labels,
+		Annotations:                   agent.Annotations,
+		LabelsAll:                     agent.LabelsAll,
+		AnnotationsAll:                agent.AnnotationsAll,
+		Spec:                          agent.Spec,
+		Kubeconfig:                    agent.Kubeconfig,
+		RemoveAgentResourcesOnDestroy: agent.RemoveAgentResourcesOnDestroy,
+		ReapplyManifestsOnUpdate:      agent.ReapplyManifestsOnUpdate,
+		ApplyStrategy:                 agent.ApplyStrategy,
+		ForceConflicts:                agent.ForceConflicts,
+		PruneAgentResources:           agent.PruneAgentResources,
+		PruneDryRun:                   agent.PruneDryRun,
+		AllowUnmatchedPatchTargets:    agent.AllowUnmatchedPatchTargets,
+		WaitForRollout:                agent.WaitForRollout,
+		ImageRegistryMirror:           agent.ImageRegistryMirror,
+		ImagePullSecrets:              agent.ImagePullSecrets,
+		AgentManifestHash:             agent.AgentManifestHash,
+		WaitFor:                       agent.WaitFor,
+	}
+}
+
 func normalizeStringMap(value types.Map) types.Map {
 	if value.ElementType(context.Background()) == nil {
 		return types.MapNull(types.StringType)
 	}
 	return value
 }
+
+// Instances is the list of Argo CD instances matching the optional Workspace,
+// NameRegex and Health filters. ID is the workspace ID when Workspace is set,
+// the organization ID otherwise.
+type Instances struct {
+	ID        types.String      `tfsdk:"id"`
+	Workspace types.String      `tfsdk:"workspace"`
+	NameRegex types.String      `tfsdk:"name_regex"`
+	Health    types.String      `tfsdk:"health"`
+	Instances []InstanceSummary `tfsdk:"instances"`
+}
+
+// KargoInstances is the Kargo counterpart of Instances.
+type KargoInstances struct {
+	ID        types.String      `tfsdk:"id"`
+	Workspace types.String      `tfsdk:"workspace"`
+	NameRegex types.String      `tfsdk:"name_regex"`
+	Health    types.String      `tfsdk:"health"`
+	Instances []InstanceSummary `tfsdk:"instances"`
+}
+
+// InstanceSummary is the lightweight projection of an Argo CD or Kargo
+// instance returned by the list data sources, instead of the full export.
+type InstanceSummary struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Workspace types.String `tfsdk:"workspace"`
+	Version   types.String `tfsdk:"version"`
+	Fqdn      types.String `tfsdk:"fqdn"`
+	Health    types.String `tfsdk:"health"`
+}
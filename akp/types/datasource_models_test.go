@@ -29,11 +29,40 @@ var instanceDataSourceExcludedTags = map[string]struct{}{
 	"repo_credential_secrets":          {},
 	"repo_template_credential_secrets": {},
 	"metrics_ingress_password_hash":    {},
+	// Operation timeouts and waits only apply to the managed resource.
+	"timeouts": {},
+	"wait_for": {},
+	// Write-only secrets and their versions are never read back.
+	"argocd_secret_wo":                            {},
+	"argocd_secret_wo_version":                    {},
+	"application_set_secret_wo":                   {},
+	"application_set_secret_wo_version":           {},
+	"argocd_notifications_secret_wo":              {},
+	"argocd_notifications_secret_wo_version":      {},
+	"argocd_image_updater_secret_wo":              {},
+	"argocd_image_updater_secret_wo_version":      {},
+	"repo_credential_secrets_wo":                  {},
+	"repo_credential_secrets_wo_version":          {},
+	"repo_template_credential_secrets_wo":         {},
+	"repo_template_credential_secrets_wo_version": {},
 }
 
 var kargoDataSourceExcludedTags = map[string]struct{}{
 	"kargo_secret":      {},
 	"dex_config_secret": {},
+	// Operation timeouts and waits only apply to the managed resource.
+	"timeouts": {},
+	"wait_for": {},
+	// Write-only secrets and their versions are never read back.
+	"kargo_secret_wo":         {},
+	"kargo_secret_wo_version": {},
+}
+
+// agentDataSourceExcludedTags are the fields of the Cluster and KargoAgent
+// resource models that their data sources leave out.
+var agentDataSourceExcludedTags = map[string]struct{}{
+	// Operation timeouts only apply to the managed resource.
+	"timeouts": {},
 }
 
 // If this test fails, a new non-secret field was added to the resource model
@@ -56,6 +85,26 @@ func TestNewKargoInstanceDataSourceModelMatchesResourceModel(t *testing.T) {
 	assertProjectionMatches(t, reflect.ValueOf(instance), reflect.ValueOf(projected), kargoDataSourceExcludedTags, "kargo_instance", "NewKargoInstanceDataSourceModel")
 }
 
+// If this test fails, a new field was added to the resource model without
+// being added to the data source projection, or a projected field was added
+// but not populated by NewClusterDataSourceModel.
+func TestNewClusterDataSourceModelMatchesResourceModel(t *testing.T) {
+	cluster := populateProjectionFixture[Cluster](t, "cluster")
+	projected := NewClusterDataSourceModel(&cluster)
+
+	assertProjectionMatches(t, reflect.ValueOf(cluster), reflect.ValueOf(projected), agentDataSourceExcludedTags, "cluster", "NewClusterDataSourceModel")
+}
+
+// If this test fails, a new field was added to the resource model without
+// being added to the data source projection, or a projected field was added
+// but not populated by NewKargoAgentDataSourceModel.
+func TestNewKargoAgentDataSourceModelMatchesResourceModel(t *testing.T) {
+	agent := populateProjectionFixture[KargoAgent](t, "kargo_agent")
+	projected := NewKargoAgentDataSourceModel(&agent)
+
+	assertProjectionMatches(t, reflect.ValueOf(agent), reflect.ValueOf(projected), agentDataSourceExcludedTags, "kargo_agent", "NewKargoAgentDataSourceModel")
+}
+
 func populateProjectionFixture[T any](t *testing.T, root string) T {
 	t.Helper()
 
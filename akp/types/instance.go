@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -30,27 +31,46 @@ type Instance struct {
 	RepoTemplateCredentialSecrets types.Map                          `tfsdk:"repo_template_credential_secrets"`
 	ConfigManagementPlugins       map[string]*ConfigManagementPlugin `tfsdk:"config_management_plugins"`
 	ArgoCDResources               types.Map                          `tfsdk:"argocd_resources"`
+	AllowUnmatchedPatchTargets    types.Bool                         `tfsdk:"allow_unmatched_patch_targets"`
+	WaitFor                       types.String                       `tfsdk:"wait_for"`
+	Timeouts                      timeouts.Value                     `tfsdk:"timeouts"`
+
+	// Write-only counterparts of the secrets above. Terraform never stores
+	// them; they are read from the configuration on create and update, and
+	// the matching version attribute is what triggers an update.
+	ArgoCDSecretWO                         types.Map   `tfsdk:"argocd_secret_wo"`
+	ArgoCDSecretWOVersion                  types.Int64 `tfsdk:"argocd_secret_wo_version"`
+	ApplicationSetSecretWO                 types.Map   `tfsdk:"application_set_secret_wo"`
+	ApplicationSetSecretWOVersion          types.Int64 `tfsdk:"application_set_secret_wo_version"`
+	NotificationsSecretWO                  types.Map   `tfsdk:"argocd_notifications_secret_wo"`
+	NotificationsSecretWOVersion           types.Int64 `tfsdk:"argocd_notifications_secret_wo_version"`
+	ImageUpdaterSecretWO                   types.Map   `tfsdk:"argocd_image_updater_secret_wo"`
+	ImageUpdaterSecretWOVersion            types.Int64 `tfsdk:"argocd_image_updater_secret_wo_version"`
+	RepoCredentialSecretsWO                types.Map   `tfsdk:"repo_credential_secrets_wo"`
+	RepoCredentialSecretsWOVersion         types.Int64 `tfsdk:"repo_credential_secrets_wo_version"`
+	RepoTemplateCredentialSecretsWO        types.Map   `tfsdk:"repo_template_credential_secrets_wo"`
+	RepoTemplateCredentialSecretsWOVersion types.Int64 `tfsdk:"repo_template_credential_secrets_wo_version"`
 }
 
 func (i *Instance) GetSensitiveStrings(ctx context.Context, diagnostics *diag.Diagnostics) []string {
 	var res []string
-	res = append(res, GetSensitiveStrings(i.ArgoCDSecret)...)
-	res = append(res, GetSensitiveStrings(i.NotificationsSecret)...)
-	res = append(res, GetSensitiveStrings(i.ImageUpdaterSecret)...)
-	res = append(res, GetSensitiveStrings(i.ApplicationSetSecret)...)
-	var repoCredentialSecrets map[string]types.Map
-	if !i.RepoCredentialSecrets.IsNull() {
-		diagnostics.Append(i.RepoCredentialSecrets.ElementsAs(ctx, &repoCredentialSecrets, true)...)
-	}
-	for _, secret := range repoCredentialSecrets {
+	for _, secret := range []types.Map{
+		i.ArgoCDSecret, i.NotificationsSecret, i.ImageUpdaterSecret, i.ApplicationSetSecret,
+		i.ArgoCDSecretWO, i.NotificationsSecretWO, i.ImageUpdaterSecretWO, i.ApplicationSetSecretWO,
+	} {
 		res = append(res, GetSensitiveStrings(secret)...)
 	}
-	var repoTemplateCredentialSecrets map[string]types.Map
-	if !i.RepoTemplateCredentialSecrets.IsNull() {
-		diagnostics.Append(i.RepoTemplateCredentialSecrets.ElementsAs(ctx, &repoTemplateCredentialSecrets, true)...)
-	}
-	for _, secret := range repoTemplateCredentialSecrets {
-		res = append(res, GetSensitiveStrings(secret)...)
+	for _, secrets := range []types.Map{
+		i.RepoCredentialSecrets, i.RepoTemplateCredentialSecrets,
+		i.RepoCredentialSecretsWO, i.RepoTemplateCredentialSecretsWO,
+	} {
+		var secretMap map[string]types.Map
+		if !secrets.IsNull() && !secrets.IsUnknown() {
+			diagnostics.Append(secrets.ElementsAs(ctx, &secretMap, true)...)
+		}
+		for _, secret := range secretMap {
+			res = append(res, GetSensitiveStrings(secret)...)
+		}
 	}
 	return res
 }
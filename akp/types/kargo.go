@@ -59,6 +59,8 @@ type KargoAgentCustomization struct {
 	Kustomization       types.String `tfsdk:"kustomization"`
 	Connectivity        types.String `tfsdk:"connectivity"`
 	CustomCaBundle      types.String `tfsdk:"custom_ca_bundle"`
+	ImageRegistryMirror types.String `tfsdk:"image_registry_mirror"`
+	ImagePullSecrets    types.List   `tfsdk:"image_pull_secrets"`
 }
 
 type KargoInstanceSpec struct {
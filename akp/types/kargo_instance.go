@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,13 +18,20 @@ import (
 )
 
 type KargoInstance struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Kargo          *Kargo       `tfsdk:"kargo"`
-	KargoConfigMap types.Map    `tfsdk:"kargo_cm"`
-	KargoSecret    types.Map    `tfsdk:"kargo_secret"`
-	Workspace      types.String `tfsdk:"workspace"`
-	KargoResources types.Map    `tfsdk:"kargo_resources"`
+	ID                         types.String   `tfsdk:"id"`
+	Name                       types.String   `tfsdk:"name"`
+	Kargo                      *Kargo         `tfsdk:"kargo"`
+	KargoConfigMap             types.Map      `tfsdk:"kargo_cm"`
+	KargoSecret                types.Map      `tfsdk:"kargo_secret"`
+	Workspace                  types.String   `tfsdk:"workspace"`
+	KargoResources             types.Map      `tfsdk:"kargo_resources"`
+	AllowUnmatchedPatchTargets types.Bool     `tfsdk:"allow_unmatched_patch_targets"`
+	WaitFor                    types.String   `tfsdk:"wait_for"`
+	Timeouts                   timeouts.Value `tfsdk:"timeouts"`
+
+	// Write-only counterpart of KargoSecret, see Instance.ArgoCDSecretWO.
+	KargoSecretWO        types.Map   `tfsdk:"kargo_secret_wo"`
+	KargoSecretWOVersion types.Int64 `tfsdk:"kargo_secret_wo_version"`
 }
 
 func (k *KargoInstance) Update(ctx context.Context, diagnostics *diag.Diagnostics, exportResp *kargov1.ExportKargoInstanceResponse, agentMaps *AgentMaps, isDataSource bool) error {
@@ -38,6 +38,16 @@ var (
 		// TF-only fields for KargoAgent
 		"remove_agent_resources_on_destroy": TFOnlyField(types.BoolValue(true)),
 		"reapply_manifests_on_update":       TFOnlyField(types.BoolValue(false)),
+		"apply_strategy":                    TFOnlyField(types.StringValue(ApplyStrategyClientSide)),
+		"force_conflicts":                   TFOnlyField(types.BoolValue(false)),
+		"prune_agent_resources":             TFOnlyField(types.BoolValue(true)),
+		"prune_dry_run":                     TFOnlyField(types.BoolValue(false)),
+		"allow_unmatched_patch_targets":     TFOnlyField(types.BoolValue(false)),
+		"wait_for_rollout":                  TFOnlyField(types.BoolValue(false)),
+		"image_registry_mirror":             TFOnlyField(types.StringNull()),
+		"image_pull_secrets":                TFOnlyField(types.ListNull(types.StringType)),
+		"agent_manifest_hash":               TFOnlyField(types.StringNull()),
+		"wait_for":                          TFOnlyField(types.StringNull()),
 		// Enum fields: protojson outputs proto names, TF expects lowercase
 		"data.size": ProtoEnumToLowerString(kargoAgentSizeProtoToTF),
 		// Connectivity enum: normalize proto name to public/private, defaulting to public when unset
@@ -74,6 +84,8 @@ func (ka *KargoAgent) Update(ctx context.Context, diagnostics *diag.Diagnostics,
 	diagnostics.Append(d...)
 	ka.Labels = labels
 	ka.Annotations = annotations
+	ka.LabelsAll = labels
+	ka.AnnotationsAll = annotations
 
 	if ka.RemoveAgentResourcesOnDestroy.IsUnknown() || ka.RemoveAgentResourcesOnDestroy.IsNull() {
 		ka.RemoveAgentResourcesOnDestroy = types.BoolValue(true)
@@ -81,6 +93,36 @@ func (ka *KargoAgent) Update(ctx context.Context, diagnostics *diag.Diagnostics,
 	if ka.ReapplyManifestsOnUpdate.IsUnknown() || ka.ReapplyManifestsOnUpdate.IsNull() {
 		ka.ReapplyManifestsOnUpdate = types.BoolValue(false)
 	}
+	if ka.ApplyStrategy.IsUnknown() || ka.ApplyStrategy.IsNull() {
+		ka.ApplyStrategy = types.StringValue(ApplyStrategyClientSide)
+	}
+	if ka.ForceConflicts.IsUnknown() || ka.ForceConflicts.IsNull() {
+		ka.ForceConflicts = types.BoolValue(false)
+	}
+	if ka.PruneAgentResources.IsUnknown() || ka.PruneAgentResources.IsNull() {
+		ka.PruneAgentResources = types.BoolValue(true)
+	}
+	if ka.PruneDryRun.IsUnknown() || ka.PruneDryRun.IsNull() {
+		ka.PruneDryRun = types.BoolValue(false)
+	}
+	if ka.AllowUnmatchedPatchTargets.IsUnknown() || ka.AllowUnmatchedPatchTargets.IsNull() {
+		ka.AllowUnmatchedPatchTargets = types.BoolValue(false)
+	}
+	if ka.WaitForRollout.IsUnknown() || ka.WaitForRollout.IsNull() {
+		ka.WaitForRollout = types.BoolValue(false)
+	}
+	if ka.ImageRegistryMirror.IsUnknown() {
+		ka.ImageRegistryMirror = types.StringNull()
+	}
+	if ka.ImagePullSecrets.IsUnknown() || ka.ImagePullSecrets.IsNull() {
+		ka.ImagePullSecrets = types.ListNull(types.StringType)
+	}
+	if ka.AgentManifestHash.IsUnknown() {
+		ka.AgentManifestHash = types.StringNull()
+	}
+	if ka.WaitFor.IsUnknown() {
+		ka.WaitFor = types.StringNull()
+	}
 
 	if ka.Spec == nil {
 		ka.Spec = &KargoAgentSpec{}
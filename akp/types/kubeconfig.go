@@ -1,6 +1,9 @@
 package types
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type Kubeconfig struct {
 	Host                  types.String `tfsdk:"host"`
@@ -15,7 +18,9 @@ type Kubeconfig struct {
 	ConfigContext         types.String `tfsdk:"config_context"`
 	ConfigContextAuthInfo types.String `tfsdk:"config_context_auth_info"`
 	ConfigContextCluster  types.String `tfsdk:"config_context_cluster"`
+	ConfigRaw             types.String `tfsdk:"config_raw"`
 	Token                 types.String `tfsdk:"token"`
+	TokenFile             types.String `tfsdk:"token_file"`
 	ProxyUrl              types.String `tfsdk:"proxy_url"`
 	Exec                  *Exec        `tfsdk:"exec"`
 }
@@ -26,3 +31,59 @@ type Exec struct {
 	Args       types.List   `tfsdk:"args"`
 	Env        types.Map    `tfsdk:"env"`
 }
+
+// WithDefaults returns k with every unset attribute taken from defaults, the
+// provider-level kube_config. The config file sources (config_path,
+// config_paths and config_raw) and the token sources (token and token_file)
+// are each inherited as a group, so a resource that sets one of them does not
+// also pick up a conflicting one from the provider. It returns nil when
+// neither is set.
+func (k *Kubeconfig) WithDefaults(defaults *Kubeconfig) *Kubeconfig {
+	if defaults == nil {
+		return k
+	}
+	if k == nil {
+		merged := *defaults
+		return &merged
+	}
+	merged := *k
+	if !isSet(k.ConfigPath) && !isSet(k.ConfigPaths) && !isSet(k.ConfigRaw) {
+		merged.ConfigPath = defaults.ConfigPath
+		merged.ConfigPaths = defaults.ConfigPaths
+		merged.ConfigRaw = defaults.ConfigRaw
+	}
+	if !isSet(k.Token) && !isSet(k.TokenFile) {
+		merged.Token = defaults.Token
+		merged.TokenFile = defaults.TokenFile
+	}
+	for _, f := range []struct {
+		value *types.String
+		def   types.String
+	}{
+		{&merged.Host, defaults.Host},
+		{&merged.Username, defaults.Username},
+		{&merged.Password, defaults.Password},
+		{&merged.ClientCertificate, defaults.ClientCertificate},
+		{&merged.ClientKey, defaults.ClientKey},
+		{&merged.ClusterCaCertificate, defaults.ClusterCaCertificate},
+		{&merged.ConfigContext, defaults.ConfigContext},
+		{&merged.ConfigContextAuthInfo, defaults.ConfigContextAuthInfo},
+		{&merged.ConfigContextCluster, defaults.ConfigContextCluster},
+		{&merged.ProxyUrl, defaults.ProxyUrl},
+	} {
+		if !isSet(*f.value) {
+			*f.value = f.def
+		}
+	}
+	if !isSet(k.Insecure) {
+		merged.Insecure = defaults.Insecure
+	}
+	if k.Exec == nil {
+		merged.Exec = defaults.Exec
+	}
+	return &merged
+}
+
+func isSet(v attr.Value) bool {
+	return !v.IsNull() && !v.IsUnknown()
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKubeconfigWithDefaults(t *testing.T) {
+	defaults := &Kubeconfig{
+		Host:          tftypes.StringValue("https://default.example.com"),
+		Insecure:      tftypes.BoolValue(true),
+		ConfigPath:    tftypes.StringValue("~/.kube/config"),
+		ConfigPaths:   tftypes.ListNull(tftypes.StringType),
+		ConfigContext: tftypes.StringValue("default"),
+		Token:         tftypes.StringValue("default-token"),
+		Exec:          &Exec{Command: tftypes.StringValue("aws")},
+	}
+
+	t.Run("no defaults", func(t *testing.T) {
+		k := &Kubeconfig{Host: tftypes.StringValue("https://cluster.example.com")}
+		assert.Same(t, k, k.WithDefaults(nil))
+		assert.Nil(t, (*Kubeconfig)(nil).WithDefaults(nil))
+	})
+
+	t.Run("no resource block", func(t *testing.T) {
+		got := (*Kubeconfig)(nil).WithDefaults(defaults)
+		assert.Equal(t, defaults, got)
+		assert.NotSame(t, defaults, got)
+	})
+
+	t.Run("resource attributes override", func(t *testing.T) {
+		got := (&Kubeconfig{
+			Host:          tftypes.StringNull(),
+			Insecure:      tftypes.BoolValue(false),
+			ConfigPath:    tftypes.StringNull(),
+			ConfigPaths:   tftypes.ListNull(tftypes.StringType),
+			ConfigContext: tftypes.StringValue("prod"),
+			Token:         tftypes.StringNull(),
+		}).WithDefaults(defaults)
+		assert.Equal(t, "https://default.example.com", got.Host.ValueString())
+		assert.False(t, got.Insecure.ValueBool())
+		assert.Equal(t, "~/.kube/config", got.ConfigPath.ValueString())
+		assert.Equal(t, "prod", got.ConfigContext.ValueString())
+		assert.Equal(t, "default-token", got.Token.ValueString())
+		assert.Equal(t, defaults.Exec, got.Exec)
+	})
+
+	t.Run("config and token sources are inherited as a group", func(t *testing.T) {
+		got := (&Kubeconfig{
+			ConfigPath:  tftypes.StringNull(),
+			ConfigPaths: tftypes.ListNull(tftypes.StringType),
+			ConfigRaw:   tftypes.StringValue("apiVersion: v1"),
+			TokenFile:   tftypes.StringValue("/var/run/token"),
+		}).WithDefaults(defaults)
+		assert.True(t, got.ConfigPath.IsNull())
+		assert.Equal(t, "apiVersion: v1", got.ConfigRaw.ValueString())
+		assert.True(t, got.Token.IsNull())
+		assert.Equal(t, "/var/run/token", got.TokenFile.ValueString())
+		assert.True(t, got.ConfigPaths.IsNull())
+	})
+}
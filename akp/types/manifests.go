@@ -0,0 +1,27 @@
+package types
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/ephemeral/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AgentManifests is the model shared by the cluster and Kargo agent manifests
+// ephemeral resources. It only ever lives in memory for a single run, so the
+// agent credentials embedded in the manifests never reach state or plan files.
+type AgentManifests struct {
+	ID         types.String          `tfsdk:"id"`
+	InstanceID types.String          `tfsdk:"instance_id"`
+	Name       types.String          `tfsdk:"name"`
+	Manifests  types.String          `tfsdk:"manifests"`
+	Objects    []AgentManifestObject `tfsdk:"objects"`
+	Timeouts   timeouts.Value        `tfsdk:"timeouts"`
+}
+
+// AgentManifestObject is a single Kubernetes object of the agent install manifests.
+type AgentManifestObject struct {
+	APIVersion types.String `tfsdk:"api_version"`
+	Kind       types.String `tfsdk:"kind"`
+	Name       types.String `tfsdk:"name"`
+	Namespace  types.String `tfsdk:"namespace"`
+	Manifest   types.String `tfsdk:"manifest"`
+}
@@ -21,9 +21,24 @@ func GetSensitiveStrings(data types.Map) []string {
 	return res
 }
 
-func ToSecretAPIModel(ctx context.Context, diagnostics *diag.Diagnostics, name string, labels map[string]string, m types.Map) *v1.Secret {
+// ToSecretAPIModel builds the named Secret from m and its write-only
+// counterpart writeOnly. Either may be null; keys set in writeOnly take
+// precedence over the same keys in m.
+func ToSecretAPIModel(ctx context.Context, diagnostics *diag.Diagnostics, name string, labels map[string]string, m, writeOnly types.Map) *v1.Secret {
 	var data map[string]string
-	diagnostics.Append(m.ElementsAs(ctx, &data, true)...)
+	if !m.IsNull() {
+		diagnostics.Append(m.ElementsAs(ctx, &data, true)...)
+	}
+	if !writeOnly.IsNull() {
+		var woData map[string]string
+		diagnostics.Append(writeOnly.ElementsAs(ctx, &woData, true)...)
+		if data == nil {
+			data = make(map[string]string, len(woData))
+		}
+		for k, v := range woData {
+			data[k] = v
+		}
+	}
 	return &v1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Secret",
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSecretAPIModel(t *testing.T) {
+	tests := map[string]struct {
+		m         tftypes.Map
+		writeOnly tftypes.Map
+		expected  map[string]string
+	}{
+		"plain only": {
+			m:         stringMapValue(map[string]string{"a": "1"}),
+			writeOnly: tftypes.MapNull(tftypes.StringType),
+			expected:  map[string]string{"a": "1"},
+		},
+		"write-only only": {
+			m:         tftypes.MapNull(tftypes.StringType),
+			writeOnly: stringMapValue(map[string]string{"b": "2"}),
+			expected:  map[string]string{"b": "2"},
+		},
+		"write-only keys take precedence": {
+			m:         stringMapValue(map[string]string{"a": "1", "b": "plain"}),
+			writeOnly: stringMapValue(map[string]string{"b": "2"}),
+			expected:  map[string]string{"a": "1", "b": "2"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			secret := ToSecretAPIModel(context.Background(), &diags, "argocd-secret", nil, tc.m, tc.writeOnly)
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, "argocd-secret", secret.Name)
+			assert.Equal(t, tc.expected, secret.StringData)
+		})
+	}
+}
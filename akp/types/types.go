@@ -17,6 +17,27 @@ import (
 	"github.com/akuity/terraform-provider-akp/akp/marshal"
 )
 
+const (
+	// ApplyStrategyClientSide applies agent manifests with kubectl's client-side apply.
+	ApplyStrategyClientSide = "client-side"
+	// ApplyStrategyServerSide applies agent manifests with server-side apply.
+	ApplyStrategyServerSide = "server-side"
+)
+
+// Values of the wait_for attribute, from the weakest to the strongest
+// guarantee. Each mode also runs the phases of the modes before it.
+const (
+	// WaitForNone returns as soon as the platform accepted the change.
+	WaitForNone = "none"
+	// WaitForReconciled waits for the platform to reconcile the change.
+	WaitForReconciled = "reconciled"
+	// WaitForAgentConnected waits for the agent to connect and report its
+	// health, whether healthy or degraded.
+	WaitForAgentConnected = "agent-connected"
+	// WaitForHealthy waits for the resource to report a healthy status.
+	WaitForHealthy = "healthy"
+)
+
 var (
 	DirectClusterTypeString = map[argocdv1.DirectClusterType]string{
 		argocdv1.DirectClusterType_DIRECT_CLUSTER_TYPE_KARGO: "kargo",
@@ -62,7 +83,17 @@ var (
 		"data.custom_agent_size_config":     ExcludeFromAPI(),
 		"remove_agent_resources_on_destroy": TFOnlyField(types.BoolValue(true)),
 		"reapply_manifests_on_update":       TFOnlyField(types.BoolValue(false)),
+		"apply_strategy":                    TFOnlyField(types.StringValue(ApplyStrategyClientSide)),
+		"force_conflicts":                   TFOnlyField(types.BoolValue(false)),
+		"prune_agent_resources":             TFOnlyField(types.BoolValue(true)),
+		"prune_dry_run":                     TFOnlyField(types.BoolValue(false)),
+		"allow_unmatched_patch_targets":     TFOnlyField(types.BoolValue(false)),
+		"wait_for_rollout":                  TFOnlyField(types.BoolValue(false)),
+		"image_registry_mirror":             TFOnlyField(types.StringNull()),
+		"image_pull_secrets":                TFOnlyField(types.ListNull(types.StringType)),
+		"agent_manifest_hash":               TFOnlyField(types.StringNull()),
 		"ensure_healthy":                    TFOnlyField(types.BoolValue(false)),
+		"wait_for":                          TFOnlyField(types.StringNull()),
 		"namespace_scoped":                  HydrateFromAPIWhenPlanNull(),
 		// Write-only secret field
 		"spec.instance_spec.metrics_ingress_password_hash": PreserveFromPlan(),
@@ -98,6 +129,8 @@ func (c *Cluster) Update(ctx context.Context, diagnostics *diag.Diagnostics, api
 	diagnostics.Append(d...)
 	c.Labels = labels
 	c.Annotations = annotations
+	c.LabelsAll = labels
+	c.AnnotationsAll = annotations
 
 	if c.RemoveAgentResourcesOnDestroy.IsUnknown() || c.RemoveAgentResourcesOnDestroy.IsNull() {
 		c.RemoveAgentResourcesOnDestroy = types.BoolValue(true)
@@ -105,9 +138,39 @@ func (c *Cluster) Update(ctx context.Context, diagnostics *diag.Diagnostics, api
 	if c.ReapplyManifestsOnUpdate.IsUnknown() || c.ReapplyManifestsOnUpdate.IsNull() {
 		c.ReapplyManifestsOnUpdate = types.BoolValue(false)
 	}
+	if c.ApplyStrategy.IsUnknown() || c.ApplyStrategy.IsNull() {
+		c.ApplyStrategy = types.StringValue(ApplyStrategyClientSide)
+	}
+	if c.ForceConflicts.IsUnknown() || c.ForceConflicts.IsNull() {
+		c.ForceConflicts = types.BoolValue(false)
+	}
+	if c.PruneAgentResources.IsUnknown() || c.PruneAgentResources.IsNull() {
+		c.PruneAgentResources = types.BoolValue(true)
+	}
+	if c.PruneDryRun.IsUnknown() || c.PruneDryRun.IsNull() {
+		c.PruneDryRun = types.BoolValue(false)
+	}
+	if c.AllowUnmatchedPatchTargets.IsUnknown() || c.AllowUnmatchedPatchTargets.IsNull() {
+		c.AllowUnmatchedPatchTargets = types.BoolValue(false)
+	}
+	if c.WaitForRollout.IsUnknown() || c.WaitForRollout.IsNull() {
+		c.WaitForRollout = types.BoolValue(false)
+	}
+	if c.ImageRegistryMirror.IsUnknown() {
+		c.ImageRegistryMirror = types.StringNull()
+	}
+	if c.ImagePullSecrets.IsUnknown() || c.ImagePullSecrets.IsNull() {
+		c.ImagePullSecrets = types.ListNull(types.StringType)
+	}
+	if c.AgentManifestHash.IsUnknown() {
+		c.AgentManifestHash = types.StringNull()
+	}
 	if c.EnsureHealthy.IsUnknown() || c.EnsureHealthy.IsNull() {
 		c.EnsureHealthy = types.BoolValue(false)
 	}
+	if c.WaitFor.IsUnknown() {
+		c.WaitFor = types.StringNull()
+	}
 
 	if c.Spec == nil {
 		c.Spec = &ClusterSpec{}
@@ -0,0 +1,4 @@
+data "akp_api_keys" "example" {
+  workspace         = "platform"
+  description_regex = "^CI"
+}
@@ -0,0 +1,4 @@
+data "akp_custom_role" "example" {
+  workspace = "platform"
+  name      = "instance-viewer"
+}
@@ -0,0 +1,3 @@
+data "akp_custom_roles" "example" {
+  name_regex = "viewer"
+}
@@ -0,0 +1,18 @@
+data "akp_instances" "example" {
+  workspace  = "platform"
+  name_regex = "^team-"
+  health     = "healthy"
+}
+
+// Attach a shared cluster to every matching instance.
+resource "akp_cluster" "shared" {
+  for_each    = { for i in data.akp_instances.example.instances : i.name => i }
+  instance_id = each.value.id
+  name        = "shared"
+  namespace   = "akuity"
+  spec = {
+    data = {
+      size = "small"
+    }
+  }
+}
@@ -0,0 +1,3 @@
+data "akp_kargo_instances" "example" {
+  name_regex = "^team-"
+}
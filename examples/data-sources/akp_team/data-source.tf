@@ -0,0 +1,3 @@
+data "akp_team" "example" {
+  name = "platform"
+}
@@ -0,0 +1,3 @@
+data "akp_teams" "example" {
+  name_regex = "-admins$"
+}
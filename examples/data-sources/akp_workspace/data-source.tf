@@ -0,0 +1,5 @@
+data "akp_workspace" "default" {}
+
+data "akp_workspace" "example" {
+  name = "platform"
+}
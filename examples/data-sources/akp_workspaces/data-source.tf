@@ -0,0 +1,3 @@
+data "akp_workspaces" "example" {
+  name_regex = "^team-"
+}
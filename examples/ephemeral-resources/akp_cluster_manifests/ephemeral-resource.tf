@@ -0,0 +1,14 @@
+data "akp_instance" "example" {
+  name = "test"
+}
+
+ephemeral "akp_cluster_manifests" "example" {
+  instance_id = data.akp_instance.example.id
+  name        = "test-cluster"
+}
+
+# The rendered manifests can be passed to write-only arguments, provider
+# configuration or ephemeral module outputs, e.g. to hand them to a GitOps
+# pipeline without storing the agent credentials in state:
+#   ephemeral.akp_cluster_manifests.example.manifests
+#   [for o in ephemeral.akp_cluster_manifests.example.objects : yamldecode(o.manifest)]
@@ -0,0 +1,8 @@
+data "akp_kargo_instance" "example" {
+  name = "test"
+}
+
+ephemeral "akp_kargo_agent_manifests" "example" {
+  instance_id = data.akp_kargo_instance.example.id
+  name        = "test-agent"
+}
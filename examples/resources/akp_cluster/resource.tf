@@ -81,8 +81,9 @@ resource "akp_cluster" "example" {
     token       = "YOUR TOKEN"
   }
 
-  # Optional: Wait for the cluster to be healthy before completing the resource creation. Other states will be considered failures.
-  ensure_healthy = true
+  # Optional: Wait for the cluster agent to be healthy before completing the apply. Other states will be considered failures.
+  # One of `none`, `reconciled` (default), `agent-connected` or `healthy`.
+  wait_for = "healthy"
 
   # When using a Kubernetes token retrieved from a Terraform provider (e.g. aws_eks_cluster_auth or google_client_config) in the above `kube_config`,
   # the token value may change over time. This will cause Terraform to detect a diff in the `token` on each plan and apply.
@@ -0,0 +1,31 @@
+variable "github_token" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "akp_instance" "argocd" {
+  name = "argocd"
+  argocd = {
+    "spec" = {
+      "instance_spec" = {}
+      "version"       = "v2.11.4"
+    }
+  }
+
+  # The secret values are only sent to the Akuity Platform and are never
+  # written to the plan or state. Bump the version to push new values.
+  argocd_secret_wo = {
+    "webhook.github.secret" = var.github_token
+  }
+  argocd_secret_wo_version = 1
+
+  repo_credential_secrets_wo = {
+    "repo-my-private-repo" = {
+      "url"      = "https://github.com/my-org/my-private-repo"
+      "username" = "git"
+      "password" = var.github_token
+    }
+  }
+  repo_credential_secrets_wo_version = 1
+}
@@ -0,0 +1,10 @@
+// Add a single user to a team. Other members of the team are left untouched.
+resource "akp_team" "platform" {
+  name        = "platform"
+  description = "Platform engineering team"
+}
+
+resource "akp_team_member" "alice" {
+  team_name = akp_team.platform.name
+  email     = "alice@example.com"
+}
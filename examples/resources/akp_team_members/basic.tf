@@ -0,0 +1,14 @@
+// Authoritatively manage the users of a team. Users not listed here, including
+// users added in the UI, are removed from the team on the next apply.
+resource "akp_team" "operators" {
+  name        = "operators"
+  description = "On-call operators"
+}
+
+resource "akp_team_members" "operators" {
+  team_name = akp_team.operators.name
+  emails = [
+    "alice@example.com",
+    "bob@example.com",
+  ]
+}
@@ -0,0 +1,23 @@
+// Authoritatively manage the members of a workspace. Members not listed here,
+// including members added in the UI, are removed on the next apply.
+resource "akp_workspace" "platform" {
+  name = "platform"
+}
+
+resource "akp_team" "operators" {
+  name = "operators"
+}
+
+resource "akp_workspace_members" "platform" {
+  workspace = akp_workspace.platform.name
+  members = [
+    {
+      user_email = "alice@example.com"
+      role       = "admin"
+    },
+    {
+      team_name = akp_team.operators.name
+      role      = "member"
+    },
+  ]
+}